@@ -0,0 +1,133 @@
+// Package cursor generates and checks the signed pagination cursors shared by the Go services
+// and pages the Firestore queries with them
+package cursor
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor was altered or belongs to another ordering
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ErrMissingSecret is returned when a signer is created without a secret,
+// the cursors signed with an empty key could be forged by any client
+var ErrMissingSecret = errors.New("the cursor secret is not set")
+
+// Cursor holds the position of the last document returned by a paginated query
+type Cursor struct {
+	Field string `json:"f"`
+	Value any    `json:"v"`
+	ID    string `json:"id"`
+}
+
+// Signer signs and checks the cursors of a service with the service secret
+type Signer struct {
+	secret []byte
+}
+
+// NewSigner generates a new cursor signer
+//
+// Parameters:
+//   - secret: The HMAC key of the cursor signatures
+//
+// Returns:
+//   - *Signer: The cursor signer
+//   - error: An error that occured during the process
+func NewSigner(secret string) (*Signer, error) {
+	if secret == "" {
+		return nil, ErrMissingSecret
+	}
+
+	return &Signer{secret: []byte(secret)}, nil
+}
+
+// Encode generates an opaque signed token with the sort key and the ID of the last document.
+// The document ID is used as a tie-breaker when several documents share the same sort value
+//
+// Parameters:
+//   - field: The field the query is ordered by
+//   - value: The value of the ordering field of the last document
+//   - id: The ID of the last document
+//
+// Returns:
+//   - string: The cursor token
+//   - error: An error that occured during the process
+func (s *Signer) Encode(field string, value any, id string) (string, error) {
+	// Encode the cursor data
+	payload, err := json.Marshal(Cursor{Field: field, Value: value, ID: id})
+	if err != nil {
+		return "", err
+	}
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	return encodedPayload + "." + s.sign(encodedPayload), nil
+}
+
+// Decode checks the signature of a cursor token and returns its data
+//
+// Parameters:
+//   - token: The cursor token
+//   - field: The field the query is ordered by
+//
+// Returns:
+//   - Cursor: The cursor data
+//   - error: An error that occured during the process
+func (s *Signer) Decode(token string, field string) (Cursor, error) {
+	// Split the token into the payload and the signature
+	encodedPayload, signature, found := strings.Cut(token, ".")
+	if !found {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	// Check if the token was generated by the service
+	if !hmac.Equal([]byte(signature), []byte(s.sign(encodedPayload))) {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	// Decode the numbers as json numbers to keep the integer values
+	var cursor Cursor
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&cursor); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	// A cursor can only be used with the ordering it was generated for
+	if cursor.Field != field || cursor.ID == "" {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	if number, ok := cursor.Value.(json.Number); ok {
+		if value, err := number.Int64(); err == nil {
+			cursor.Value = value
+		} else if value, err := number.Float64(); err == nil {
+			cursor.Value = value
+		}
+	}
+
+	return cursor, nil
+}
+
+// sign is a private method that generates the signature of an encoded cursor payload
+//
+// Parameters:
+//   - encodedPayload: The encoded cursor data
+//
+// Returns:
+//   - string: The encoded signature
+func (s *Signer) sign(encodedPayload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encodedPayload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
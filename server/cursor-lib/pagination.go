@@ -0,0 +1,66 @@
+package cursor
+
+import (
+	firestore "cloud.google.com/go/firestore"
+)
+
+// OrderPageQuery orders a query by a field and by the document ID, then moves the query after the position
+// stored in the cursor. Ordering by the document ID keeps the pages stable when several documents share the same value
+//
+// Parameters:
+//   - query: The query to paginate
+//   - field: The ordering field
+//   - direction: The direction of the order
+//   - token: The cursor returned with the previous page, empty for the first page
+//
+// Returns:
+//   - firestore.Query: The ordered query
+//   - error: An error that occured during the process
+func (s *Signer) OrderPageQuery(query firestore.Query, field string, direction firestore.Direction, token string) (firestore.Query, error) {
+	query = query.OrderBy(field, direction).OrderBy(firestore.DocumentID, direction)
+
+	// Check if the cursor of the previous page was sent
+	if token == "" {
+		return query, nil
+	}
+
+	decodedCursor, err := s.Decode(token, field)
+	if err != nil {
+		return firestore.Query{}, err
+	}
+
+	return query.StartAfter(decodedCursor.Value, decodedCursor.ID), nil
+}
+
+// NextPageCursor generates the cursor of the page that follows a document
+//
+// Parameters:
+//   - doc: The snapshot of the last document of the page
+//   - field: The ordering field
+//
+// Returns:
+//   - string: The cursor token
+//   - error: An error that occured during the process
+func (s *Signer) NextPageCursor(doc *firestore.DocumentSnapshot, field string) (string, error) {
+	value, err := doc.DataAt(field)
+	if err != nil {
+		return "", err
+	}
+
+	return s.Encode(field, value, doc.Ref.ID)
+}
+
+// QueryDirection converts the order direction sent by the client
+//
+// Parameters:
+//   - orderDirection: The order direction, `asc` or `desc`
+//
+// Returns:
+//   - firestore.Direction: The Firestore direction
+func QueryDirection(orderDirection string) firestore.Direction {
+	if orderDirection == "asc" {
+		return firestore.Asc
+	}
+
+	return firestore.Desc
+}
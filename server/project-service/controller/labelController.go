@@ -0,0 +1,255 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/horatiucrisan/project-service/interfaces"
+	"github.com/horatiucrisan/project-service/middleware"
+	"github.com/horatiucrisan/project-service/model"
+	"github.com/horatiucrisan/project-service/rabbitmq"
+	"github.com/horatiucrisan/project-service/schemas"
+	"github.com/horatiucrisan/project-service/utils"
+)
+
+type labelController struct {
+	labelService interfaces.LabelService
+	logProducer  *rabbitmq.ProjectProducer
+}
+
+func NewLabelController(labelService interfaces.LabelService, logProducer *rabbitmq.ProjectProducer) interfaces.LabelController {
+	return &labelController{
+		labelService: labelService,
+		logProducer:  logProducer,
+	}
+}
+
+// POST methods
+
+func (c *labelController) CreateLabel(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the context token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Validate the user ID, the project ID and the request body data
+	inputData := schemas.CreateLabelSchema{
+		UserID:    user.UID,
+		ProjectID: chi.URLParam(r, "projectId"),
+	}
+
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to create the label
+	label, duration, err := utils.MeasureTime("Create-Label", func() (model.Label, error) {
+		return c.labelService.CreateLabel(r.Context(), inputData.UserID, inputData.ProjectID, inputData.Name, inputData.Color, inputData.Description)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), labelErrorStatus(err))
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.logProducer,
+		fmt.Sprintf("User `%s` created the label `%s` in the project `%s`", inputData.UserID, label.Name, inputData.ProjectID),
+		"audit",
+		http.StatusCreated,
+		duration,
+		label,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data into JSON format and return it
+	if err = utils.EncodeData(w, r, label); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// GET methods
+
+func (c *labelController) GetLabels(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the context token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Validate the user ID and the project ID
+	inputData := schemas.GetLabelsSchema{
+		UserID:    user.UID,
+		ProjectID: chi.URLParam(r, "projectId"),
+	}
+
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to retrieve the project labels
+	labels, duration, err := utils.MeasureTime("Get-Labels", func() ([]model.Label, error) {
+		return c.labelService.GetLabels(r.Context(), inputData.UserID, inputData.ProjectID)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), labelErrorStatus(err))
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.logProducer,
+		fmt.Sprintf("User `%s` retrieved the labels of the project `%s`", inputData.UserID, inputData.ProjectID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		labels,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data into JSON format and return it
+	if err = utils.EncodeData(w, r, labels); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// UPDATE methods
+
+func (c *labelController) UpdateLabel(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the context token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Validate the user ID, the project ID, the label ID and the request body data
+	inputData := schemas.UpdateLabelSchema{
+		UserID:    user.UID,
+		ProjectID: chi.URLParam(r, "projectId"),
+		LabelID:   chi.URLParam(r, "labelId"),
+	}
+
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to update the label and the tasks that use it
+	change, duration, err := utils.MeasureTime("Update-Label", func() (model.LabelChange, error) {
+		return c.labelService.UpdateLabel(r.Context(), inputData.UserID, inputData.ProjectID, inputData.LabelID, inputData.Name, inputData.Color, inputData.Description)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), labelErrorStatus(err))
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.logProducer,
+		fmt.Sprintf("User `%s` updated the label `%s` of the project `%s` and %d tasks", inputData.UserID, inputData.LabelID, inputData.ProjectID, change.UpdatedTasks),
+		"audit",
+		http.StatusAccepted,
+		duration,
+		change,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data into JSON format and return it
+	if err = utils.EncodeData(w, r, change); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// DELETE methods
+
+func (c *labelController) DeleteLabel(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the context token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Validate the user ID, the project ID and the label ID
+	inputData := schemas.DeleteLabelSchema{
+		UserID:    user.UID,
+		ProjectID: chi.URLParam(r, "projectId"),
+		LabelID:   chi.URLParam(r, "labelId"),
+	}
+
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to delete the label and remove it from the tasks
+	change, duration, err := utils.MeasureTime("Delete-Label", func() (model.LabelChange, error) {
+		return c.labelService.DeleteLabel(r.Context(), inputData.UserID, inputData.ProjectID, inputData.LabelID)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), labelErrorStatus(err))
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.logProducer,
+		fmt.Sprintf("User `%s` deleted the label `%s` of the project `%s` from %d tasks", inputData.UserID, change.Label.Name, inputData.ProjectID, change.UpdatedTasks),
+		"audit",
+		http.StatusAccepted,
+		duration,
+		change,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data into JSON format and return it
+	if err = utils.EncodeData(w, r, change); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// labelErrorStatus is a private function that returns the http status of a label service error
+//
+// Parameters:
+//   - err: The service layer error
+//
+// Returns:
+//   - int: The http status code
+func labelErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, utils.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, utils.ErrLabelExists):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
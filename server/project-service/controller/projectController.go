@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -154,8 +155,8 @@ func (c *projectController) GetProjects(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Get the ID of the last project retreived at the previous fetching request
-	startAfter := r.URL.Query().Get("startAfter")
+	// Get the cursor returned with the previous page
+	cursor := r.URL.Query().Get("cursor")
 
 	// Validate the data of the request
 	inputData := schemas.GetProjectsSchema{
@@ -163,7 +164,7 @@ func (c *projectController) GetProjects(w http.ResponseWriter, r *http.Request)
 		Limit:          limit,
 		OrderBy:        r.URL.Query().Get("orderBy"),
 		OrderDirection: r.URL.Query().Get("orderDirection"),
-		StartAfter:     &startAfter,
+		Cursor:         cursor,
 	}
 
 	if err = utils.ValidateParams(inputData); err != nil {
@@ -172,10 +173,18 @@ func (c *projectController) GetProjects(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Send the data to the service layer to retrieve the projects
+	var nextCursor string
 	projects, duration, err := utils.MeasureTime("Get-Projects", func() ([]model.Project, error) {
-		return c.projectService.GetProjects(r.Context(), inputData.Limit, inputData.OrderBy, inputData.OrderDirection, inputData.StartAfter)
+		var projects []model.Project
+		var err error
+		projects, nextCursor, err = c.projectService.GetProjects(r.Context(), inputData.Limit, inputData.OrderBy, inputData.OrderDirection, inputData.Cursor)
+		return projects, err
 	})
 	if err != nil {
+		if errors.Is(err, utils.ErrInvalidCursor) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -195,8 +204,8 @@ func (c *projectController) GetProjects(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Encode the data into JSON format and return it
-	if err = utils.EncodeData(w, r, projects); err != nil {
+	// Encode the data with the cursor of the next page into JSON format and return it
+	if err = utils.EncodePaginatedData(w, r, projects, nextCursor); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -276,9 +285,20 @@ func (c *projectController) GetUserProjects(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// Get the optional number of projects to retrieve
+	limit := 0
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		if limit, err = strconv.Atoi(limitParam); err != nil {
+			http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+			return
+		}
+	}
+
 	// Validate the user ID
 	inputData := schemas.GetUserProjectsSchema{
 		UserID: chi.URLParam(r, "userId"),
+		Limit:  limit,
+		Cursor: r.URL.Query().Get("cursor"),
 	}
 
 	if err = utils.ValidateParams(inputData); err != nil {
@@ -287,10 +307,18 @@ func (c *projectController) GetUserProjects(w http.ResponseWriter, r *http.Reque
 	}
 
 	// Send the data to the service layer to retrieve the projects the user is part of
+	var nextCursor string
 	projects, duration, err := utils.MeasureTime("Get-User-Projects", func() ([]model.Project, error) {
-		return c.projectService.GetUserProjects(r.Context(), inputData.UserID)
+		var projects []model.Project
+		var err error
+		projects, nextCursor, err = c.projectService.GetUserProjects(r.Context(), inputData.UserID, inputData.Limit, inputData.Cursor)
+		return projects, err
 	})
 	if err != nil {
+		if errors.Is(err, utils.ErrInvalidCursor) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -310,9 +338,8 @@ func (c *projectController) GetUserProjects(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	// Encode the data into JSON format and return it
-
-	if err = utils.EncodeData(w, r, projects); err != nil {
+	// Encode the data with the cursor of the next page into JSON format and return it
+	if err = utils.EncodePaginatedData(w, r, projects, nextCursor); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -71,3 +71,7 @@ require (
 	google.golang.org/grpc v1.72.0 // indirect
 	google.golang.org/protobuf v1.36.6 // indirect
 )
+
+require github.com/horatiucrisan/cursor-lib v0.0.0
+
+replace github.com/horatiucrisan/cursor-lib => ../cursor-lib
@@ -0,0 +1,13 @@
+package interfaces
+
+import "net/http"
+
+type LabelController interface {
+	CreateLabel(w http.ResponseWriter, r *http.Request)
+
+	GetLabels(w http.ResponseWriter, r *http.Request)
+
+	UpdateLabel(w http.ResponseWriter, r *http.Request)
+
+	DeleteLabel(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/horatiucrisan/project-service/model"
+)
+
+type LabelRepository interface {
+	CreateLabel(ctx context.Context, label model.Label) (model.Label, error)
+
+	GetLabels(ctx context.Context, projectId string) ([]model.Label, error)
+
+	UpdateLabel(ctx context.Context, projectId, labelId, name, color, description string) (model.LabelChange, error)
+
+	DeleteLabel(ctx context.Context, projectId, labelId string) (model.LabelChange, error)
+}
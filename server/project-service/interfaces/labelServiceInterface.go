@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/horatiucrisan/project-service/model"
+)
+
+type LabelService interface {
+	CreateLabel(ctx context.Context, userId, projectId, name, color, description string) (model.Label, error)
+
+	GetLabels(ctx context.Context, userId, projectId string) ([]model.Label, error)
+
+	UpdateLabel(ctx context.Context, userId, projectId, labelId, name, color, description string) (model.LabelChange, error)
+
+	DeleteLabel(ctx context.Context, userId, projectId, labelId string) (model.LabelChange, error)
+}
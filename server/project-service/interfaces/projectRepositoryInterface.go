@@ -11,9 +11,9 @@ type ProjectRepository interface {
 
 	IsCodeAvailable(ctx context.Context, code string) (bool, error)
 
-	GetProjects(ctx context.Context, limit int, orderBy, orderDirection string, startAfter *string) ([]model.Project, error)
+	GetProjects(ctx context.Context, limit int, orderBy, orderDirection string, cursor string) ([]model.Project, string, error)
 	GetProjectById(ctx context.Context, prjectId string) (model.Project, error)
-	GetUserProjects(ctx context.Context, userId string) ([]model.Project, error)
+	GetUserProjects(ctx context.Context, userId string, limit int, cursor string) ([]model.Project, string, error)
 
 	UpdateProjectTitle(ctx context.Context, projectId, title string) (model.Project, error)
 	UpdateProjectDescription(ctx context.Context, projectId, description string) (model.Project, error)
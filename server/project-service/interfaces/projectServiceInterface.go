@@ -10,9 +10,9 @@ type ProjectService interface {
 	CreateProject(ctx context.Context, title, description, managerId string, memberIds []string) (model.Project, error)
 	GenerateInvitationLink(ctx context.Context, projectId string) (string, error)
 
-	GetProjects(ctx context.Context, limit int, orderBy, orderDirection string, startAfter *string) ([]model.Project, error)
+	GetProjects(ctx context.Context, limit int, orderBy, orderDirection string, cursor string) ([]model.Project, string, error)
 	GetProjectById(ctx context.Context, projectId string) (model.Project, error)
-	GetUserProjects(ctx context.Context, userId string) ([]model.Project, error)
+	GetUserProjects(ctx context.Context, userId string, limit int, cursor string) ([]model.Project, string, error)
 
 	UpdateProjectTitle(ctx context.Context, projectId, title string) (model.Project, error)
 	UpdateProjectDescription(ctx context.Context, projectId, description string) (model.Project, error)
@@ -0,0 +1,15 @@
+package model
+
+type Label struct {
+	ID          string `firestore:"id" json:"id"`
+	ProjectID   string `firestore:"projectId" json:"projectId"`
+	Name        string `firestore:"name" json:"name"`
+	Color       string `firestore:"color" json:"color"`
+	Description string `firestore:"description" json:"description"`
+	CreatedAt   int64  `firestore:"createdAt" json:"createdAt"`
+}
+
+type LabelChange struct {
+	Label        Label `json:"label"`
+	UpdatedTasks int   `json:"updatedTasks"`
+}
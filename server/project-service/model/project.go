@@ -65,7 +65,8 @@ type NotificationUser struct {
 }
 
 type EncodedResponse struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
-	Data    any    `json:"data"`
+	Success    bool   `json:"success"`
+	Message    string `json:"message"`
+	Data       any    `json:"data"`
+	NextCursor string `json:"nextCursor,omitempty"`
 }
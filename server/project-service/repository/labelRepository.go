@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	firestore "cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/horatiucrisan/project-service/interfaces"
+	"github.com/horatiucrisan/project-service/model"
+	"github.com/horatiucrisan/project-service/utils"
+)
+
+type labelRepository struct {
+	client *firestore.Client
+}
+
+func NewLabelRepository(client *firestore.Client) interfaces.LabelRepository {
+	return &labelRepository{client: client}
+}
+
+// CreateLabel retrieves the label data from the service layer and adds it to the project label catalog.
+// The name check and the creation run inside a transaction so two labels with the same name cannot be added
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - label: The label data
+//
+// Returns:
+//   - model.Label: The created label
+//   - error: An error that occured during the process
+func (r *labelRepository) CreateLabel(ctx context.Context, label model.Label) (model.Label, error) {
+	labelsRef := r.labelsCollection(label.ProjectID)
+
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Check that no other label of the project has the same name
+		if err := r.checkLabelName(tx, labelsRef, label.Name, ""); err != nil {
+			return err
+		}
+
+		// Add the label to the catalog
+		return tx.Create(labelsRef.Doc(label.ID), label)
+	})
+	if err != nil {
+		return model.Label{}, err
+	}
+
+	return label, nil
+}
+
+// GetLabels returns the label catalog of a project ordered by name
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - projectId: The ID of the project
+//
+// Returns:
+//   - []model.Label: The list of project labels
+//   - error: An error that occured during the process
+func (r *labelRepository) GetLabels(ctx context.Context, projectId string) ([]model.Label, error) {
+	// Get all the labels of the project
+	docSnapshots, err := r.labelsCollection(projectId).OrderBy("name", firestore.Asc).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert the documents to label objects
+	labels := []model.Label{}
+	for _, doc := range docSnapshots {
+		var label model.Label
+		if err := doc.DataTo(&label); err != nil {
+			return nil, err
+		}
+
+		labels = append(labels, label)
+	}
+
+	return labels, nil
+}
+
+// UpdateLabel retrieves the new label data from the service layer and updates the label inside a transaction.
+// When the name changes, the tasks that use the label are renamed in the same transaction,
+// so the catalog and the tasks never disagree and concurrent label edits on a task are retried instead of lost
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - projectId: The ID of the project
+//   - labelId: The ID of the label
+//   - name: The new label name
+//   - color: The new label color
+//   - description: The new label description
+//
+// Returns:
+//   - model.LabelChange: The updated label data and the number of renamed tasks
+//   - error: An error that occured during the process
+func (r *labelRepository) UpdateLabel(ctx context.Context, projectId, labelId, name, color, description string) (model.LabelChange, error) {
+	labelsRef := r.labelsCollection(projectId)
+	docRef := labelsRef.Doc(labelId)
+
+	var change model.LabelChange
+
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Get the current label data
+		docSnapshot, err := tx.Get(docRef)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("label with ID %s not found. Failed to update label", labelId)
+			}
+			return err
+		}
+
+		var label model.Label
+		if err := docSnapshot.DataTo(&label); err != nil {
+			return err
+		}
+
+		// Check that the new name is not used by another label
+		if err := r.checkLabelName(tx, labelsRef, name, labelId); err != nil {
+			return err
+		}
+
+		// Get the tasks that use the old name before any write of the transaction
+		var taskSnapshots []*firestore.DocumentSnapshot
+		if label.Name != name {
+			if taskSnapshots, err = tx.Documents(r.labelTasks(projectId, label.Name)).GetAll(); err != nil {
+				return err
+			}
+		}
+
+		// Replace the old name, keeping the position of the label and skipping duplicates
+		for _, doc := range taskSnapshots {
+			labels := renameLabel(labelNames(doc), label.Name, name)
+			if err := tx.Update(doc.Ref, []firestore.Update{{Path: "labels", Value: labels}}); err != nil {
+				return err
+			}
+		}
+
+		// Set the new label data
+		label.Name = name
+		label.Color = color
+		label.Description = description
+
+		change = model.LabelChange{Label: label, UpdatedTasks: len(taskSnapshots)}
+		return tx.Set(docRef, label)
+	})
+	if err != nil {
+		return model.LabelChange{}, err
+	}
+
+	return change, nil
+}
+
+// DeleteLabel removes a label from the project label catalog and from the tasks that use it inside a single transaction
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - projectId: The ID of the project
+//   - labelId: The ID of the label
+//
+// Returns:
+//   - model.LabelChange: The deleted label data and the number of updated tasks
+//   - error: An error that occured during the process
+func (r *labelRepository) DeleteLabel(ctx context.Context, projectId, labelId string) (model.LabelChange, error) {
+	// Get the document reference
+	docRef := r.labelsCollection(projectId).Doc(labelId)
+
+	var change model.LabelChange
+
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Get the document snapshot and check if the label exists
+		docSnapshot, err := tx.Get(docRef)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("label with ID %s not found. Failed to delete label", labelId)
+			}
+			return err
+		}
+
+		var label model.Label
+		if err := docSnapshot.DataTo(&label); err != nil {
+			return err
+		}
+
+		// Get the tasks that use the label
+		taskSnapshots, err := tx.Documents(r.labelTasks(projectId, label.Name)).GetAll()
+		if err != nil {
+			return err
+		}
+
+		// Remove the label from the tasks before the label leaves the catalog
+		for _, doc := range taskSnapshots {
+			if err := tx.Update(doc.Ref, []firestore.Update{{Path: "labels", Value: firestore.ArrayRemove(label.Name)}}); err != nil {
+				return err
+			}
+		}
+
+		change = model.LabelChange{Label: label, UpdatedTasks: len(taskSnapshots)}
+		return tx.Delete(docRef)
+	})
+	if err != nil {
+		return model.LabelChange{}, err
+	}
+
+	return change, nil
+}
+
+// labelsCollection is a private method that returns the label subcollection of a project
+//
+// Parameters:
+//   - projectId: The ID of the project
+//
+// Returns:
+//   - *firestore.CollectionRef: The label subcollection reference
+func (r *labelRepository) labelsCollection(projectId string) *firestore.CollectionRef {
+	return r.client.Collection(utils.EnvInstances.PROJECTS_COLLECTION).Doc(projectId).Collection(utils.EnvInstances.LABELS_SUBCOLLECTION)
+}
+
+// labelTasks is a private method that returns the query of the tasks of a project that have a given label
+//
+// Parameters:
+//   - projectId: The ID of the project
+//   - name: The label name
+//
+// Returns:
+//   - firestore.Query: The task query
+func (r *labelRepository) labelTasks(projectId, name string) firestore.Query {
+	return r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).
+		Where("projectId", "==", projectId).
+		Where("labels", "array-contains", name)
+}
+
+// checkLabelName is a private method that checks, inside a transaction,
+// that no label of the project other than the excluded one has the same name ignoring the case
+//
+// Parameters:
+//   - tx: The firestore transaction
+//   - labelsRef: The label subcollection of the project
+//   - name: The label name
+//   - excludedId: The ID of the label being updated, empty when creating a label
+//
+// Returns:
+//   - error: utils.ErrLabelExists if the name is used, or an error that occured during the process
+func (r *labelRepository) checkLabelName(tx *firestore.Transaction, labelsRef *firestore.CollectionRef, name, excludedId string) error {
+	docSnapshots, err := tx.Documents(labelsRef).GetAll()
+	if err != nil {
+		return err
+	}
+
+	for _, doc := range docSnapshots {
+		var label model.Label
+		if err := doc.DataTo(&label); err != nil {
+			return err
+		}
+
+		if label.ID != excludedId && strings.EqualFold(label.Name, name) {
+			return utils.ErrLabelExists
+		}
+	}
+
+	return nil
+}
+
+// labelNames is a private function that returns the labels stored on a task document
+//
+// Parameters:
+//   - doc: The task document
+//
+// Returns:
+//   - []string: The task labels
+func labelNames(doc *firestore.DocumentSnapshot) []string {
+	var task struct {
+		Labels []string `firestore:"labels"`
+	}
+
+	if err := doc.DataTo(&task); err != nil {
+		return nil
+	}
+
+	return task.Labels
+}
+
+// renameLabel is a private function that replaces a label name inside a list of labels
+//
+// Parameters:
+//   - labels: The list of labels
+//   - oldName: The name to replace
+//   - newName: The new name
+//
+// Returns:
+//   - []string: The updated list without duplicates
+func renameLabel(labels []string, oldName, newName string) []string {
+	renamed := make([]string, 0, len(labels))
+	seen := map[string]bool{}
+
+	for _, label := range labels {
+		if label == oldName {
+			label = newName
+		}
+
+		if seen[label] {
+			continue
+		}
+
+		seen[label] = true
+		renamed = append(renamed, label)
+	}
+
+	return renamed
+}
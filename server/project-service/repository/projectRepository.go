@@ -9,6 +9,8 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	cursorlib "github.com/horatiucrisan/cursor-lib"
+
 	"github.com/horatiucrisan/project-service/interfaces"
 	"github.com/horatiucrisan/project-service/model"
 	"github.com/horatiucrisan/project-service/utils"
@@ -83,52 +85,22 @@ func (r *projectRepository) IsCodeAvailable(ctx context.Context, code string) (b
 //   - limit: The max number of projects to retrieve
 //   - orderBy: The project order field
 //   - orderDirection: The direction of the ordering
-//   - startAfter: The ID of the last project retrieved at the previous fetching request
+//   - cursor: The cursor returned with the previous page
 //
 // Returns:
 //   - []model.Project: The list of retrieved projects
+//   - string: The cursor of the next page, empty if there are no more projects
 //   - error: An error that occured during the fetching process
-func (r *projectRepository) GetProjects(ctx context.Context, limit int, orderBy, orderDirection string, startAfter *string) ([]model.Project, error) {
-	// Limit the number of projects retrieved at a time
-	query := r.client.Collection(utils.EnvInstances.PROJECTS_COLLECTION).Limit(limit)
-
-	// Query based on the order criteria and direction
-	if orderDirection == "asc" {
-		query = query.OrderBy(orderBy, firestore.Asc)
-	} else {
-		query = query.OrderBy(orderBy, firestore.Desc)
-	}
-
-	// Check if the ID of the last project was passed
-	if startAfter != nil && *startAfter != "" {
-		// Get the snapshot of the last project based on the ID
-		lasDocumentSanpshot, err := r.client.Collection(utils.EnvInstances.PROJECTS_COLLECTION).Doc(*startAfter).Get(ctx)
-		if err != nil {
-			return nil, err
-		}
-
-		// Order the documents collection
-		query = query.StartAfter(lasDocumentSanpshot.Data()[orderBy])
-	}
-
-	// Retrieve the documents
-	docs, err := query.Documents(ctx).GetAll()
+func (r *projectRepository) GetProjects(ctx context.Context, limit int, orderBy, orderDirection string, cursor string) ([]model.Project, string, error) {
+	// Query based on the order criteria and direction and start after the previous page
+	query, err := utils.CursorSigner.OrderPageQuery(r.client.Collection(utils.EnvInstances.PROJECTS_COLLECTION).Query, orderBy, cursorlib.QueryDirection(orderDirection), cursor)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	// Add each project into a list
-	projects := []model.Project{}
-	for _, doc := range docs {
-		var project model.Project
-		if err := doc.DataTo(&project); err != nil {
-			return nil, err
-		}
-
-		projects = append(projects, project)
-	}
-
-	return projects, nil
+	// Limit the number of projects retrieved at a time
+	// and get one more project to check if there is a next page
+	return r.getProjectsPage(ctx, query, orderBy, limit)
 }
 
 // GetProjectById retrieves the ID of the project from the service layer and retrieves the data from the database
@@ -167,63 +139,29 @@ func (r *projectRepository) GetProjectById(ctx context.Context, projectId string
 // Parameters:
 //   - ctx: Request-scoped context
 //   - userId: The ID of the user
+//   - limit: The max number of projects to retrieve, 0 to retrieve all of them
+//   - cursor: The cursor returned with the previous page
 //
 // Returns:
 //   - []model.Project: The list of projects the user is part of
+//   - string: The cursor of the next page, empty if there are no more projects
 //   - error: An error that occured during the fetching process
-func (r *projectRepository) GetUserProjects(ctx context.Context, userId string) ([]model.Project, error) {
-	// Get the document references where the user is the manager of the project
-	managerDocRefs := r.client.Collection(utils.EnvInstances.PROJECTS_COLLECTION).
-		Where("projectManagerId", "==", userId)
-
-	// Get the document references where the user is a member of the gorup
-	memberDocRefs := r.client.Collection(utils.EnvInstances.PROJECTS_COLLECTION).
-		Where("memberIds", "array-contains", userId)
-
-	// Get the document snapshots for the manager documents
-	managerDocSnapshots, err := managerDocRefs.Documents(ctx).GetAll()
-	if err != nil {
-		if status.Code(err) != codes.NotFound {
-			return nil, err
-		}
-	}
-
-	// Get the document snapshots for the member documents
-	memberDocSnapshots, err := memberDocRefs.Documents(ctx).GetAll()
+func (r *projectRepository) GetUserProjects(ctx context.Context, userId string, limit int, cursor string) ([]model.Project, string, error) {
+	// Get the projects where the user is the manager or a member of the project
+	query := r.client.Collection(utils.EnvInstances.PROJECTS_COLLECTION).WhereEntity(firestore.OrFilter{
+		Filters: []firestore.EntityFilter{
+			firestore.PropertyFilter{Path: "projectManagerId", Operator: "==", Value: userId},
+			firestore.PropertyFilter{Path: "memberIds", Operator: "array-contains", Value: userId},
+		},
+	})
+
+	// Order the projects by the newest ones and start after the previous page
+	query, err := utils.CursorSigner.OrderPageQuery(query, "createdAt", firestore.Desc, cursor)
 	if err != nil {
-		if status.Code(err) != codes.NotFound {
-			return nil, err
-		}
+		return nil, "", err
 	}
 
-	var projects []model.Project
-	seen := make(map[string]bool)
-
-	// Iterate over the manager snapshots and add each project to the list
-	for _, doc := range managerDocSnapshots {
-		var project model.Project
-		if err := doc.DataTo(&project); err != nil {
-			return nil, err
-		}
-		// Add each project to the list where the user is the manager
-		projects = append(projects, project)
-		seen[project.ID] = true
-	}
-
-	// Iterate over teh member snapshots and add each project to the list
-	for _, doc := range memberDocSnapshots {
-		var project model.Project
-		if err := doc.DataTo(&project); err != nil {
-			return nil, err
-		}
-
-		if seen[project.ID] {
-			continue // skip duplicate
-		}
-		projects = append(projects, project)
-	}
-
-	return projects, nil
+	return r.getProjectsPage(ctx, query, "createdAt", limit)
 }
 
 // UpdateProjectTitle retrieves the new title from the service layer and updates the project title from the database
@@ -548,3 +486,51 @@ func (r *projectRepository) DeleteProjectById(ctx context.Context, projectId str
 
 	return project, nil
 }
+
+// getProjectsPage is a private method that retrieves a page of projects
+// and generates the cursor of the next page
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - query: The ordered projects query
+//   - orderBy: The field the query is ordered by
+//   - limit: The max number of projects to retrieve, 0 to retrieve all of them
+//
+// Returns:
+//   - []model.Project: The list of retrieved projects
+//   - string: The cursor of the next page, empty if there are no more projects
+//   - error: An error that occured during the fetching process
+func (r *projectRepository) getProjectsPage(ctx context.Context, query firestore.Query, orderBy string, limit int) ([]model.Project, string, error) {
+	// Get one more project to check if there is a next page
+	if limit > 0 {
+		query = query.Limit(limit + 1)
+	}
+
+	// Retrieve the documents
+	docs, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Generate the cursor of the next page
+	nextCursor := ""
+	if limit > 0 && len(docs) > limit {
+		docs = docs[:limit]
+		if nextCursor, err = utils.CursorSigner.NextPageCursor(docs[limit-1], orderBy); err != nil {
+			return nil, "", err
+		}
+	}
+
+	// Add each project into a list
+	projects := []model.Project{}
+	for _, doc := range docs {
+		var project model.Project
+		if err := doc.DataTo(&project); err != nil {
+			return nil, "", err
+		}
+
+		projects = append(projects, project)
+	}
+
+	return projects, nextCursor, nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"net/http"
 
-	"firebase.google.com/go/v4/auth"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
 	"github.com/horatiucrisan/project-service/controller"
@@ -50,15 +49,24 @@ func NewRouter(userProducer *rabbitmq.UserProducer, logProducer, notificationPro
 
 	// Initialize the repository layer
 	projectRepo := repository.NewProjectRepository(firebaseClient)
+	labelRepo := repository.NewLabelRepository(firebaseClient)
 
 	// Initialize the service layer
 	projectService := service.NewProjectService(projectRepo)
+	labelService := service.NewLabelService(labelRepo, projectRepo)
 
 	// Initialize the controller layer
 	projectController := controller.NewProjectController(projectService, userProducer, logProducer, notificationProducer)
+	labelController := controller.NewLabelController(labelService, logProducer)
 
 	// Initialize the routes
-	projectRoutes(r, authClient, projectController)
+	r.Route(utils.EnvInstances.ROUTE, func(r chi.Router) {
+		// Use the user token validation method
+		r.Use(middleware.AuthMiddleware(authClient))
+
+		labelRoutes(r, labelController)
+		projectRoutes(r, projectController)
+	})
 
 	return r, nil
 }
@@ -67,31 +75,44 @@ func NewRouter(userProducer *rabbitmq.UserProducer, logProducer, notificationPro
 //
 // Parameters:
 //   - r: The go chi router
-//   - authClient: The firestore authentication client
 //   - projectController: The controller layer object
-func projectRoutes(r chi.Router, authClient *auth.Client, projectController interfaces.ProjectController) {
-	r.Route(utils.EnvInstances.ROUTE, func(r chi.Router) {
-		// Use the user token validation method
-		r.Use(middleware.AuthMiddleware(authClient))
+func projectRoutes(r chi.Router, projectController interfaces.ProjectController) {
+	// POST routes
+	r.Post("/", projectController.CreateProject)
+	r.Post("/{projectId}/link", projectController.GenerateInvitationLink)
+
+	//GET routes
+	r.Get("/", projectController.GetProjects)
+	r.Get("/{userId}/user", projectController.GetUserProjects)
+	r.Get("/{projectId}", projectController.GetProjectById)
+
+	// PUT routes
+	r.Put("/join", projectController.JoinProjectMembers)
+	r.Put("/{projectId}/title", projectController.UpdateProjectTitle)
+	r.Put("/{projectId}/description", projectController.UpdateProjectDescription)
+	r.Put("/{projectId}/manager", projectController.UpdateProjectManager)
+	r.Put("/{projectId}/addMembers", projectController.AddProjectMembers)
+	r.Put("/{projectId}/removeMembers", projectController.RemoveProjectMembers)
+
+	// DELETE routes
+	r.Delete("/{projectId}", projectController.DeleteProjectById)
+}
 
-		// POST routes
-		r.Post("/", projectController.CreateProject)
-		r.Post("/{projectId}/link", projectController.GenerateInvitationLink)
-
-		//GET routes
-		r.Get("/", projectController.GetProjects)
-		r.Get("/{userId}/user", projectController.GetUserProjects)
-		r.Get("/{projectId}", projectController.GetProjectById)
-
-		// PUT routes
-		r.Put("/join", projectController.JoinProjectMembers)
-		r.Put("/{projectId}/title", projectController.UpdateProjectTitle)
-		r.Put("/{projectId}/description", projectController.UpdateProjectDescription)
-		r.Put("/{projectId}/manager", projectController.UpdateProjectManager)
-		r.Put("/{projectId}/addMembers", projectController.AddProjectMembers)
-		r.Put("/{projectId}/removeMembers", projectController.RemoveProjectMembers)
-
-		// DELETE routes
-		r.Delete("/{projectId}", projectController.DeleteProjectById)
-	})
+// labelRoutes initializes the project label catalog routes
+//
+// Parameters:
+//   - r: The go chi router
+//   - labelController: The label controller layer object
+func labelRoutes(r chi.Router, labelController interfaces.LabelController) {
+	// POST routes
+	r.Post("/{projectId}/labels", labelController.CreateLabel)
+
+	// GET routes
+	r.Get("/{projectId}/labels", labelController.GetLabels)
+
+	// PUT routes
+	r.Put("/{projectId}/labels/{labelId}", labelController.UpdateLabel)
+
+	// DELETE routes
+	r.Delete("/{projectId}/labels/{labelId}", labelController.DeleteLabel)
 }
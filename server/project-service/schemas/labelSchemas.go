@@ -0,0 +1,29 @@
+package schemas
+
+type CreateLabelSchema struct {
+	UserID      string `validate:"required"`
+	ProjectID   string `validate:"required"`
+	Name        string `validate:"required,min=1,max=30"`
+	Color       string `validate:"required,hexcolor"`
+	Description string `validate:"omitempty,max=255"`
+}
+
+type GetLabelsSchema struct {
+	UserID    string `validate:"required"`
+	ProjectID string `validate:"required"`
+}
+
+type UpdateLabelSchema struct {
+	UserID      string `validate:"required"`
+	ProjectID   string `validate:"required"`
+	LabelID     string `validate:"required"`
+	Name        string `validate:"required,min=1,max=30"`
+	Color       string `validate:"required,hexcolor"`
+	Description string `validate:"omitempty,max=255"`
+}
+
+type DeleteLabelSchema struct {
+	UserID    string `validate:"required"`
+	ProjectID string `validate:"required"`
+	LabelID   string `validate:"required"`
+}
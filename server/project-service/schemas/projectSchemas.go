@@ -9,11 +9,11 @@ type CreateProjectSchema struct {
 }
 
 type GetProjectsSchema struct {
-	UserID         string  `validate:"required"`
-	Limit          int     `validate:"required,min=1"`
-	OrderBy        string  `validate:"required"`
-	OrderDirection string  `validate:"required"`
-	StartAfter     *string `validate:"omitempty"`
+	UserID         string `validate:"required"`
+	Limit          int    `validate:"required,min=1"`
+	OrderBy        string `validate:"required,oneof=createdAt title"`
+	OrderDirection string `validate:"required,oneof=asc desc"`
+	Cursor         string `validate:"omitempty"`
 }
 
 type GetProjectByIdSchema struct {
@@ -23,6 +23,8 @@ type GetProjectByIdSchema struct {
 
 type GetUserProjectsSchema struct {
 	UserID string `validate:"required"`
+	Limit  int    `validate:"omitempty,min=1"`
+	Cursor string `validate:"omitempty"`
 }
 
 type UpdateProjectTitleSchema struct {
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/horatiucrisan/project-service/interfaces"
+	"github.com/horatiucrisan/project-service/model"
+	"github.com/horatiucrisan/project-service/utils"
+)
+
+type labelService struct {
+	labelRepository   interfaces.LabelRepository
+	projectRepository interfaces.ProjectRepository
+}
+
+func NewLabelService(labelRepository interfaces.LabelRepository, projectRepository interfaces.ProjectRepository) interfaces.LabelService {
+	return &labelService{
+		labelRepository:   labelRepository,
+		projectRepository: projectRepository,
+	}
+}
+
+// CreateLabel retrieves the label data from the controller layer
+// and sends it to the repository layer to add it to the project label catalog.
+// Only the project manager can manage the labels of a project
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that creates the label
+//   - projectId: The ID of the project
+//   - name: The label name
+//   - color: The label color as a hex code
+//   - description: The label description
+//
+// Returns:
+//   - model.Label: The created label
+//   - error: An error that occured during the process
+func (s *labelService) CreateLabel(ctx context.Context, userId, projectId, name, color, description string) (model.Label, error) {
+	// Check if the user manages the project
+	if err := s.checkProjectAccess(ctx, userId, projectId, true); err != nil {
+		return model.Label{}, err
+	}
+
+	// Generate the label object
+	label := model.Label{
+		ID:          uuid.NewString(),
+		ProjectID:   projectId,
+		Name:        strings.TrimSpace(name),
+		Color:       strings.ToLower(color),
+		Description: description,
+		CreatedAt:   time.Now().UnixMilli(),
+	}
+
+	// Send the label to the repository layer to be stored
+	return s.labelRepository.CreateLabel(ctx, label)
+}
+
+// GetLabels retrieves the project ID from the controller layer and returns the project label catalog.
+// The labels are visible to the project manager and the project members
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user
+//   - projectId: The ID of the project
+//
+// Returns:
+//   - []model.Label: The list of project labels
+//   - error: An error that occured during the process
+func (s *labelService) GetLabels(ctx context.Context, userId, projectId string) ([]model.Label, error) {
+	// Check if the user is part of the project
+	if err := s.checkProjectAccess(ctx, userId, projectId, false); err != nil {
+		return nil, err
+	}
+
+	// Send the data to the repository layer to retrieve the labels
+	return s.labelRepository.GetLabels(ctx, projectId)
+}
+
+// UpdateLabel retrieves the new label data from the controller layer and updates the label.
+// When the name changes, the tasks that use the label are updated with the new name
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that updates the label
+//   - projectId: The ID of the project
+//   - labelId: The ID of the label
+//   - name: The new label name
+//   - color: The new label color
+//   - description: The new label description
+//
+// Returns:
+//   - model.LabelChange: The updated label and the number of updated tasks
+//   - error: An error that occured during the process
+func (s *labelService) UpdateLabel(ctx context.Context, userId, projectId, labelId, name, color, description string) (model.LabelChange, error) {
+	// Check if the user manages the project
+	if err := s.checkProjectAccess(ctx, userId, projectId, true); err != nil {
+		return model.LabelChange{}, err
+	}
+
+	// Send the data to the repository layer to update the label and rename it on the tasks that use it
+	return s.labelRepository.UpdateLabel(ctx, projectId, labelId, strings.TrimSpace(name), strings.ToLower(color), description)
+}
+
+// DeleteLabel removes a label from the project label catalog and from the tasks that use it
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that deletes the label
+//   - projectId: The ID of the project
+//   - labelId: The ID of the label
+//
+// Returns:
+//   - model.LabelChange: The deleted label and the number of updated tasks
+//   - error: An error that occured during the process
+func (s *labelService) DeleteLabel(ctx context.Context, userId, projectId, labelId string) (model.LabelChange, error) {
+	// Check if the user manages the project
+	if err := s.checkProjectAccess(ctx, userId, projectId, true); err != nil {
+		return model.LabelChange{}, err
+	}
+
+	// Send the data to the repository layer to delete the label and remove it from the tasks that use it
+	return s.labelRepository.DeleteLabel(ctx, projectId, labelId)
+}
+
+// checkProjectAccess is a private method that checks if a user can access the labels of a project
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user
+//   - projectId: The ID of the project
+//   - managerOnly: True if only the project manager has access
+//
+// Returns:
+//   - error: utils.ErrForbidden if the user has no access, or an error that occured during the process
+func (s *labelService) checkProjectAccess(ctx context.Context, userId, projectId string, managerOnly bool) error {
+	// Get the project data
+	project, err := s.projectRepository.GetProjectById(ctx, projectId)
+	if err != nil {
+		return err
+	}
+
+	if project.ProjectManagerID == userId {
+		return nil
+	}
+
+	if !managerOnly && slices.Contains(project.MemberIDs, userId) {
+		return nil
+	}
+
+	return utils.ErrForbidden
+}
@@ -107,19 +107,20 @@ func (s *projectService) GenerateInvitationLink(ctx context.Context, projectId s
 //   - limit: The max number of projects to retrieve
 //   - orderBy: The project oreder field
 //   - orderDirection: The direction of the order
-//   - startAfter: The ID of the last project retrieved at the previous fetching request
+//   - cursor: The cursor returned with the previous page
 //
 // Returns:
 //   - []model.Proejct: The list of retrieved projects
+//   - string: The cursor of the next page, empty if there are no more projects
 //   - error: An error that occured during the feching process
-func (s *projectService) GetProjects(ctx context.Context, limit int, oderBy, orderDirection string, startAfter *string) ([]model.Project, error) {
+func (s *projectService) GetProjects(ctx context.Context, limit int, oderBy, orderDirection string, cursor string) ([]model.Project, string, error) {
 	// Send the dat to the repository layer to retrieve the projects list
-	projects, err := s.projectRepository.GetProjects(ctx, limit, oderBy, orderDirection, startAfter)
+	projects, nextCursor, err := s.projectRepository.GetProjects(ctx, limit, oderBy, orderDirection, cursor)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	return projects, nil
+	return projects, nextCursor, nil
 }
 
 // GetProjectById retrieves the project ID from the controller
@@ -148,18 +149,21 @@ func (s *projectService) GetProjectById(ctx context.Context, projectId string) (
 // Parameters:
 //   - ctx: Request-scoped context
 //   - userId: The ID of the user
+//   - limit: The max number of projects to retrieve, 0 to retrieve all of them
+//   - cursor: The cursor returned with the previous page
 //
 // Returns:
 //   - []model.Project: The list of retrieved projects
+//   - string: The cursor of the next page, empty if there are no more projects
 //   - error: An error that occured during the fetching process
-func (s *projectService) GetUserProjects(ctx context.Context, userId string) ([]model.Project, error) {
+func (s *projectService) GetUserProjects(ctx context.Context, userId string, limit int, cursor string) ([]model.Project, string, error) {
 	// Send the data to the repository layer to retrieve the user projects
-	projects, err := s.projectRepository.GetUserProjects(ctx, userId)
+	projects, nextCursor, err := s.projectRepository.GetUserProjects(ctx, userId, limit, cursor)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	return projects, nil
+	return projects, nextCursor, nil
 }
 
 // UpdateProjectTitle retrieves the data from the controller layer
@@ -36,3 +36,36 @@ func EncodeData(w http.ResponseWriter, r *http.Request, data any) error {
 
 	return nil
 }
+
+// EncodePaginatedData retrieves a page of data from the controller layer and encodes it
+// together with the cursor of the next page
+//
+// Parameters:
+//   - w: The response writter of the method
+//   - r: The method request
+//   - data: The data to be encoded
+//   - nextCursor: The cursor of the next page, empty if there are no more pages
+//
+// Returns:
+//   - error: An error that occured during the encoding process
+func EncodePaginatedData(w http.ResponseWriter, r *http.Request, data any, nextCursor string) error {
+	// Set the header of the response to a json format
+	w.Header().Set("Content-Type", "application/json")
+
+	// Set the http status for the response
+	w.WriteHeader(http.StatusCreated)
+
+	encodedResponse := model.EncodedResponse{
+		Success:    true,
+		Message:    "Data encoded successfully",
+		Data:       data,
+		NextCursor: nextCursor,
+	}
+
+	// Encode the data into the JSON format
+	if err := json.NewEncoder(w).Encode(encodedResponse); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package utils
+
+import (
+	"errors"
+
+	cursor "github.com/horatiucrisan/cursor-lib"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor was altered or belongs to another ordering
+var ErrInvalidCursor = cursor.ErrInvalidCursor
+
+// ErrForbidden is returned when the user has no access to the requested resource
+var ErrForbidden = errors.New("forbidden")
+
+// ErrLabelExists is returned when a project already has a label with the same name
+var ErrLabelExists = errors.New("a label with the same name already exists")
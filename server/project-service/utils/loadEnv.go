@@ -3,12 +3,16 @@ package utils
 import (
 	"os"
 
+	cursor "github.com/horatiucrisan/cursor-lib"
 	"github.com/joho/godotenv"
 )
 
 type env struct {
 	PROJECTS_COLLECTION    string
 	CODES_COLLECTION       string
+	LABELS_SUBCOLLECTION   string
+	TASKS_COLLECTION       string
+	CURSOR_SECRET          string
 	RABBITMQ_URL           string
 	CLIENT_URL             string
 	RABBITMQ_USERS         string
@@ -20,6 +24,9 @@ type env struct {
 
 var EnvInstances *env
 
+// CursorSigner signs and checks the pagination cursors with the cursor secret
+var CursorSigner *cursor.Signer
+
 // LoadEnv initializes the data from the .env file
 //
 // Returns:
@@ -34,6 +41,9 @@ func LoadEnv() error {
 	EnvInstances = &env{
 		PROJECTS_COLLECTION:    os.Getenv("PROJECTS"),
 		CODES_COLLECTION:       os.Getenv("CODES"),
+		LABELS_SUBCOLLECTION:   os.Getenv("LABELS"),
+		TASKS_COLLECTION:       os.Getenv("TASKS"),
+		CURSOR_SECRET:          os.Getenv("CURSOR_SECRET"),
 		RABBITMQ_URL:           os.Getenv("RABBITMQ_URL"),
 		CLIENT_URL:             os.Getenv("CLIENT_URL"),
 		ROUTE:                  os.Getenv("ROUTE"),
@@ -43,5 +53,12 @@ func LoadEnv() error {
 		RABBITMQ_NOTIFICATIONS: os.Getenv("RABBITMQ_NOTIFICATIONS"),
 	}
 
+	// Refuse to start without a cursor secret, the cursors signed with an empty key could be forged
+	signer, err := cursor.NewSigner(EnvInstances.CURSOR_SECRET)
+	if err != nil {
+		return err
+	}
+	CursorSigner = signer
+
 	return nil
 }
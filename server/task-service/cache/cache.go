@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultTTL is the time an entry is kept when no TTL is configured
+const DefaultTTL = 5 * time.Minute
+
+// sweepSize is the number of entries after which the expired entries are removed on write
+const sweepSize = 1000
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// Cache is an in-memory store whose entries expire after a fixed time
+type Cache[K comparable, V any] struct {
+	ttl     time.Duration
+	entries map[K]entry[V]
+	mu      sync.RWMutex
+}
+
+// New generates a new cache whose entries expire after the TTL
+//
+// Parameters:
+//   - ttl: The time an entry is kept, DefaultTTL when not positive
+//
+// Returns:
+//   - *Cache[K, V]: The new cache
+func New[K comparable, V any](ttl time.Duration) *Cache[K, V] {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	return &Cache[K, V]{ttl: ttl, entries: map[K]entry[V]{}}
+}
+
+// Get returns the value stored for a key if it did not expire
+//
+// Parameters:
+//   - key: The key of the entry
+//
+// Returns:
+//   - V: The stored value
+//   - bool: True if a valid entry was found
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	cached, ok := c.entries[key]
+	if !ok || time.Now().After(cached.expiresAt) {
+		var zero V
+		return zero, false
+	}
+
+	return cached.value, true
+}
+
+// Set stores the value of a key for the TTL of the cache
+//
+// Parameters:
+//   - key: The key of the entry
+//   - value: The value to store
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+
+	// Remove the expired entries so the cache does not grow without bounds
+	if len(c.entries) >= sweepSize {
+		for key, cached := range c.entries {
+			if now.After(cached.expiresAt) {
+				delete(c.entries, key)
+			}
+		}
+	}
+
+	c.entries[key] = entry[V]{value: value, expiresAt: now.Add(c.ttl)}
+}
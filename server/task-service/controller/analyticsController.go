@@ -0,0 +1,310 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/middleware"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/rabbitmq"
+	"github.com/horatiucrisan/task-service/schemas"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+// The time window of the analytics when no start date is sent
+const defaultAnalyticsWindow = 30 * 24 * time.Hour
+
+type analyticsController struct {
+	analyticsService interfaces.AnalyticsService
+	loggerProducer   *rabbitmq.TaskProducer
+}
+
+func NewAnalyticsController(analyticsService interfaces.AnalyticsService, loggerProducer *rabbitmq.TaskProducer) interfaces.AnalyticsController {
+	return &analyticsController{analyticsService: analyticsService, loggerProducer: loggerProducer}
+}
+
+// GET methods
+func (c *analyticsController) GetProjectAnalytics(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Get the optional time window from the request query
+	from, to, err := analyticsWindow(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetProjectAnalyticsSchema{
+		UserID:    user.UID,
+		ProjectID: r.URL.Query().Get("projectId"),
+		From:      from,
+		To:        to,
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to compute the analytics
+	analytics, duration, err := utils.MeasureTime("Get-Project-Analytics", func() (model.ProjectAnalytics, error) {
+		return c.analyticsService.GetProjectAnalytics(r.Context(), inputData.UserID, inputData.ProjectID, inputData.From, inputData.To)
+	})
+	if err != nil {
+		analyticsErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the analytics of the project `%s`", inputData.UserID, inputData.ProjectID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		analytics,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, analytics); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *analyticsController) GetCumulativeFlow(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Get the optional time window from the request query
+	from, to, err := analyticsWindow(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetProjectAnalyticsSchema{
+		UserID:    user.UID,
+		ProjectID: r.URL.Query().Get("projectId"),
+		From:      from,
+		To:        to,
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to compute the cumulative flow
+	flow, duration, err := utils.MeasureTime("Get-Cumulative-Flow", func() (model.CumulativeFlow, error) {
+		return c.analyticsService.GetCumulativeFlow(r.Context(), inputData.UserID, inputData.ProjectID, inputData.From, inputData.To)
+	})
+	if err != nil {
+		analyticsErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the cumulative flow of the project `%s`", inputData.UserID, inputData.ProjectID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		flow,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, flow); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *analyticsController) GetCycleTimeMetrics(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Get the optional time window from the request query
+	from, to, err := analyticsWindow(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetProjectAnalyticsSchema{
+		UserID:    user.UID,
+		ProjectID: r.URL.Query().Get("projectId"),
+		From:      from,
+		To:        to,
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to compute the cycle time metrics
+	metrics, duration, err := utils.MeasureTime("Get-Cycle-Time-Metrics", func() (model.CycleTimeMetrics, error) {
+		return c.analyticsService.GetCycleTimeMetrics(r.Context(), inputData.UserID, inputData.ProjectID, inputData.From, inputData.To)
+	})
+	if err != nil {
+		analyticsErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the cycle time metrics of the project `%s`", inputData.UserID, inputData.ProjectID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		metrics,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, metrics); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *analyticsController) GetTaskTimeInStatus(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetTaskTimeInStatusSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to compute the time in status of the task
+	timeInStatus, duration, err := utils.MeasureTime("Get-Task-Time-In-Status", func() (model.TimeInStatus, error) {
+		return c.analyticsService.GetTaskTimeInStatus(r.Context(), inputData.UserID, inputData.TaskID)
+	})
+	if err != nil {
+		analyticsErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the time in status of the task `%s`", inputData.UserID, inputData.TaskID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		timeInStatus,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, timeInStatus); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// analyticsWindow is a private function that reads the time window of a metric from the request query.
+// The default window ends at the current minute, so the cached metrics are reused between requests
+//
+// Parameters:
+//   - r: The http request
+//
+// Returns:
+//   - int64: The inclusive start of the window
+//   - int64: The exclusive end of the window
+//   - error: An error that occured during the process
+func analyticsWindow(r *http.Request) (int64, int64, error) {
+	from, err := utils.ParseInt64Param(r, "from")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	to, err := utils.ParseInt64Param(r, "to")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	windowTo := time.Now().Truncate(time.Minute).UnixMilli()
+	if to != nil {
+		windowTo = *to
+	}
+
+	windowFrom := windowTo - defaultAnalyticsWindow.Milliseconds()
+	if from != nil {
+		windowFrom = *from
+	}
+
+	return windowFrom, windowTo, nil
+}
+
+// analyticsErrorStatus is a private function that writes the http status matching an analytics error
+//
+// Parameters:
+//   - w: The http response writer
+//   - err: The error returned by the service layer
+func analyticsErrorStatus(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, utils.ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, utils.ErrInvalidTimeWindow):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
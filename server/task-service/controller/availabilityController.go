@@ -0,0 +1,310 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/middleware"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/rabbitmq"
+	"github.com/horatiucrisan/task-service/schemas"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+// The number of days the upcoming absences are looked up for when the request does not set it
+const defaultAtRiskDays = 14
+
+type availabilityController struct {
+	availabilityService interfaces.AvailabilityService
+	loggerProducer      *rabbitmq.TaskProducer
+}
+
+func NewAvailabilityController(availabilityService interfaces.AvailabilityService, loggerProducer *rabbitmq.TaskProducer) interfaces.AvailabilityController {
+	return &availabilityController{availabilityService: availabilityService, loggerProducer: loggerProducer}
+}
+
+// POST methods
+func (c *availabilityController) CreateAbsence(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.CreateAbsenceSchema{
+		UserID: user.UID,
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// The members add their own absence when no member is given
+	if inputData.MemberID == "" {
+		inputData.MemberID = inputData.UserID
+	}
+
+	// Send the data to the service layer to create the absence
+	absence, duration, err := utils.MeasureTime("Create-Absence", func() (model.Absence, error) {
+		return c.availabilityService.CreateAbsence(r.Context(), inputData.UserID, inputData.ProjectID, inputData.MemberID, inputData.StartAt, inputData.EndAt, inputData.Reason)
+	})
+	if err != nil {
+		availabilityErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` added an absence of the member `%s` to the project `%s`", inputData.UserID, absence.UserID, inputData.ProjectID),
+		"audit",
+		http.StatusCreated,
+		duration,
+		absence,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, absence); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// GET methods
+func (c *availabilityController) GetAbsences(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Get the date range of the absences from the request query
+	from, err := utils.ParseInt64Param(r, "from")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	to, err := utils.ParseInt64Param(r, "to")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if from == nil || to == nil {
+		http.Error(w, "the `from` and `to` query parameters are required", http.StatusBadRequest)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetAbsencesSchema{
+		UserID:    user.UID,
+		ProjectID: r.URL.Query().Get("projectId"),
+		From:      *from,
+		To:        *to,
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to get the absences of the date range
+	absences, duration, err := utils.MeasureTime("Get-Absences", func() ([]model.Absence, error) {
+		return c.availabilityService.GetAbsences(r.Context(), inputData.UserID, inputData.ProjectID, inputData.From, inputData.To)
+	})
+	if err != nil {
+		availabilityErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the absences of the project `%s`", inputData.UserID, inputData.ProjectID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		absences,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, absences); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *availabilityController) GetTasksAtRisk(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Get the optional number of days the upcoming absences are looked up for
+	days, err := utils.ParseInt64Param(r, "days")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetTasksAtRiskSchema{
+		UserID:    user.UID,
+		ProjectID: r.URL.Query().Get("projectId"),
+		Days:      defaultAtRiskDays,
+	}
+
+	if days != nil {
+		inputData.Days = int(*days)
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to get the tasks at risk
+	tasks, duration, err := utils.MeasureTime("Get-Tasks-At-Risk", func() ([]model.TaskAtRisk, error) {
+		return c.availabilityService.GetTasksAtRisk(r.Context(), inputData.UserID, inputData.ProjectID, inputData.Days)
+	})
+	if err != nil {
+		availabilityErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the tasks of the project `%s` at risk because of the absences of the next %d days", inputData.UserID, inputData.ProjectID, inputData.Days),
+		"info",
+		http.StatusAccepted,
+		duration,
+		tasks,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, tasks); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// DELETE methods
+func (c *availabilityController) DeleteAbsence(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.DeleteAbsenceSchema{
+		UserID:    user.UID,
+		ProjectID: r.URL.Query().Get("projectId"),
+		AbsenceID: chi.URLParam(r, "absenceId"),
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to delete the absence
+	absence, duration, err := utils.MeasureTime("Delete-Absence", func() (model.Absence, error) {
+		return c.availabilityService.DeleteAbsence(r.Context(), inputData.UserID, inputData.ProjectID, inputData.AbsenceID)
+	})
+	if err != nil {
+		availabilityErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` deleted the absence `%s` of the member `%s`", inputData.UserID, absence.ID, absence.UserID),
+		"audit",
+		http.StatusOK,
+		duration,
+		absence,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, absence); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// availabilityErrorStatus is a private function that writes the http status matching a member availability error
+//
+// Parameters:
+//   - w: The http response writer
+//   - err: The error returned by the service layer
+func availabilityErrorStatus(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, utils.ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, utils.ErrInvalidAbsence):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// absenceWarningMessage is a private function that generates the log suffix of a task change
+// that left the deadline of the task inside the absence of a handler
+//
+// Parameters:
+//   - warnings: The absences of the handlers that cover the task deadline
+//
+// Returns:
+//   - string: The log suffix, empty when there are no warnings
+func absenceWarningMessage(warnings []model.AbsenceWarning) string {
+	if len(warnings) == 0 {
+		return ""
+	}
+
+	absences := make([]string, 0, len(warnings))
+	for _, warning := range warnings {
+		absences = append(absences, fmt.Sprintf("`%s` (%s - %s)", warning.HandlerID, time.UnixMilli(warning.StartAt).UTC().Format(time.DateOnly), time.UnixMilli(warning.EndAt).UTC().Format(time.DateOnly)))
+	}
+
+	return fmt.Sprintf(", the deadline falls inside the absences of the handlers %s", strings.Join(absences, ", "))
+}
@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/rabbitmq"
+)
+
+type deadlineController struct {
+	deadlineService      interfaces.DeadlineService
+	userProducer         *rabbitmq.UserProducer
+	notificationProducer *rabbitmq.TaskProducer
+	versionProducer      *rabbitmq.TaskProducer
+}
+
+func NewDeadlineController(
+	deadlineService interfaces.DeadlineService,
+	userProducer *rabbitmq.UserProducer,
+	notificationProducer,
+	versionProducer *rabbitmq.TaskProducer,
+) interfaces.DeadlineController {
+	return &deadlineController{
+		deadlineService:      deadlineService,
+		userProducer:         userProducer,
+		notificationProducer: notificationProducer,
+		versionProducer:      versionProducer,
+	}
+}
+
+// Scheduled methods
+
+// SendDeadlineNotifications sends the deadline reminders, the overdue notices and the escalations
+// that are due. The notices are claimed by the service layer before they are returned, so a notice is
+// never sent twice. A notice that fails to be sent is released, so the next check sends it
+//
+// Parameters:
+//   - ctx: The scheduler context
+//
+// Returns:
+//   - error: An error that occured during the process
+func (c *deadlineController) SendDeadlineNotifications(ctx context.Context) error {
+	notices, err := c.deadlineService.CheckDeadlines(ctx)
+	if len(notices) == 0 {
+		return err
+	}
+
+	// Get the data of all the users that have to be notified at once
+	var userIds []string
+	for _, notice := range notices {
+		for _, userId := range noticeRecipients(notice) {
+			if !slices.Contains(userIds, userId) {
+				userIds = append(userIds, userId)
+			}
+		}
+	}
+
+	usersData := []model.User{}
+	if len(userIds) > 0 {
+		var usersErr error
+		if usersData, usersErr = c.userProducer.GetUsers(userIds); usersErr != nil {
+			// Release the claimed notices so the next check sends them
+			err = errors.Join(err, usersErr)
+			for _, notice := range notices {
+				err = errors.Join(err, c.deadlineService.ReleaseDeadlineNotice(ctx, notice))
+			}
+
+			return err
+		}
+	}
+
+	for _, notice := range notices {
+		log.Printf("Sending the `%s` deadline notice of the task %s", notice.Type, notice.Task.ID)
+
+		// Generate the user notification data
+		recipients := noticeRecipients(notice)
+		notificationUsers := []model.NotificationUser{}
+		for _, userData := range usersData {
+			if !slices.Contains(recipients, userData.ID) {
+				continue
+			}
+
+			notificationUser := model.NotificationUser{
+				UserID:  userData.ID,
+				Email:   userData.Email,
+				Message: noticeMessage(notice),
+			}
+
+			notificationUsers = append(notificationUsers, notificationUser)
+		}
+
+		if sendErr := rabbitmq.GenerateNotificationData(c.notificationProducer, notificationUsers, "email", notice); sendErr != nil {
+			// Release the claimed notice so the next check sends it
+			err = errors.Join(err, sendErr, c.deadlineService.ReleaseDeadlineNotice(ctx, notice))
+			continue
+		}
+
+		// Generate the version of the task that became overdue
+		if notice.Type == model.NoticeOverdue {
+			if versionErr := rabbitmq.GenerateVersionData(c.versionProducer, notice.Task.ID, notice.Task); versionErr != nil {
+				err = errors.Join(err, versionErr)
+			}
+		}
+	}
+
+	return err
+}
+
+// noticeRecipients is a private function that returns the users a deadline notice is sent to.
+// The escalations are sent to the project manager and the other notices to the task handlers
+//
+// Parameters:
+//   - notice: The deadline notice
+//
+// Returns:
+//   - []string: The IDs of the users
+func noticeRecipients(notice model.DeadlineNotice) []string {
+	if notice.Type == model.NoticeEscalation {
+		if notice.ProjectManagerID == "" {
+			return nil
+		}
+		return []string{notice.ProjectManagerID}
+	}
+
+	return notice.Task.HandlerIDs
+}
+
+// noticeMessage is a private function that generates the message of a deadline notice
+//
+// Parameters:
+//   - notice: The deadline notice
+//
+// Returns:
+//   - string: The notification message
+func noticeMessage(notice model.DeadlineNotice) string {
+	offset := time.Duration(notice.Offset) * time.Millisecond
+
+	switch notice.Type {
+	case model.NoticeReminder:
+		return fmt.Sprintf("The task `%s` is due in %s", notice.Task.Description, offset)
+	case model.NoticeOverdue:
+		return fmt.Sprintf("The task `%s` passed its deadline and is now overdue", notice.Task.Description)
+	default:
+		return fmt.Sprintf("The task `%s` is still open %s after its deadline", notice.Task.Description, offset)
+	}
+}
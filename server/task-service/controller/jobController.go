@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/middleware"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/rabbitmq"
+	"github.com/horatiucrisan/task-service/schemas"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+type jobController struct {
+	jobService     interfaces.JobService
+	loggerProducer *rabbitmq.TaskProducer
+}
+
+func NewJobController(jobService interfaces.JobService, loggerProducer *rabbitmq.TaskProducer) interfaces.JobController {
+	return &jobController{
+		jobService:     jobService,
+		loggerProducer: loggerProducer,
+	}
+}
+
+// GET methods
+func (c *jobController) GetJobById(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetJobByIdSchema{
+		UserID: user.UID,
+		JobID:  chi.URLParam(r, "jobId"),
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to retrieve the job
+	job, duration, err := utils.MeasureTime("Get-Job-By-Id", func() (model.Job, error) {
+		return c.jobService.GetJobById(r.Context(), inputData.JobID)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Only the user that started the job can see it
+	if job.CreatedBy != inputData.UserID {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the status of the job `%s`", inputData.UserID, inputData.JobID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		job,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, job); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// DELETE methods
+func (c *jobController) CancelJob(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.CancelJobSchema{
+		UserID: user.UID,
+		JobID:  chi.URLParam(r, "jobId"),
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Get the job data to check who started it
+	currentJob, err := c.jobService.GetJobById(r.Context(), inputData.JobID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Only the user that started the job can cancel it
+	if currentJob.CreatedBy != inputData.UserID {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	// Send the data to the service layer to cancel the job
+	job, duration, err := utils.MeasureTime("Cancel-Job", func() (model.Job, error) {
+		return c.jobService.CancelJob(r.Context(), inputData.JobID)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` cancelled the job `%s`", inputData.UserID, inputData.JobID),
+		"audit",
+		http.StatusCreated,
+		duration,
+		job,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, job); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,419 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/middleware"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/rabbitmq"
+	"github.com/horatiucrisan/task-service/schemas"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+type recurrenceController struct {
+	recurrenceService    interfaces.RecurrenceService
+	userProducer         *rabbitmq.UserProducer
+	loggerProducer       *rabbitmq.TaskProducer
+	notificationProducer *rabbitmq.TaskProducer
+	versionProducer      *rabbitmq.TaskProducer
+}
+
+func NewRecurrenceController(
+	recurrenceService interfaces.RecurrenceService,
+	userProducer *rabbitmq.UserProducer,
+	loggerProducer,
+	notificationProducer,
+	versionProducer *rabbitmq.TaskProducer,
+) interfaces.RecurrenceController {
+	return &recurrenceController{
+		recurrenceService:    recurrenceService,
+		userProducer:         userProducer,
+		loggerProducer:       loggerProducer,
+		notificationProducer: notificationProducer,
+		versionProducer:      versionProducer,
+	}
+}
+
+// POST methods
+func (c *recurrenceController) CreateSeries(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.CreateSeriesSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	rule := model.RecurrenceRule{
+		Frequency: inputData.Frequency,
+		Interval:  inputData.Interval,
+		Until:     inputData.Until,
+		Count:     inputData.Count,
+	}
+
+	// Send the data to the service layer to create the series
+	occurrence, duration, err := utils.MeasureTime("Create-Series", func() (model.SeriesOccurrence, error) {
+		return c.recurrenceService.CreateSeries(r.Context(), inputData.UserID, inputData.TaskID, rule)
+	})
+	if err != nil {
+		seriesErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` made the task `%s` recurring `%s` as the series `%s`", inputData.UserID, inputData.TaskID, rule.Frequency, occurrence.Series.ID),
+		"audit",
+		http.StatusCreated,
+		duration,
+		occurrence,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the new task version
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, occurrence.Task.ID, occurrence.Task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, occurrence); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// GET methods
+func (c *recurrenceController) GetProjectSeries(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetProjectSeriesSchema{
+		UserID:    user.UID,
+		ProjectID: r.URL.Query().Get("projectId"),
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to get the project series
+	seriesList, duration, err := utils.MeasureTime("Get-Project-Series", func() ([]model.TaskSeries, error) {
+		return c.recurrenceService.GetProjectSeries(r.Context(), inputData.UserID, inputData.ProjectID)
+	})
+	if err != nil {
+		seriesErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the task series of the project `%s`", inputData.UserID, inputData.ProjectID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		seriesList,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, seriesList); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *recurrenceController) GetSeriesById(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetSeriesByIdSchema{
+		UserID:   user.UID,
+		SeriesID: chi.URLParam(r, "seriesId"),
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to get the series
+	series, duration, err := utils.MeasureTime("Get-Series-By-Id", func() (model.TaskSeries, error) {
+		return c.recurrenceService.GetSeriesById(r.Context(), inputData.UserID, inputData.SeriesID)
+	})
+	if err != nil {
+		seriesErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the task series `%s`", inputData.UserID, inputData.SeriesID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		series,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, series); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// PUT methods
+func (c *recurrenceController) UpdateSeries(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.UpdateSeriesSchema{
+		UserID:   user.UID,
+		SeriesID: chi.URLParam(r, "seriesId"),
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	changes := model.SeriesChanges{
+		Description: inputData.Description,
+		HandlerIDs:  inputData.HandlerIDs,
+		Priority:    inputData.Priority,
+		Severity:    inputData.Severity,
+	}
+
+	if inputData.Rule != nil {
+		changes.Rule = &model.RecurrenceRule{
+			Frequency: inputData.Rule.Frequency,
+			Interval:  inputData.Rule.Interval,
+			Until:     inputData.Rule.Until,
+			Count:     inputData.Rule.Count,
+		}
+	}
+
+	// Send the data to the service layer to update the series
+	series, duration, err := utils.MeasureTime("Update-Series", func() (model.TaskSeries, error) {
+		return c.recurrenceService.UpdateSeries(r.Context(), inputData.UserID, inputData.SeriesID, changes)
+	})
+	if err != nil {
+		seriesErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` updated the task series `%s`", inputData.UserID, inputData.SeriesID),
+		"audit",
+		http.StatusAccepted,
+		duration,
+		changes,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, series); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *recurrenceController) StopSeries(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.StopSeriesSchema{
+		UserID:   user.UID,
+		SeriesID: chi.URLParam(r, "seriesId"),
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to stop the series
+	series, duration, err := utils.MeasureTime("Stop-Series", func() (model.TaskSeries, error) {
+		return c.recurrenceService.StopSeries(r.Context(), inputData.UserID, inputData.SeriesID)
+	})
+	if err != nil {
+		seriesErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` stopped the task series `%s`", inputData.UserID, inputData.SeriesID),
+		"audit",
+		http.StatusAccepted,
+		duration,
+		series,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, series); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// Scheduled methods
+
+// CreateDueOccurrences creates the next occurrence of the due series and publishes the new tasks
+//
+// Parameters:
+//   - ctx: The scheduler context
+//
+// Returns:
+//   - error: An error that occured during the process
+func (c *recurrenceController) CreateDueOccurrences(ctx context.Context) error {
+	occurrences, err := c.recurrenceService.CreateDueOccurrences(ctx)
+
+	// Publish the occurrences that were created even when some series failed
+	for _, occurrence := range occurrences {
+		log.Printf("Created occurrence %d of the series %s as the task %s", occurrence.Task.Occurrence, occurrence.Series.ID, occurrence.Task.ID)
+
+		if publishErr := publishOccurrence(c.userProducer, c.notificationProducer, c.versionProducer, occurrence); publishErr != nil {
+			err = errors.Join(err, publishErr)
+		}
+	}
+
+	return err
+}
+
+// publishOccurrence is a private function that notifies the handlers of a new series occurrence
+// and generates the versions of the created task and subtasks
+//
+// Parameters:
+//   - userProducer: The rabbitMq user producer
+//   - notificationProducer: The rabbitMq notification producer
+//   - versionProducer: The rabbitMq version producer
+//   - occurrence: The created occurrence
+//
+// Returns:
+//   - error: An error that occured during the process
+func publishOccurrence(userProducer *rabbitmq.UserProducer, notificationProducer, versionProducer *rabbitmq.TaskProducer, occurrence model.SeriesOccurrence) error {
+	// Notify the handlers of the new occurrence
+	if len(occurrence.Task.HandlerIDs) != 0 {
+		usersData, err := userProducer.GetUsers(occurrence.Task.HandlerIDs)
+		if err != nil {
+			return err
+		}
+
+		notificationUsers := []model.NotificationUser{}
+		for _, userData := range usersData {
+			notificationUser := model.NotificationUser{
+				UserID:  userData.ID,
+				Email:   userData.Email,
+				Message: fmt.Sprintf("The recurring task `%s` is due again", occurrence.Task.Description),
+			}
+
+			notificationUsers = append(notificationUsers, notificationUser)
+		}
+
+		if err = rabbitmq.GenerateNotificationData(notificationProducer, notificationUsers, "email", occurrence.Task); err != nil {
+			return err
+		}
+	}
+
+	// Generate the version of the new task and of its subtasks
+	if err := rabbitmq.GenerateVersionData(versionProducer, occurrence.Task.ID, occurrence.Task); err != nil {
+		return err
+	}
+
+	for _, subtask := range occurrence.Subtasks {
+		if err := rabbitmq.GenerateVersionData(versionProducer, subtask.ID, subtask); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// seriesErrorStatus is a private function that writes the http status matching a task series error
+//
+// Parameters:
+//   - w: The http response writer
+//   - err: The error returned by the service layer
+func seriesErrorStatus(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, utils.ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, utils.ErrInvalidRecurrence):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, utils.ErrSeriesStopped), errors.Is(err, utils.ErrHandlersNotMembers):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
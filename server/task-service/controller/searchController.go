@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/middleware"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/rabbitmq"
+	"github.com/horatiucrisan/task-service/schemas"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+// The number of results returned when the request has no limit
+const defaultSearchLimit = 20
+
+type searchController struct {
+	searchService  interfaces.SearchService
+	loggerProducer *rabbitmq.TaskProducer
+}
+
+func NewSearchController(searchService interfaces.SearchService, loggerProducer *rabbitmq.TaskProducer) interfaces.SearchController {
+	return &searchController{
+		searchService:  searchService,
+		loggerProducer: loggerProducer,
+	}
+}
+
+// GET methods
+func (c *searchController) Search(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Get the optional number of results from the request query
+	limit, err := utils.ParseInt64Param(r, "limit")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.SearchSchema{
+		UserID:    user.UID,
+		Query:     r.URL.Query().Get("q"),
+		ProjectID: r.URL.Query().Get("projectId"),
+		Limit:     defaultSearchLimit,
+	}
+
+	if limit != nil {
+		inputData.Limit = int(*limit)
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to search the tasks
+	hits, duration, err := utils.MeasureTime("Search", func() ([]model.SearchHit, error) {
+		return c.searchService.Search(r.Context(), inputData.UserID, inputData.Query, inputData.ProjectID, inputData.Limit)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrForbidden):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case errors.Is(err, utils.ErrInvalidSearchQuery):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` searched for `%s` and found `%d` results", inputData.UserID, inputData.Query, len(hits)),
+		"info",
+		http.StatusAccepted,
+		duration,
+		hits,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, hits); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,550 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/middleware"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/rabbitmq"
+	"github.com/horatiucrisan/task-service/schemas"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+// The number of finished sprints included in the velocity when no limit is sent
+const defaultVelocitySprints = 5
+
+type sprintController struct {
+	sprintService   interfaces.SprintService
+	loggerProducer  *rabbitmq.TaskProducer
+	versionProducer *rabbitmq.TaskProducer
+}
+
+func NewSprintController(sprintService interfaces.SprintService, loggerProducer, versionProducer *rabbitmq.TaskProducer) interfaces.SprintController {
+	return &sprintController{sprintService: sprintService, loggerProducer: loggerProducer, versionProducer: versionProducer}
+}
+
+// POST methods
+func (c *sprintController) CreateSprint(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.CreateSprintSchema{
+		UserID: user.UID,
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to create the sprint
+	sprint, duration, err := utils.MeasureTime("Create-Sprint", func() (model.Sprint, error) {
+		return c.sprintService.CreateSprint(r.Context(), inputData.UserID, inputData.ProjectID, inputData.Name, inputData.Goal, inputData.StartAt, inputData.EndAt)
+	})
+	if err != nil {
+		sprintErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` created the sprint `%s` of the project `%s`", inputData.UserID, sprint.ID, sprint.ProjectID),
+		"audit",
+		http.StatusCreated,
+		duration,
+		sprint,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, sprint); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// GET methods
+func (c *sprintController) GetProjectSprints(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetProjectSprintsSchema{
+		UserID:    user.UID,
+		ProjectID: r.URL.Query().Get("projectId"),
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to get the project sprints
+	sprints, duration, err := utils.MeasureTime("Get-Project-Sprints", func() ([]model.Sprint, error) {
+		return c.sprintService.GetProjectSprints(r.Context(), inputData.UserID, inputData.ProjectID)
+	})
+	if err != nil {
+		sprintErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the sprints of the project `%s`", inputData.UserID, inputData.ProjectID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		sprints,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, sprints); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *sprintController) GetSprintById(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetSprintByIdSchema{
+		UserID:   user.UID,
+		SprintID: chi.URLParam(r, "sprintId"),
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to get the sprint
+	sprint, duration, err := utils.MeasureTime("Get-Sprint-By-Id", func() (model.Sprint, error) {
+		return c.sprintService.GetSprintById(r.Context(), inputData.UserID, inputData.SprintID)
+	})
+	if err != nil {
+		sprintErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the sprint `%s`", inputData.UserID, inputData.SprintID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		sprint,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, sprint); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *sprintController) GetSprintBurndown(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetSprintByIdSchema{
+		UserID:   user.UID,
+		SprintID: chi.URLParam(r, "sprintId"),
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to compute the sprint burndown
+	burndown, duration, err := utils.MeasureTime("Get-Sprint-Burndown", func() (model.Burndown, error) {
+		return c.sprintService.GetSprintBurndown(r.Context(), inputData.UserID, inputData.SprintID)
+	})
+	if err != nil {
+		sprintErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the burndown of the sprint `%s`", inputData.UserID, inputData.SprintID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		burndown,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, burndown); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *sprintController) GetProjectVelocity(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Get the optional number of sprints from the request query
+	limit, err := utils.ParseInt64Param(r, "limit")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetProjectVelocitySchema{
+		UserID:    user.UID,
+		ProjectID: r.URL.Query().Get("projectId"),
+		Limit:     defaultVelocitySprints,
+	}
+
+	if limit != nil {
+		inputData.Limit = int(*limit)
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to compute the project velocity
+	velocity, duration, err := utils.MeasureTime("Get-Project-Velocity", func() (model.Velocity, error) {
+		return c.sprintService.GetProjectVelocity(r.Context(), inputData.UserID, inputData.ProjectID, inputData.Limit)
+	})
+	if err != nil {
+		sprintErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the velocity of the project `%s`", inputData.UserID, inputData.ProjectID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		velocity,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, velocity); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// PUT methods
+func (c *sprintController) UpdateSprint(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.UpdateSprintSchema{
+		UserID:   user.UID,
+		SprintID: chi.URLParam(r, "sprintId"),
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	changes := model.SprintChanges{
+		Name:    inputData.Name,
+		Goal:    inputData.Goal,
+		StartAt: inputData.StartAt,
+		EndAt:   inputData.EndAt,
+	}
+
+	// Send the data to the service layer to update the sprint
+	sprint, duration, err := utils.MeasureTime("Update-Sprint", func() (model.Sprint, error) {
+		return c.sprintService.UpdateSprint(r.Context(), inputData.UserID, inputData.SprintID, changes)
+	})
+	if err != nil {
+		sprintErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` updated the sprint `%s`", inputData.UserID, inputData.SprintID),
+		"audit",
+		http.StatusOK,
+		duration,
+		sprint,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, sprint); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *sprintController) AssignTaskSprint(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.AssignTaskSprintSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to plan the task into the sprint
+	task, duration, err := utils.MeasureTime("Assign-Task-Sprint", func() (model.Task, error) {
+		return c.sprintService.AssignTaskSprint(r.Context(), inputData.UserID, inputData.TaskID, inputData.SprintID)
+	})
+	if err != nil {
+		sprintErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` moved the task `%s` to the sprint `%s`", inputData.UserID, inputData.TaskID, inputData.SprintID),
+		"audit",
+		http.StatusOK,
+		duration,
+		task,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the new task version
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, task.ID, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *sprintController) UpdateTaskEstimate(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.UpdateTaskEstimateSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	estimate := model.TaskEstimate{
+		StoryPoints:   inputData.StoryPoints,
+		EstimatedTime: inputData.EstimatedTime,
+	}
+
+	// Send the data to the service layer to update the task estimate
+	task, duration, err := utils.MeasureTime("Update-Task-Estimate", func() (model.Task, error) {
+		return c.sprintService.UpdateTaskEstimate(r.Context(), inputData.UserID, inputData.TaskID, estimate)
+	})
+	if err != nil {
+		sprintErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` updated the estimate of the task `%s`", inputData.UserID, inputData.TaskID),
+		"audit",
+		http.StatusOK,
+		duration,
+		task,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the new task version
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, task.ID, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// DELETE methods
+func (c *sprintController) DeleteSprint(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.DeleteSprintSchema{
+		UserID:   user.UID,
+		SprintID: chi.URLParam(r, "sprintId"),
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to delete the sprint
+	tasks, duration, err := utils.MeasureTime("Delete-Sprint", func() ([]model.Task, error) {
+		return c.sprintService.DeleteSprint(r.Context(), inputData.UserID, inputData.SprintID)
+	})
+	if err != nil {
+		sprintErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` deleted the sprint `%s` and moved %d tasks back to the backlog", inputData.UserID, inputData.SprintID, len(tasks)),
+		"audit",
+		http.StatusOK,
+		duration,
+		tasks,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the new versions of the tasks moved back to the backlog
+	for _, task := range tasks {
+		if err = rabbitmq.GenerateVersionData(c.versionProducer, task.ID, task); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, tasks); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// sprintErrorStatus is a private function that writes the http status matching a sprint error
+//
+// Parameters:
+//   - w: The http response writer
+//   - err: The error returned by the service layer
+func sprintErrorStatus(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, utils.ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, utils.ErrInvalidSprint):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -1,9 +1,12 @@
 package controller
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/horatiucrisan/task-service/interfaces"
@@ -16,6 +19,7 @@ import (
 
 type taskController struct {
 	taskService          interfaces.TaskService
+	recurrenceService    interfaces.RecurrenceService
 	userProducer         *rabbitmq.UserProducer
 	loggerProducer       *rabbitmq.TaskProducer
 	notificationProducer *rabbitmq.TaskProducer
@@ -24,6 +28,7 @@ type taskController struct {
 
 func NewTaskController(
 	taskService interfaces.TaskService,
+	recurrenceService interfaces.RecurrenceService,
 	userProducer *rabbitmq.UserProducer,
 	loggerProducer,
 	notificationProducer,
@@ -31,6 +36,7 @@ func NewTaskController(
 ) interfaces.TaskController {
 	return &taskController{
 		taskService:          taskService,
+		recurrenceService:    recurrenceService,
 		userProducer:         userProducer,
 		loggerProducer:       loggerProducer,
 		notificationProducer: notificationProducer,
@@ -61,10 +67,19 @@ func (c *taskController) CreateTask(w http.ResponseWriter, r *http.Request) {
 
 	// Send the data to the service layer to create the task
 	task, duration, err := utils.MeasureTime("Create-Task", func() (model.Task, error) {
-		return c.taskService.CreateTask(r.Context(), user.UID, inputData.ProjectID, inputData.HandlerIDs, inputData.Description, inputData.Deadline)
+		return c.taskService.CreateTask(r.Context(), user.UID, inputData.ProjectID, inputData.HandlerIDs, inputData.Description, inputData.Deadline, inputData.Priority, inputData.Severity, inputData.AutoAssign)
 	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, utils.ErrForbidden):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case errors.Is(err, utils.ErrInvalidAssignment):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, utils.ErrWipLimitExceeded):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
 		return
 	}
 
@@ -73,7 +88,7 @@ func (c *taskController) CreateTask(w http.ResponseWriter, r *http.Request) {
 		w,
 		r,
 		c.loggerProducer,
-		fmt.Sprintf("User `%s` created a new task `%s`", inputData.AuthorID, task.ID),
+		fmt.Sprintf("User `%s` created a new task `%s`%s", inputData.AuthorID, task.ID, absenceWarningMessage(task.AbsenceWarnings)),
 		"audit",
 		http.StatusCreated,
 		duration,
@@ -83,8 +98,8 @@ func (c *taskController) CreateTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get the data of the task handlers
-	usersData, err := c.userProducer.GetUsers(inputData.HandlerIDs)
+	// Get the data of the task handlers, the auto assigned handler is only known after the creation
+	usersData, err := c.userProducer.GetUsers(task.HandlerIDs)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -96,7 +111,7 @@ func (c *taskController) CreateTask(w http.ResponseWriter, r *http.Request) {
 		notificationUser := model.NotificationUser{
 			UserID:  userData.ID,
 			Email:   userData.Email,
-			Message: fmt.Sprintf("You have been assigned a new task `%s`", task.Description),
+			Message: fmt.Sprintf("You have been assigned a new `%s` priority task `%s`, please accept or decline the assignment", task.Priority, task.Description),
 		}
 
 		notificationUsers = append(notificationUsers, notificationUser)
@@ -281,6 +296,319 @@ func (c *taskController) CreateTaskResponse(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+func (c *taskController) DuplicateTask(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.DuplicateTaskSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to duplicate the task
+	details, duration, err := utils.MeasureTime("Duplicate-Task", func() (model.TaskDetails, error) {
+		return c.taskService.DuplicateTask(r.Context(), inputData.UserID, inputData.TaskID, inputData.IncludeResponses, inputData.Deadline)
+	})
+	if err != nil {
+		workflowErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` duplicated the task `%s` into the task `%s`", inputData.UserID, inputData.TaskID, details.Task.ID),
+		"audit",
+		http.StatusCreated,
+		duration,
+		details,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Notify the handlers about the copy of the task
+	if len(details.Task.HandlerIDs) != 0 {
+		usersData, err := c.userProducer.GetUsers(details.Task.HandlerIDs)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		notificationUsers := []model.NotificationUser{}
+		for _, userData := range usersData {
+			notificationUser := model.NotificationUser{
+				UserID:  userData.ID,
+				Email:   userData.Email,
+				Message: fmt.Sprintf("You have been assigned a copy of the task `%s`", details.Task.Description),
+			}
+
+			notificationUsers = append(notificationUsers, notificationUser)
+		}
+
+		if err = rabbitmq.GenerateNotificationData(c.notificationProducer, notificationUsers, "email", details.Task); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Generate the version of the new task and of its subtasks
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, details.Task.ID, details.Task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	for _, subtask := range details.Subtasks {
+		if err = rabbitmq.GenerateVersionData(c.versionProducer, subtask.ID, subtask); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, details); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *taskController) PromoteSubtask(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.PromoteSubtaskSchema{
+		UserID:    user.UID,
+		TaskID:    chi.URLParam(r, "taskId"),
+		SubtaskID: chi.URLParam(r, "subtaskId"),
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to promote the subtask
+	promotion, duration, err := utils.MeasureTime("Promote-Subtask", func() (model.SubtaskPromotion, error) {
+		return c.taskService.PromoteSubtask(r.Context(), inputData.TaskID, inputData.SubtaskID, inputData.Deadline, inputData.Priority, inputData.KeepSubtask)
+	})
+	if err != nil {
+		workflowErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` promoted the subtask `%s` of the task `%s` to the task `%s`", inputData.UserID, inputData.SubtaskID, inputData.TaskID, promotion.Task.ID),
+		"audit",
+		http.StatusCreated,
+		duration,
+		promotion,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Notify the handler of the subtask about the new task
+	if len(promotion.Task.HandlerIDs) != 0 {
+		usersData, err := c.userProducer.GetUsers(promotion.Task.HandlerIDs)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		notificationUsers := []model.NotificationUser{}
+		for _, userData := range usersData {
+			notificationUser := model.NotificationUser{
+				UserID:  userData.ID,
+				Email:   userData.Email,
+				Message: fmt.Sprintf("Your subtask `%s` of the task `%s` is now a task of its own", promotion.Task.Description, promotion.ParentTask.Description),
+			}
+
+			notificationUsers = append(notificationUsers, notificationUser)
+		}
+
+		if err = rabbitmq.GenerateNotificationData(c.notificationProducer, notificationUsers, "email", promotion.Task); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Generate the version of the new task and of the original task
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, promotion.Task.ID, promotion.Task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, promotion.ParentTask.ID, promotion.ParentTask); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the version of the subtask when it was kept
+	if !promotion.Removed {
+		if err = rabbitmq.GenerateVersionData(c.versionProducer, promotion.Subtask.ID, promotion.Subtask); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, promotion); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *taskController) BulkUpdateTasks(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.BulkTaskOperationSchema{
+		UserID: user.UID,
+	}
+
+	// Validate the input data and the request body data
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	operation := model.BulkTaskOperation{
+		Operation:  inputData.Operation,
+		Status:     inputData.Status,
+		HandlerIDs: inputData.HandlerIDs,
+		Deadline:   inputData.Deadline,
+		Override:   inputData.Override,
+	}
+
+	// Send the data to the service layer to apply the operation to the tasks
+	result, duration, err := utils.MeasureTime("Bulk-Update-Tasks", func() (model.BulkResult, error) {
+		return c.taskService.BulkUpdateTasks(r.Context(), inputData.UserID, inputData.TaskIDs, operation)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` applied the bulk operation `%s` to `%d` tasks, `%d` failed", inputData.UserID, inputData.Operation, result.Succeeded, result.Failed),
+		"audit",
+		http.StatusCreated,
+		duration,
+		result,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Group the affected tasks by user so each user receives a single notification
+	affectedTasks := map[string][]string{}
+	var affectedUserIds []string
+	for _, task := range result.Tasks {
+		// The added or removed handlers are notified about the handler operations,
+		// the task handlers are notified about the other operations
+		recipients := task.HandlerIDs
+		if operation.Operation == model.BulkAddHandlers || operation.Operation == model.BulkRemoveHandlers {
+			recipients = operation.HandlerIDs
+		}
+
+		for _, userId := range recipients {
+			if _, ok := affectedTasks[userId]; !ok {
+				affectedUserIds = append(affectedUserIds, userId)
+			}
+			affectedTasks[userId] = append(affectedTasks[userId], task.Description)
+		}
+	}
+
+	if len(affectedUserIds) != 0 {
+		// Get the data of the affected users
+		usersData, err := c.userProducer.GetUsers(affectedUserIds)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// Generate the aggregated notification of each user
+		notificationUsers := []model.NotificationUser{}
+		for _, userData := range usersData {
+			notificationUser := model.NotificationUser{
+				UserID:  userData.ID,
+				Email:   userData.Email,
+				Message: bulkNotificationMessage(operation, affectedTasks[userData.ID]),
+			}
+
+			notificationUsers = append(notificationUsers, notificationUser)
+		}
+
+		// Notify the users
+		if err = rabbitmq.GenerateNotificationData(c.notificationProducer, notificationUsers, "email", nil); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Notify the handlers of the tasks that are no longer blocked
+	if operation.Operation == model.BulkSetStatus {
+		if err = c.notifyUnblockedTasks(r.Context(), result.Tasks); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// Create the next occurrence of the completed recurring tasks
+		if err = c.continueTaskSeries(r.Context(), result.Tasks); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Generate the new version of each updated task
+	if operation.Operation != model.BulkDelete {
+		for _, task := range result.Tasks {
+			if err = rabbitmq.GenerateVersionData(c.versionProducer, task.ID, task); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, result); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
 // GET methods
 func (c *taskController) GetTasks(w http.ResponseWriter, r *http.Request) {
 	// Get the user data from the user token
@@ -297,19 +625,66 @@ func (c *taskController) GetTasks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get the ID of the last task retrieved
-	// Value can be nil
-	startAfter := r.URL.Query().Get("startAfter")
-	fmt.Printf("Received startAfter query param: '%s'\n", startAfter)
+	// Get the cursor returned with the previous page
+	// Value can be empty for the first page
+	cursor := r.URL.Query().Get("cursor")
+
+	// Get the optional filters from the request query
+	deadlineFrom, err := utils.ParseInt64Param(r, "deadlineFrom")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	deadlineTo, err := utils.ParseInt64Param(r, "deadlineTo")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	createdFrom, err := utils.ParseInt64Param(r, "createdFrom")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	createdTo, err := utils.ParseInt64Param(r, "createdTo")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	hasOpenSubtasks, err := utils.ParseBoolParam(r, "hasOpenSubtasks")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pendingAcceptance, err := utils.ParseBoolParam(r, "pendingAcceptance")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	// Generate the request schema
 	inputData := schemas.GetTasksSchema{
-		UserID:         user.UID,
-		ProjectID:      chi.URLParam(r, "projectId"),
-		OrderBy:        r.URL.Query().Get("orderBy"),
-		OrderDirection: r.URL.Query().Get("orderDirection"),
-		Limit:          limit,
-		StartAfter:     startAfter,
+		UserID:            user.UID,
+		ProjectID:         chi.URLParam(r, "projectId"),
+		OrderBy:           r.URL.Query().Get("orderBy"),
+		OrderDirection:    r.URL.Query().Get("orderDirection"),
+		Limit:             limit,
+		Cursor:            cursor,
+		Statuses:          utils.ParseListParam(r, "status"),
+		Priorities:        utils.ParseListParam(r, "priority"),
+		Labels:            utils.ParseListParam(r, "label"),
+		HandlerID:         r.URL.Query().Get("handlerId"),
+		AuthorID:          r.URL.Query().Get("authorId"),
+		DeadlineFrom:      deadlineFrom,
+		DeadlineTo:        deadlineTo,
+		CreatedFrom:       createdFrom,
+		CreatedTo:         createdTo,
+		HasOpenSubtasks:   hasOpenSubtasks,
+		PendingAcceptance: pendingAcceptance,
 	}
 
 	// Validate the input data
@@ -318,11 +693,39 @@ func (c *taskController) GetTasks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Generate the task query
+	taskQuery := model.TaskQuery{
+		ProjectID:         inputData.ProjectID,
+		Limit:             inputData.Limit,
+		OrderBy:           inputData.OrderBy,
+		OrderDirection:    inputData.OrderDirection,
+		Cursor:            inputData.Cursor,
+		Statuses:          inputData.Statuses,
+		Priorities:        inputData.Priorities,
+		Labels:            inputData.Labels,
+		HandlerID:         inputData.HandlerID,
+		AuthorID:          inputData.AuthorID,
+		DeadlineFrom:      inputData.DeadlineFrom,
+		DeadlineTo:        inputData.DeadlineTo,
+		CreatedFrom:       inputData.CreatedFrom,
+		CreatedTo:         inputData.CreatedTo,
+		HasOpenSubtasks:   inputData.HasOpenSubtasks,
+		PendingAcceptance: inputData.PendingAcceptance,
+	}
+
 	// Send the data to the service layer to retrieve the task lis
+	var nextCursor string
 	tasks, duration, err := utils.MeasureTime("Get-Tasks", func() ([]model.Task, error) {
-		return c.taskService.GetTasks(r.Context(), inputData.ProjectID, inputData.Limit, inputData.OrderBy, inputData.OrderDirection, inputData.StartAfter)
+		var tasks []model.Task
+		var err error
+		tasks, nextCursor, err = c.taskService.GetTasks(r.Context(), taskQuery)
+		return tasks, err
 	})
 	if err != nil {
+		if errors.Is(err, utils.ErrInvalidCursor) || errors.Is(err, utils.ErrInvalidRank) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -360,8 +763,8 @@ func (c *taskController) GetTasks(w http.ResponseWriter, r *http.Request) {
 		taskCards = append(taskCards, taskCard)
 	}
 
-	// Encode the data and return it
-	if err = utils.EncodeData(w, r, taskCards); err != nil {
+	// Encode the data with the cursor of the next page and return it
+	if err = utils.EncodePaginatedData(w, r, taskCards, nextCursor); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -439,10 +842,22 @@ func (c *taskController) GetSubtasks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Get the optional page size from the request query
+	limit, err := utils.ParseInt64Param(r, "limit")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Generate the request schema
 	inputData := schemas.GetSubtasksSchema{
 		UserID: user.UID,
 		TaskID: chi.URLParam(r, "taskId"),
+		Cursor: r.URL.Query().Get("cursor"),
+	}
+
+	if limit != nil {
+		inputData.Limit = int(*limit)
 	}
 
 	// Validate the input data
@@ -452,10 +867,18 @@ func (c *taskController) GetSubtasks(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Send the data to the service layer to retrieve the subtasks
+	var nextCursor string
 	subtasks, duration, err := utils.MeasureTime("Get-Subtasks", func() ([]model.Subtask, error) {
-		return c.taskService.GetSubtasks(r.Context(), inputData.TaskID)
+		var subtasks []model.Subtask
+		var err error
+		subtasks, nextCursor, err = c.taskService.GetSubtasks(r.Context(), inputData.TaskID, inputData.Limit, inputData.Cursor)
+		return subtasks, err
 	})
 	if err != nil {
+		if errors.Is(err, utils.ErrInvalidCursor) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -475,8 +898,8 @@ func (c *taskController) GetSubtasks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Encode the data and return it
-	if err = utils.EncodeData(w, r, subtasks); err != nil {
+	// Encode the data with the cursor of the next page and return it
+	if err = utils.EncodePaginatedData(w, r, subtasks, nextCursor); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -543,50 +966,1395 @@ func (c *taskController) GetResponses(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Get the optional page size from the request query
+	limit, err := utils.ParseInt64Param(r, "limit")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Generate the request schema
 	inputData := schemas.GetResponsesSchema{
 		UserID: user.UID,
 		TaskID: chi.URLParam(r, "taskId"),
+		Cursor: r.URL.Query().Get("cursor"),
 	}
 
-	// Validate the input data
+	if limit != nil {
+		inputData.Limit = int(*limit)
+	}
+
+	// Validate the input data
 	if err = utils.ValidateParams(inputData); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	// Send the data to the service layer to retrieve the responses of the task
+	var nextCursor string
 	responses, duration, err := utils.MeasureTime("Get-Responses", func() ([]model.Response, error) {
-		return c.taskService.GetResponses(r.Context(), inputData.TaskID)
+		var responses []model.Response
+		var err error
+		responses, nextCursor, err = c.taskService.GetResponses(r.Context(), inputData.TaskID, inputData.Limit, inputData.Cursor)
+		return responses, err
+	})
+	if err != nil {
+		if errors.Is(err, utils.ErrInvalidCursor) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the responses for the task `%s`", inputData.UserID, inputData.TaskID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		responses,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data with the cursor of the next page and return it
+	if err = utils.EncodePaginatedData(w, r, responses, nextCursor); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *taskController) GetResponseById(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the input data request schema
+	inputData := schemas.GetResponseByIdSchema{
+		UserID:     user.UID,
+		TaskID:     chi.URLParam(r, "taskId"),
+		ResponseID: chi.URLParam(r, "responseId"),
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to retrieve the data of the response
+	response, duration, err := utils.MeasureTime("Get-ResponseById", func() (model.Response, error) {
+		return c.taskService.GetResponseById(r.Context(), inputData.TaskID, inputData.ResponseID)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the data of the response `%s` of the task `%s`", inputData.UserID, inputData.ResponseID, inputData.TaskID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		response,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+}
+
+func (c *taskController) GetUserWork(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Get the due window from the request query
+	deadlineFrom, err := utils.ParseInt64Param(r, "deadlineFrom")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	deadlineTo, err := utils.ParseInt64Param(r, "deadlineTo")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetUserWorkSchema{
+		UserID:       user.UID,
+		Statuses:     utils.ParseListParam(r, "status"),
+		DeadlineFrom: deadlineFrom,
+		DeadlineTo:   deadlineTo,
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the task query for the tasks handled by the user
+	taskQuery := model.TaskQuery{
+		HandlerID:    inputData.UserID,
+		Statuses:     inputData.Statuses,
+		DeadlineFrom: inputData.DeadlineFrom,
+		DeadlineTo:   inputData.DeadlineTo,
+	}
+
+	// Send the data to the service layer to retrieve the work of the user
+	work, duration, err := utils.MeasureTime("Get-User-Work", func() ([]model.ProjectWork, error) {
+		return c.taskService.GetUserWork(r.Context(), taskQuery)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the tasks and subtasks assigned to them", inputData.UserID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		work,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, work); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *taskController) GetDependencyGraph(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetDependencyGraphSchema{
+		UserID:    user.UID,
+		ProjectID: chi.URLParam(r, "projectId"),
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to retrieve the dependency graph
+	graph, duration, err := utils.MeasureTime("Get-Dependency-Graph", func() (model.DependencyGraph, error) {
+		return c.taskService.GetDependencyGraph(r.Context(), inputData.ProjectID)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the dependency graph of the project `%s`", inputData.UserID, inputData.ProjectID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		graph,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, graph); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *taskController) GetHandlerSuggestions(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Get the optional deadline of the new task, the members absent before it are left out
+	deadline, err := utils.ParseInt64Param(r, "deadline")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetHandlerSuggestionsSchema{
+		UserID:    user.UID,
+		ProjectID: chi.URLParam(r, "projectId"),
+	}
+
+	if deadline != nil {
+		inputData.Deadline = *deadline
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to rank the project members as candidate handlers
+	suggestions, duration, err := utils.MeasureTime("Get-Handler-Suggestions", func() (model.HandlerSuggestions, error) {
+		return c.taskService.GetHandlerSuggestions(r.Context(), inputData.UserID, inputData.ProjectID, inputData.Deadline)
+	})
+	if err != nil {
+		if errors.Is(err, utils.ErrForbidden) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the handler suggestions of the project `%s`", inputData.UserID, inputData.ProjectID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		suggestions,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, suggestions); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *taskController) GetTaskTree(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Get the optional number of descendant levels to retrieve
+	depth := 1
+	if depthParam := r.URL.Query().Get("depth"); depthParam != "" {
+		if depth, err = strconv.Atoi(depthParam); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetTaskTreeSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+		Depth:  depth,
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to retrieve the task hierarchy
+	tree, duration, err := utils.MeasureTime("Get-Task-Tree", func() (*model.TaskTree, error) {
+		return c.taskService.GetTaskTree(r.Context(), inputData.TaskID, inputData.Depth)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the hierarchy of the task `%s`", inputData.UserID, inputData.TaskID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		tree,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, tree); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// PUT methods
+func (c *taskController) UpdateTaskDescription(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.UpdateTaskDescriptionSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data and the request body data
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to update the task description
+	task, duration, err := utils.MeasureTime("Update-Task-Description", func() (model.Task, error) {
+		return c.taskService.UpdateTaskDescription(r.Context(), inputData.TaskID, inputData.Description)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` updated the task description to: `%s`", inputData.UserID, inputData.Description),
+		"audit",
+		http.StatusCreated,
+		duration,
+		task,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Get the handlers data
+	usersData, err := c.userProducer.GetUsers(task.HandlerIDs)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the notification users list
+	notificationUsers := []model.NotificationUser{}
+	for _, userData := range usersData {
+		notificationUser := model.NotificationUser{
+			UserID:  userData.ID,
+			Email:   userData.Email,
+			Message: fmt.Sprintf("Description of the task `%s` has been updated", task.Description),
+		}
+
+		notificationUsers = append(notificationUsers, notificationUser)
+	}
+
+	// Notify the handlers of the task about the update
+	if err = rabbitmq.GenerateNotificationData(c.notificationProducer, notificationUsers, "email", task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Update the version of the task
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, task.ID, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *taskController) AddTaskHandlers(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.AddTaskHandlersSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data and the request body data
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to update the task handlers
+	var exceeded []string
+	task, duration, err := utils.MeasureTime("Add-Task-Handlers", func() (model.Task, error) {
+		var task model.Task
+		var err error
+		task, exceeded, err = c.taskService.AddTaskHandlers(r.Context(), inputData.UserID, inputData.TaskID, inputData.HandlerIDs, inputData.Override)
+		return task, err
+	})
+	if err != nil {
+		wipErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` added new handlers to the task `%s`: `%v`%s%s", inputData.UserID, inputData.TaskID, inputData.HandlerIDs, wipOverrideMessage(exceeded), absenceWarningMessage(task.AbsenceWarnings)),
+		"audit",
+		http.StatusCreated,
+		duration,
+		task,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Get the new handlers data
+	usersData, err := c.userProducer.GetUsers(inputData.HandlerIDs)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the user notification data
+	notificationUsers := []model.NotificationUser{}
+	for _, userData := range usersData {
+		notificationUser := model.NotificationUser{
+			UserID:  userData.ID,
+			Email:   userData.Email,
+			Message: fmt.Sprintf("You have been assigned the task `%s`, please accept or decline the assignment", task.Description),
+		}
+
+		notificationUsers = append(notificationUsers, notificationUser)
+	}
+
+	// Notify the new handlers
+	if err = rabbitmq.GenerateNotificationData(c.notificationProducer, notificationUsers, "email", task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the new task version
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, task.ID, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *taskController) AcceptTaskAssignment(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.AcceptTaskAssignmentSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to accept the assignment
+	task, duration, err := utils.MeasureTime("Accept-Task-Assignment", func() (model.Task, error) {
+		return c.taskService.AcceptTaskAssignment(r.Context(), inputData.UserID, inputData.TaskID)
+	})
+	if err != nil {
+		assignmentErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` accepted the assignment to the task `%s`", inputData.UserID, inputData.TaskID),
+		"audit",
+		http.StatusOK,
+		duration,
+		task,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the new task version
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, task.ID, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *taskController) DeclineTaskAssignment(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.DeclineTaskAssignmentSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data and the request body data
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to decline the assignment
+	task, duration, err := utils.MeasureTime("Decline-Task-Assignment", func() (model.Task, error) {
+		return c.taskService.DeclineTaskAssignment(r.Context(), inputData.UserID, inputData.TaskID, inputData.Reason)
+	})
+	if err != nil {
+		assignmentErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` declined the assignment to the task `%s`", inputData.UserID, inputData.TaskID),
+		"audit",
+		http.StatusOK,
+		duration,
+		task,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Notify the author of the task
+	if err = c.notifyUsers(
+		[]string{task.AuthorID},
+		fmt.Sprintf("User `%s` declined the task `%s`: %s", inputData.UserID, task.Description, inputData.Reason),
+		task,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the new task version
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, task.ID, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *taskController) RemoveTaskHandlers(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.RemoveTaskHandlersSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to remove the handlers
+	task, duration, err := utils.MeasureTime("Remove-Task-Handlers", func() (model.Task, error) {
+		return c.taskService.RemoveTaskHandlers(r.Context(), inputData.TaskID, inputData.HandlerIDs)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` removed `%v` handlers from the task `%s`", inputData.UserID, inputData.HandlerIDs, inputData.TaskID),
+		"audit",
+		http.StatusCreated,
+		duration,
+		task,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Get the removed handlers data
+	usersData, err := c.userProducer.GetUsers(inputData.HandlerIDs)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the user notification data
+	notificationUsers := []model.NotificationUser{}
+	for _, userData := range usersData {
+		notificationUser := model.NotificationUser{
+			UserID:  userData.ID,
+			Email:   userData.Email,
+			Message: fmt.Sprintf("You have been removed from the task `%s`", task.Description),
+		}
+
+		notificationUsers = append(notificationUsers, notificationUser)
+	}
+
+	// Notify the users
+	if err = rabbitmq.GenerateNotificationData(c.notificationProducer, notificationUsers, "email", nil); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the task version data
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, task.ID, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *taskController) AddTaskLabels(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.AddTaskLabelsSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to add the labels
+	task, duration, err := utils.MeasureTime("Add-Task-Labels", func() (model.Task, error) {
+		return c.taskService.AddTaskLabels(r.Context(), inputData.TaskID, inputData.Labels)
+	})
+	if err != nil {
+		if errors.Is(err, utils.ErrInvalidLabel) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` added the labels `%v` to the task `%s`", inputData.UserID, inputData.Labels, inputData.TaskID),
+		"audit",
+		http.StatusCreated,
+		duration,
+		task,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the task version data
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, task.ID, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *taskController) RemoveTaskLabels(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.RemoveTaskLabelsSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to remove the labels
+	task, duration, err := utils.MeasureTime("Remove-Task-Labels", func() (model.Task, error) {
+		return c.taskService.RemoveTaskLabels(r.Context(), inputData.TaskID, inputData.Labels)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` removed the labels `%v` from the task `%s`", inputData.UserID, inputData.Labels, inputData.TaskID),
+		"audit",
+		http.StatusCreated,
+		duration,
+		task,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the task version data
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, task.ID, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *taskController) AddTaskDependency(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.AddTaskDependencySchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to add the dependency
+	task, duration, err := utils.MeasureTime("Add-Task-Dependency", func() (model.Task, error) {
+		return c.taskService.AddTaskDependency(r.Context(), inputData.TaskID, inputData.BlockerID)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrInvalidDependency):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, utils.ErrDependencyCycle):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` marked the task `%s` as blocked by the task `%s`", inputData.UserID, inputData.TaskID, inputData.BlockerID),
+		"audit",
+		http.StatusCreated,
+		duration,
+		task,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the task version data
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, task.ID, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *taskController) RemoveTaskDependency(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.RemoveTaskDependencySchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to remove the dependency
+	task, duration, err := utils.MeasureTime("Remove-Task-Dependency", func() (model.Task, error) {
+		return c.taskService.RemoveTaskDependency(r.Context(), inputData.TaskID, inputData.BlockerID)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` removed the task `%s` from the blockers of the task `%s`", inputData.UserID, inputData.BlockerID, inputData.TaskID),
+		"audit",
+		http.StatusCreated,
+		duration,
+		task,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the task version data
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, task.ID, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *taskController) AddTaskChildren(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.AddTaskChildrenSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to attach the children
+	children, duration, err := utils.MeasureTime("Add-Task-Children", func() ([]model.Task, error) {
+		return c.taskService.AddTaskChildren(r.Context(), inputData.TaskID, inputData.ChildIDs)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrInvalidHierarchy):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, utils.ErrHierarchyCycle):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` attached the tasks `%v` to the task `%s`", inputData.UserID, inputData.ChildIDs, inputData.TaskID),
+		"audit",
+		http.StatusCreated,
+		duration,
+		children,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the new version of each child task
+	for _, child := range children {
+		if err = rabbitmq.GenerateVersionData(c.versionProducer, child.ID, child); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, children); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *taskController) RemoveTaskChildren(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.RemoveTaskChildrenSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to detach the children
+	children, duration, err := utils.MeasureTime("Remove-Task-Children", func() ([]model.Task, error) {
+		return c.taskService.RemoveTaskChildren(r.Context(), inputData.TaskID, inputData.ChildIDs)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` detached the tasks `%v` from the task `%s`", inputData.UserID, inputData.ChildIDs, inputData.TaskID),
+		"audit",
+		http.StatusCreated,
+		duration,
+		children,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the new version of each detached task
+	for _, child := range children {
+		if err = rabbitmq.GenerateVersionData(c.versionProducer, child.ID, child); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, children); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *taskController) MoveTask(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.MoveTaskSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to move the task
+	move, duration, err := utils.MeasureTime("Move-Task", func() (model.TaskMove, error) {
+		return c.taskService.MoveTask(r.Context(), inputData.UserID, inputData.TaskID, inputData.ProjectID, inputData.NonMembers)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrForbidden):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case errors.Is(err, utils.ErrInvalidMove):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, utils.ErrHandlersNotMembers), errors.Is(err, utils.ErrWipLimitExceeded):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Generate the log data of the source project
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` moved the task `%s` out of the project `%s` to the project `%s`", inputData.UserID, inputData.TaskID, move.FromProjectID, move.ToProjectID),
+		"audit",
+		http.StatusCreated,
+		duration,
+		move,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the log data of the target project
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` moved the task `%s` into the project `%s` from the project `%s`", inputData.UserID, inputData.TaskID, move.ToProjectID, move.FromProjectID),
+		"audit",
+		http.StatusCreated,
+		duration,
+		move,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Notify the handlers that were dropped from the task
+	if len(move.DroppedHandlerIDs) != 0 {
+		usersData, err := c.userProducer.GetUsers(move.DroppedHandlerIDs)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		notificationUsers := []model.NotificationUser{}
+		for _, userData := range usersData {
+			notificationUser := model.NotificationUser{
+				UserID:  userData.ID,
+				Email:   userData.Email,
+				Message: fmt.Sprintf("You have been removed from the task `%s` since it was moved to a project you are not part of", move.Task.Description),
+			}
+
+			notificationUsers = append(notificationUsers, notificationUser)
+		}
+
+		if err = rabbitmq.GenerateNotificationData(c.notificationProducer, notificationUsers, "email", nil); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Generate the new task version
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, move.Task.ID, move.Task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, move); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *taskController) UpdateTaskStatus(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	fmt.Printf("%+v", r.Body)
+
+	// Generate the request schema
+	inputData := schemas.UpdateTaskStatusSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data and the request body data
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	fmt.Printf("%+v", inputData)
+
+	// Send the data to the service layer to update the task status
+	var exceeded []string
+	task, duration, err := utils.MeasureTime("Update-Task-Status", func() (model.Task, error) {
+		var task model.Task
+		var err error
+		task, exceeded, err = c.taskService.UpdateTaskStatus(r.Context(), inputData.UserID, inputData.TaskID, inputData.Status, inputData.Override)
+		return task, err
+	})
+	if err != nil {
+		workflowErrorStatus(w, err)
+		return
+	}
+
+	// Generate log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("Uset `%s` updated the status of the task `%s` to `%s`%s", inputData.UserID, inputData.TaskID, inputData.Status, wipOverrideMessage(exceeded)),
+		"audit",
+		http.StatusCreated,
+		duration,
+		task,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Get the task handlers data
+	usersData, err := c.userProducer.GetUsers(task.HandlerIDs)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the user notification data
+	notificationUsers := []model.NotificationUser{}
+	for _, userData := range usersData {
+		notificationUser := model.NotificationUser{
+			UserID:  userData.ID,
+			Email:   userData.Email,
+			Message: fmt.Sprintf("Status updated to `%s` for the task `%s`", inputData.Status, task.Description),
+		}
+
+		notificationUsers = append(notificationUsers, notificationUser)
+	}
+
+	// Notify the users
+	if err = rabbitmq.GenerateNotificationData(c.notificationProducer, notificationUsers, "email", task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Notify the handlers of the tasks that are no longer blocked
+	if err = c.notifyUnblockedTasks(r.Context(), []model.Task{task}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Create the next occurrence of a completed recurring task
+	if err = c.continueTaskSeries(r.Context(), []model.Task{task}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the new task version
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, task.ID, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *taskController) UpdateTaskRank(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.UpdateTaskRankSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data and the request body data
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to move the task on the board
+	var exceeded []string
+	task, duration, err := utils.MeasureTime("Update-Task-Rank", func() (model.Task, error) {
+		var task model.Task
+		var err error
+		task, exceeded, err = c.taskService.UpdateTaskRank(r.Context(), inputData.UserID, inputData.TaskID, inputData.Status, inputData.AfterID, inputData.BeforeID, inputData.Override)
+		return task, err
 	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		workflowErrorStatus(w, err)
 		return
 	}
 
-	// Generate the log data
+	// Generate log data
 	if err = rabbitmq.GenerateLogData(
 		w,
 		r,
 		c.loggerProducer,
-		fmt.Sprintf("User `%s` retrieved the responses for the task `%s`", inputData.UserID, inputData.TaskID),
-		"info",
-		http.StatusAccepted,
+		fmt.Sprintf("User `%s` moved the task `%s` to the `%s` column at rank `%s`%s", inputData.UserID, inputData.TaskID, inputData.Status, task.Rank, wipOverrideMessage(exceeded)),
+		"audit",
+		http.StatusOK,
 		duration,
-		responses,
+		task,
 	); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	// Notify the handlers of the tasks that are no longer blocked
+	if err = c.notifyUnblockedTasks(r.Context(), []model.Task{task}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Create the next occurrence of a completed recurring task
+	if err = c.continueTaskSeries(r.Context(), []model.Task{task}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the new task version
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, task.ID, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	// Encode the data and return it
-	if err = utils.EncodeData(w, r, responses); err != nil {
+	if err = utils.EncodeData(w, r, task); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 }
 
-func (c *taskController) GetResponseById(w http.ResponseWriter, r *http.Request) {
+func (c *taskController) SetTaskReviewers(w http.ResponseWriter, r *http.Request) {
 	// Get the user data from the user token
 	user, err := middleware.GetUserFromContext(r.Context())
 	if err != nil {
@@ -594,25 +2362,24 @@ func (c *taskController) GetResponseById(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Generate the input data request schema
-	inputData := schemas.GetResponseByIdSchema{
-		UserID:     user.UID,
-		TaskID:     chi.URLParam(r, "taskId"),
-		ResponseID: chi.URLParam(r, "responseId"),
+	// Generate the request schema
+	inputData := schemas.SetTaskReviewersSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
 	}
 
-	// Validate the input data
-	if err = utils.ValidateParams(inputData); err != nil {
+	// Validate the input data and the request body data
+	if err = utils.ValidateBody(r, &inputData); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// Send the data to the service layer to retrieve the data of the response
-	response, duration, err := utils.MeasureTime("Get-ResponseById", func() (model.Response, error) {
-		return c.taskService.GetResponseById(r.Context(), inputData.TaskID, inputData.ResponseID)
+	// Send the data to the service layer to replace the task reviewers
+	task, duration, err := utils.MeasureTime("Set-Task-Reviewers", func() (model.Task, error) {
+		return c.taskService.SetTaskReviewers(r.Context(), inputData.UserID, inputData.TaskID, inputData.ReviewerIDs)
 	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		workflowErrorStatus(w, err)
 		return
 	}
 
@@ -621,26 +2388,30 @@ func (c *taskController) GetResponseById(w http.ResponseWriter, r *http.Request)
 		w,
 		r,
 		c.loggerProducer,
-		fmt.Sprintf("User `%s` retrieved the data of the response `%s` of the task `%s`", inputData.UserID, inputData.ResponseID, inputData.TaskID),
-		"info",
-		http.StatusAccepted,
+		fmt.Sprintf("User `%s` set the reviewers of the task `%s`: `%v`", inputData.UserID, inputData.TaskID, task.ReviewerIDs),
+		"audit",
+		http.StatusOK,
 		duration,
-		response,
+		task,
 	); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// Encode the data and return it
-	if err = utils.EncodeData(w, r, response); err != nil {
+	// Generate the new task version
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, task.ID, task); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 }
 
-// PUT methods
-func (c *taskController) UpdateTaskDescription(w http.ResponseWriter, r *http.Request) {
+func (c *taskController) RequestTaskReview(w http.ResponseWriter, r *http.Request) {
 	// Get the user data from the user token
 	user, err := middleware.GetUserFromContext(r.Context())
 	if err != nil {
@@ -649,7 +2420,7 @@ func (c *taskController) UpdateTaskDescription(w http.ResponseWriter, r *http.Re
 	}
 
 	// Generate the request schema
-	inputData := schemas.UpdateTaskDescriptionSchema{
+	inputData := schemas.RequestTaskReviewSchema{
 		UserID: user.UID,
 		TaskID: chi.URLParam(r, "taskId"),
 	}
@@ -660,12 +2431,16 @@ func (c *taskController) UpdateTaskDescription(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	// Send the data to the service layer to update the task description
-	task, duration, err := utils.MeasureTime("Update-Task-Description", func() (model.Task, error) {
-		return c.taskService.UpdateTaskDescription(r.Context(), inputData.TaskID, inputData.Description)
+	// Send the data to the service layer to move the task into the review
+	var exceeded []string
+	task, duration, err := utils.MeasureTime("Request-Task-Review", func() (model.Task, error) {
+		var task model.Task
+		var err error
+		task, exceeded, err = c.taskService.RequestTaskReview(r.Context(), inputData.UserID, inputData.TaskID, inputData.Override)
+		return task, err
 	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		workflowErrorStatus(w, err)
 		return
 	}
 
@@ -674,9 +2449,9 @@ func (c *taskController) UpdateTaskDescription(w http.ResponseWriter, r *http.Re
 		w,
 		r,
 		c.loggerProducer,
-		fmt.Sprintf("User `%s` updated the task description to: `%s`", inputData.UserID, inputData.Description),
+		fmt.Sprintf("User `%s` requested a review of the task `%s`%s", inputData.UserID, inputData.TaskID, wipOverrideMessage(exceeded)),
 		"audit",
-		http.StatusCreated,
+		http.StatusOK,
 		duration,
 		task,
 	); err != nil {
@@ -684,32 +2459,13 @@ func (c *taskController) UpdateTaskDescription(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	// Get the handlers data
-	usersData, err := c.userProducer.GetUsers(task.HandlerIDs)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	// Generate the notification users list
-	notificationUsers := []model.NotificationUser{}
-	for _, userData := range usersData {
-		notificationUser := model.NotificationUser{
-			UserID:  userData.ID,
-			Email:   userData.Email,
-			Message: fmt.Sprintf("Description of the task `%s` has been updated", task.Description),
-		}
-
-		notificationUsers = append(notificationUsers, notificationUser)
-	}
-
-	// Notify the handlers of the task about the update
-	if err = rabbitmq.GenerateNotificationData(c.notificationProducer, notificationUsers, "email", task); err != nil {
+	// Notify the reviewers
+	if err = c.notifyUsers(task.ReviewerIDs, fmt.Sprintf("Your review was requested for the task `%s`", task.Description), task); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// Update the version of the task
+	// Generate the new task version
 	if err = rabbitmq.GenerateVersionData(c.versionProducer, task.ID, task); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -722,32 +2478,32 @@ func (c *taskController) UpdateTaskDescription(w http.ResponseWriter, r *http.Re
 	}
 }
 
-func (c *taskController) AddTaskHandlers(w http.ResponseWriter, r *http.Request) {
+func (c *taskController) ApproveTaskReview(w http.ResponseWriter, r *http.Request) {
 	// Get the user data from the user token
 	user, err := middleware.GetUserFromContext(r.Context())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
 	}
 
 	// Generate the request schema
-	inputData := schemas.AddTaskHandlersSchema{
+	inputData := schemas.ApproveTaskReviewSchema{
 		UserID: user.UID,
 		TaskID: chi.URLParam(r, "taskId"),
 	}
 
 	// Validate the input data and the request body data
-	if err = utils.ValidateBody(r, inputData); err != nil {
+	if err = utils.ValidateBody(r, &inputData); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// Send the data to the service layer to update the task handlers
-	task, duration, err := utils.MeasureTime("Add-Task-Handlers", func() (model.Task, error) {
-		return c.taskService.AddTaskHandlers(r.Context(), inputData.TaskID, inputData.HandlerIDs)
+	// Send the data to the service layer to approve the task
+	review, duration, err := utils.MeasureTime("Approve-Task-Review", func() (model.TaskReview, error) {
+		return c.taskService.ApproveTaskReview(r.Context(), inputData.UserID, inputData.TaskID, inputData.Comment)
 	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		workflowErrorStatus(w, err)
 		return
 	}
 
@@ -756,55 +2512,48 @@ func (c *taskController) AddTaskHandlers(w http.ResponseWriter, r *http.Request)
 		w,
 		r,
 		c.loggerProducer,
-		fmt.Sprintf("User `%s` added new handlers to the task `%s`: `%v`", inputData.UserID, inputData.TaskID, inputData.HandlerIDs),
+		fmt.Sprintf("User `%s` approved the task `%s`", inputData.UserID, inputData.TaskID),
 		"audit",
-		http.StatusCreated,
+		http.StatusOK,
 		duration,
-		task,
+		review,
 	); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// Get the new handlers data
-	usersData, err := c.userProducer.GetUsers(inputData.HandlerIDs)
-	if err != nil {
+	// Notify the handlers
+	if err = c.notifyUsers(review.Task.HandlerIDs, fmt.Sprintf("The task `%s` was approved and completed", review.Task.Description), review); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// Generate the user notification data
-	notificationUsers := []model.NotificationUser{}
-	for _, userData := range usersData {
-		notificationUser := model.NotificationUser{
-			UserID:  userData.ID,
-			Email:   userData.Email,
-			Message: fmt.Sprintf("You have been assigned the task `%s`", task.Description),
-		}
-
-		notificationUsers = append(notificationUsers, notificationUser)
+	// Notify the handlers of the tasks that are no longer blocked
+	if err = c.notifyUnblockedTasks(r.Context(), []model.Task{review.Task}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	// Notify the new handlers
-	if err = rabbitmq.GenerateNotificationData(c.notificationProducer, notificationUsers, "email", task); err != nil {
+	// Create the next occurrence of a completed recurring task
+	if err = c.continueTaskSeries(r.Context(), []model.Task{review.Task}); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	// Generate the new task version
-	if err = rabbitmq.GenerateVersionData(c.versionProducer, task.ID, task); err != nil {
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, review.Task.ID, review.Task); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	// Encode the data and return it
-	if err = utils.EncodeData(w, r, task); err != nil {
+	if err = utils.EncodeData(w, r, review); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 }
 
-func (c *taskController) RemoveTaskHandlers(w http.ResponseWriter, r *http.Request) {
+func (c *taskController) RejectTaskReview(w http.ResponseWriter, r *http.Request) {
 	// Get the user data from the user token
 	user, err := middleware.GetUserFromContext(r.Context())
 	if err != nil {
@@ -813,23 +2562,23 @@ func (c *taskController) RemoveTaskHandlers(w http.ResponseWriter, r *http.Reque
 	}
 
 	// Generate the request schema
-	inputData := schemas.RemoveTaskHandlersSchema{
+	inputData := schemas.RejectTaskReviewSchema{
 		UserID: user.UID,
 		TaskID: chi.URLParam(r, "taskId"),
 	}
 
-	// Validate the input data and the request body
-	if err = utils.ValidateBody(r, inputData); err != nil {
+	// Validate the input data and the request body data
+	if err = utils.ValidateBody(r, &inputData); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// Send the data to the service layer to remove the handlers
-	task, duration, err := utils.MeasureTime("Remove-Task-Handlers", func() (model.Task, error) {
-		return c.taskService.RemoveTaskHandlers(r.Context(), inputData.TaskID, inputData.HandlerIDs)
+	// Send the data to the service layer to send the task back to development
+	review, duration, err := utils.MeasureTime("Reject-Task-Review", func() (model.TaskReview, error) {
+		return c.taskService.RejectTaskReview(r.Context(), inputData.UserID, inputData.TaskID, inputData.Comment)
 	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		workflowErrorStatus(w, err)
 		return
 	}
 
@@ -838,55 +2587,36 @@ func (c *taskController) RemoveTaskHandlers(w http.ResponseWriter, r *http.Reque
 		w,
 		r,
 		c.loggerProducer,
-		fmt.Sprintf("User `%s` removed `%v` handlers from the task `%s`", inputData.UserID, inputData.HandlerIDs, inputData.TaskID),
+		fmt.Sprintf("User `%s` rejected the task `%s`", inputData.UserID, inputData.TaskID),
 		"audit",
-		http.StatusCreated,
+		http.StatusOK,
 		duration,
-		task,
+		review,
 	); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// Get the removed handlers data
-	usersData, err := c.userProducer.GetUsers(inputData.HandlerIDs)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	// Generate the user notification data
-	notificationUsers := []model.NotificationUser{}
-	for _, userData := range usersData {
-		notificationUser := model.NotificationUser{
-			UserID:  userData.ID,
-			Email:   userData.Email,
-			Message: fmt.Sprintf("You have been removed from the task `%s`", task.Description),
-		}
-
-		notificationUsers = append(notificationUsers, notificationUser)
-	}
-
-	// Notify the users
-	if err = rabbitmq.GenerateNotificationData(c.notificationProducer, notificationUsers, "email", nil); err != nil {
+	// Notify the handlers
+	if err = c.notifyUsers(review.Task.HandlerIDs, fmt.Sprintf("The review of the task `%s` was rejected: %s", review.Task.Description, inputData.Comment), review); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// Generate the task version data
-	if err = rabbitmq.GenerateVersionData(c.versionProducer, task.ID, task); err != nil {
+	// Generate the new task version
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, review.Task.ID, review.Task); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	// Encode the data and return it
-	if err = utils.EncodeData(w, r, task); err != nil {
+	if err = utils.EncodeData(w, r, review); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 }
 
-func (c *taskController) UpdateTaskStatus(w http.ResponseWriter, r *http.Request) {
+func (c *taskController) UpdateTaskPriority(w http.ResponseWriter, r *http.Request) {
 	// Get the user data from the user token
 	user, err := middleware.GetUserFromContext(r.Context())
 	if err != nil {
@@ -894,10 +2624,8 @@ func (c *taskController) UpdateTaskStatus(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	fmt.Printf("%+v", r.Body)
-
 	// Generate the request schema
-	inputData := schemas.UpdateTaskStatusSchema{
+	inputData := schemas.UpdateTaskPrioritySchema{
 		UserID: user.UID,
 		TaskID: chi.URLParam(r, "taskId"),
 	}
@@ -908,11 +2636,9 @@ func (c *taskController) UpdateTaskStatus(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	fmt.Printf("%+v", inputData)
-
-	// Send the data to the service layer to update the task status
-	task, duration, err := utils.MeasureTime("Update-Task-Status", func() (model.Task, error) {
-		return c.taskService.UpdateTaskStatus(r.Context(), inputData.TaskID, inputData.Status)
+	// Send the data to the service layer to update the task priority
+	task, duration, err := utils.MeasureTime("Update-Task-Priority", func() (model.Task, error) {
+		return c.taskService.UpdateTaskPriority(r.Context(), inputData.TaskID, inputData.Priority, inputData.Severity)
 	})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -924,7 +2650,7 @@ func (c *taskController) UpdateTaskStatus(w http.ResponseWriter, r *http.Request
 		w,
 		r,
 		c.loggerProducer,
-		fmt.Sprintf("Uset `%s` updated the status of the task `%s` to `%s`", inputData.UserID, inputData.TaskID, inputData.Status),
+		fmt.Sprintf("User `%s` updated the priority of the task `%s` to `%s`", inputData.UserID, inputData.TaskID, inputData.Priority),
 		"audit",
 		http.StatusCreated,
 		duration,
@@ -941,13 +2667,19 @@ func (c *taskController) UpdateTaskStatus(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// Generate the notification message
+	message := fmt.Sprintf("Priority updated to `%s` for the task `%s`", task.Priority, task.Description)
+	if task.Severity != "" {
+		message = fmt.Sprintf("Priority updated to `%s` with a `%s` severity for the task `%s`", task.Priority, task.Severity, task.Description)
+	}
+
 	// Generate the user notification data
 	notificationUsers := []model.NotificationUser{}
 	for _, userData := range usersData {
 		notificationUser := model.NotificationUser{
 			UserID:  userData.ID,
 			Email:   userData.Email,
-			Message: fmt.Sprintf("Status updated to `%s` for the task `%s`", inputData.Status, task.Description),
+			Message: message,
 		}
 
 		notificationUsers = append(notificationUsers, notificationUser)
@@ -1326,7 +3058,7 @@ func (c *taskController) RerollTaskVersion(w http.ResponseWriter, r *http.Reques
 		return c.taskService.RerollTaskVersion(r.Context(), inputData.TaskID, inputData.Task)
 	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		workflowErrorStatus(w, err)
 		return
 	}
 
@@ -1345,42 +3077,19 @@ func (c *taskController) RerollTaskVersion(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	// Check if the handlers of the project are different
-	removedHandlers, addedHandlers, err := rabbitmq.CheckTaskHandlers(currentTask.HandlerIDs, task.HandlerIDs, *c.userProducer)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	var notificationUsers []model.NotificationUser
-
-	// Iterate over the removed task handlers and generate notification messages for each user
-	for _, removedHandler := range removedHandlers {
-		notificationUser := model.NotificationUser{
-			UserID:  removedHandler.ID,
-			Email:   removedHandler.Email,
-			Message: fmt.Sprintf("You have been removed from the task `%s`", currentTask.Description),
+	// The rolled back status does not repeat the side effects of an unchanged status
+	if task.Status != currentTask.Status {
+		// Notify the handlers of the tasks that are no longer blocked
+		if err = c.notifyUnblockedTasks(r.Context(), []model.Task{task}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 
-		notificationUsers = append(notificationUsers, notificationUser)
-	}
-
-	// Iterate over the added task handlers and generate notification messages for each user
-	for _, addedaddedHandler := range addedHandlers {
-		notificationUser := model.NotificationUser{
-			UserID:  addedaddedHandler.ID,
-			Email:   addedaddedHandler.Email,
-			Message: fmt.Sprintf("You have been assigned a new task: `%s`", task.Description),
+		// Create the next occurrence of a completed recurring task
+		if err = c.continueTaskSeries(r.Context(), []model.Task{task}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-
-		notificationUsers = append(notificationUsers, notificationUser)
-	}
-
-	// Send the notification data
-	err = rabbitmq.GenerateNotificationData(c.notificationProducer, notificationUsers, "email", nil)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
 	}
 
 	// Encode the data and return it
@@ -1502,8 +3211,11 @@ func (c *taskController) DeleteTaskById(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Send the data to the service layer to delete the task
+	var job model.Job
 	task, duration, err := utils.MeasureTime("Delete-Task", func() (model.Task, error) {
-		return c.taskService.DeleteTaskById(r.Context(), inputData.TaskID)
+		deletedTask, deletionJob, err := c.taskService.DeleteTaskById(r.Context(), inputData.UserID, inputData.TaskID)
+		job = deletionJob
+		return deletedTask, err
 	})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -1551,10 +3263,9 @@ func (c *taskController) DeleteTaskById(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// TODO: REMOVE SUBTASKS
-
-	// Encode the data and return it
-	if err = utils.EncodeData(w, r, "OK"); err != nil {
+	// Encode the subcollections deletion job and return it
+	// The job status can be polled until the remaining documents of the task are deleted
+	if err = utils.EncodeData(w, r, job); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -1684,3 +3395,175 @@ func (c *taskController) DeleteResponseById(w http.ResponseWriter, r *http.Reque
 		return
 	}
 }
+
+// bulkNotificationMessage generates the aggregated notification message of a bulk operation
+//
+// Parameters:
+//   - operation: The bulk operation
+//   - descriptions: The descriptions of the tasks that affect the user
+//
+// Returns:
+//   - string: The notification message
+func bulkNotificationMessage(operation model.BulkTaskOperation, descriptions []string) string {
+	tasks := fmt.Sprintf("`%s`", strings.Join(descriptions, "`, `"))
+
+	switch operation.Operation {
+	case model.BulkSetStatus:
+		return fmt.Sprintf("Status updated to `%s` for %d tasks: %s", operation.Status, len(descriptions), tasks)
+	case model.BulkAddHandlers:
+		return fmt.Sprintf("You have been assigned %d tasks: %s", len(descriptions), tasks)
+	case model.BulkRemoveHandlers:
+		return fmt.Sprintf("You have been removed from %d tasks: %s", len(descriptions), tasks)
+	case model.BulkSetDeadline:
+		return fmt.Sprintf("Deadline updated for %d tasks: %s", len(descriptions), tasks)
+	default:
+		return fmt.Sprintf("%d tasks have been deleted: %s", len(descriptions), tasks)
+	}
+}
+
+// notifyUsers is a private method that sends the same email notification to a list of users
+//
+// Parameters:
+//   - userIds: The IDs of the users to notify
+//   - message: The notification message
+//   - data: The data attached to the notification
+//
+// Returns:
+//   - error: An error that occured during the process
+func (c *taskController) notifyUsers(userIds []string, message string, data any) error {
+	if len(userIds) == 0 {
+		return nil
+	}
+
+	usersData, err := c.userProducer.GetUsers(userIds)
+	if err != nil {
+		return err
+	}
+
+	notificationUsers := []model.NotificationUser{}
+	for _, userData := range usersData {
+		notificationUsers = append(notificationUsers, model.NotificationUser{
+			UserID:  userData.ID,
+			Email:   userData.Email,
+			Message: message,
+		})
+	}
+
+	return rabbitmq.GenerateNotificationData(c.notificationProducer, notificationUsers, "email", data)
+}
+
+// assignmentErrorStatus is a private function that writes the http status matching a task assignment error
+//
+// Parameters:
+//   - w: The http response writer
+//   - err: The error returned by the service layer
+func assignmentErrorStatus(w http.ResponseWriter, err error) {
+	if errors.Is(err, utils.ErrInvalidAssignment) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+// workflowErrorStatus is a private function that writes the http status matching an error of a task workflow change
+//
+// Parameters:
+//   - w: The http response writer
+//   - err: The error returned by the service layer
+func workflowErrorStatus(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, utils.ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, utils.ErrBlockedTask),
+		errors.Is(err, utils.ErrInvalidRank),
+		errors.Is(err, utils.ErrWipLimitExceeded),
+		errors.Is(err, utils.ErrInvalidReview),
+		errors.Is(err, utils.ErrReviewRequired):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// notifyUnblockedTasks is a private method that notifies the handlers of the tasks
+// that are no longer blocked after their blockers were completed
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - completedTasks: The list of tasks that were completed
+//
+// Returns:
+//   - error: An error that occured during the process
+func (c *taskController) notifyUnblockedTasks(ctx context.Context, completedTasks []model.Task) error {
+	// Get the tasks that have no open blockers left
+	unblockedTasks, err := c.taskService.GetUnblockedTasks(ctx, completedTasks)
+	if err != nil {
+		return err
+	}
+
+	// Group the unblocked tasks by handler so each handler receives a single notification
+	handlerTasks := map[string][]string{}
+	var handlerIds []string
+	for _, task := range unblockedTasks {
+		for _, handlerId := range task.HandlerIDs {
+			if _, ok := handlerTasks[handlerId]; !ok {
+				handlerIds = append(handlerIds, handlerId)
+			}
+			handlerTasks[handlerId] = append(handlerTasks[handlerId], task.Description)
+		}
+	}
+
+	if len(handlerIds) == 0 {
+		return nil
+	}
+
+	// Get the handlers data
+	usersData, err := c.userProducer.GetUsers(handlerIds)
+	if err != nil {
+		return err
+	}
+
+	// Generate the user notification data
+	notificationUsers := []model.NotificationUser{}
+	for _, userData := range usersData {
+		notificationUser := model.NotificationUser{
+			UserID:  userData.ID,
+			Email:   userData.Email,
+			Message: fmt.Sprintf("The blockers were completed and you can start working on: `%s`", strings.Join(handlerTasks[userData.ID], "`, `")),
+		}
+
+		notificationUsers = append(notificationUsers, notificationUser)
+	}
+
+	// Notify the users
+	return rabbitmq.GenerateNotificationData(c.notificationProducer, notificationUsers, "email", unblockedTasks)
+}
+
+// continueTaskSeries is a private method that creates the next occurrence of the recurring tasks
+// that were completed and publishes the new tasks
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - completedTasks: The list of tasks that were completed
+//
+// Returns:
+//   - error: An error that occured during the process
+func (c *taskController) continueTaskSeries(ctx context.Context, completedTasks []model.Task) error {
+	for _, task := range completedTasks {
+		occurrence, err := c.recurrenceService.ContinueSeries(ctx, task)
+		if err != nil {
+			return err
+		}
+
+		if occurrence == nil {
+			continue
+		}
+
+		if err = publishOccurrence(c.userProducer, c.notificationProducer, c.versionProducer, *occurrence); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,424 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/middleware"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/rabbitmq"
+	"github.com/horatiucrisan/task-service/schemas"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+type templateController struct {
+	templateService      interfaces.TemplateService
+	userProducer         *rabbitmq.UserProducer
+	loggerProducer       *rabbitmq.TaskProducer
+	notificationProducer *rabbitmq.TaskProducer
+	versionProducer      *rabbitmq.TaskProducer
+}
+
+func NewTemplateController(
+	templateService interfaces.TemplateService,
+	userProducer *rabbitmq.UserProducer,
+	loggerProducer,
+	notificationProducer,
+	versionProducer *rabbitmq.TaskProducer,
+) interfaces.TemplateController {
+	return &templateController{
+		templateService:      templateService,
+		userProducer:         userProducer,
+		loggerProducer:       loggerProducer,
+		notificationProducer: notificationProducer,
+		versionProducer:      versionProducer,
+	}
+}
+
+// POST methods
+func (c *templateController) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.CreateTemplateSchema{
+		UserID: user.UID,
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	templateData := model.TaskTemplate{
+		ProjectID:   inputData.ProjectID,
+		Name:        inputData.Name,
+		Description: inputData.Description,
+		Priority:    inputData.Priority,
+		Severity:    inputData.Severity,
+		Labels:      inputData.Labels,
+		Subtasks:    []model.TemplateSubtask{},
+	}
+
+	for _, subtask := range inputData.Subtasks {
+		templateData.Subtasks = append(templateData.Subtasks, model.TemplateSubtask{Description: subtask.Description})
+	}
+
+	// Send the data to the service layer to create the template
+	template, duration, err := utils.MeasureTime("Create-Template", func() (model.TaskTemplate, error) {
+		return c.templateService.CreateTemplate(r.Context(), inputData.UserID, templateData)
+	})
+	if err != nil {
+		templateErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` created the task template `%s` in the project `%s`", inputData.UserID, template.ID, template.ProjectID),
+		"audit",
+		http.StatusCreated,
+		duration,
+		template,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, template); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *templateController) CreateTemplateFromTask(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.CreateTemplateFromTaskSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to save the task as a template
+	template, duration, err := utils.MeasureTime("Create-Template-From-Task", func() (model.TaskTemplate, error) {
+		return c.templateService.CreateTemplateFromTask(r.Context(), inputData.UserID, inputData.TaskID, inputData.Name)
+	})
+	if err != nil {
+		templateErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` saved the task `%s` as the task template `%s`", inputData.UserID, inputData.TaskID, template.ID),
+		"audit",
+		http.StatusCreated,
+		duration,
+		template,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, template); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *templateController) InstantiateTemplate(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.InstantiateTemplateSchema{
+		UserID:     user.UID,
+		TemplateID: chi.URLParam(r, "templateId"),
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to create the task from the template
+	details, duration, err := utils.MeasureTime("Instantiate-Template", func() (model.TaskDetails, error) {
+		return c.templateService.InstantiateTemplate(r.Context(), inputData.UserID, inputData.TemplateID, inputData.Variables, inputData.Deadline, inputData.HandlerIDs, inputData.SubtaskHandlerIDs)
+	})
+	if err != nil {
+		templateErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` created the task `%s` from the task template `%s`", inputData.UserID, details.Task.ID, inputData.TemplateID),
+		"audit",
+		http.StatusCreated,
+		duration,
+		details,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Get the data of the task and subtask handlers
+	handlerIds := slices.Clone(details.Task.HandlerIDs)
+	for _, subtask := range details.Subtasks {
+		if subtask.HandlerID != "" && !slices.Contains(handlerIds, subtask.HandlerID) {
+			handlerIds = append(handlerIds, subtask.HandlerID)
+		}
+	}
+
+	usersData, err := c.userProducer.GetUsers(handlerIds)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the user notification data
+	notificationUsers := []model.NotificationUser{}
+	for _, userData := range usersData {
+		notificationUser := model.NotificationUser{
+			UserID:  userData.ID,
+			Email:   userData.Email,
+			Message: fmt.Sprintf("You have been assigned work on the new `%s` priority task `%s`", details.Task.Priority, details.Task.Description),
+		}
+
+		notificationUsers = append(notificationUsers, notificationUser)
+	}
+
+	if err = rabbitmq.GenerateNotificationData(c.notificationProducer, notificationUsers, "email", details.Task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Generate the version of the new task and of its subtasks
+	if err = rabbitmq.GenerateVersionData(c.versionProducer, details.Task.ID, details.Task); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	for _, subtask := range details.Subtasks {
+		if err = rabbitmq.GenerateVersionData(c.versionProducer, subtask.ID, subtask); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, details); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// GET methods
+func (c *templateController) GetProjectTemplates(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetProjectTemplatesSchema{
+		UserID:    user.UID,
+		ProjectID: r.URL.Query().Get("projectId"),
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to get the project templates
+	templates, duration, err := utils.MeasureTime("Get-Project-Templates", func() ([]model.TaskTemplate, error) {
+		return c.templateService.GetProjectTemplates(r.Context(), inputData.UserID, inputData.ProjectID)
+	})
+	if err != nil {
+		templateErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the task templates of the project `%s`", inputData.UserID, inputData.ProjectID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		templates,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, templates); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *templateController) GetTemplateById(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetTemplateByIdSchema{
+		UserID:     user.UID,
+		TemplateID: chi.URLParam(r, "templateId"),
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to get the template
+	template, duration, err := utils.MeasureTime("Get-Template-By-Id", func() (model.TaskTemplate, error) {
+		return c.templateService.GetTemplateById(r.Context(), inputData.UserID, inputData.TemplateID)
+	})
+	if err != nil {
+		templateErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the task template `%s`", inputData.UserID, inputData.TemplateID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		template,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, template); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// DELETE methods
+func (c *templateController) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.DeleteTemplateSchema{
+		UserID:     user.UID,
+		TemplateID: chi.URLParam(r, "templateId"),
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to delete the template
+	template, duration, err := utils.MeasureTime("Delete-Template", func() (model.TaskTemplate, error) {
+		return c.templateService.DeleteTemplate(r.Context(), inputData.UserID, inputData.TemplateID)
+	})
+	if err != nil {
+		templateErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` deleted the task template `%s` of the project `%s`", inputData.UserID, template.ID, template.ProjectID),
+		"audit",
+		http.StatusAccepted,
+		duration,
+		template,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, template); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// templateErrorStatus is a private function that writes the http status matching a template error
+//
+// Parameters:
+//   - w: The http response writer
+//   - err: The error returned by the service layer
+func templateErrorStatus(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, utils.ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, utils.ErrInvalidLabel), errors.Is(err, utils.ErrMissingTemplateVariables):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, utils.ErrHandlersNotMembers), errors.Is(err, utils.ErrWipLimitExceeded):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
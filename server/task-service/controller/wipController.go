@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/middleware"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/rabbitmq"
+	"github.com/horatiucrisan/task-service/schemas"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+type wipController struct {
+	wipService     interfaces.WipService
+	loggerProducer *rabbitmq.TaskProducer
+}
+
+func NewWipController(wipService interfaces.WipService, loggerProducer *rabbitmq.TaskProducer) interfaces.WipController {
+	return &wipController{wipService: wipService, loggerProducer: loggerProducer}
+}
+
+// GET methods
+func (c *wipController) GetWipLimits(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetWipLimitsSchema{
+		UserID:    user.UID,
+		ProjectID: r.URL.Query().Get("projectId"),
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to get the project limits
+	limits, duration, err := utils.MeasureTime("Get-Wip-Limits", func() (model.WipLimits, error) {
+		return c.wipService.GetWipLimits(r.Context(), inputData.UserID, inputData.ProjectID)
+	})
+	if err != nil {
+		wipErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the work in progress limits of the project `%s`", inputData.UserID, inputData.ProjectID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		limits,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, limits); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// PUT methods
+func (c *wipController) SetWipLimits(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.SetWipLimitsSchema{
+		UserID: user.UID,
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to replace the project limits
+	limits, duration, err := utils.MeasureTime("Set-Wip-Limits", func() (model.WipLimits, error) {
+		return c.wipService.SetWipLimits(r.Context(), inputData.UserID, inputData.ProjectID, inputData.StatusLimits, inputData.HandlerLimits)
+	})
+	if err != nil {
+		wipErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` set the work in progress limits of the project `%s`", inputData.UserID, inputData.ProjectID),
+		"audit",
+		http.StatusOK,
+		duration,
+		limits,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, limits); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// wipErrorStatus is a private function that writes the http status matching a work in progress limit error
+//
+// Parameters:
+//   - w: The http response writer
+//   - err: The error returned by the service layer
+func wipErrorStatus(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, utils.ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, utils.ErrWipLimitExceeded):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// wipOverrideMessage is a private function that generates the audit log suffix of a task change that overrode
+// some work in progress limits
+//
+// Parameters:
+//   - exceeded: The work in progress limits that were overridden
+//
+// Returns:
+//   - string: The log message suffix, empty when no limit was overridden
+func wipOverrideMessage(exceeded []string) string {
+	if len(exceeded) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(", overriding the work in progress limits: %s", strings.Join(exceeded, ", "))
+}
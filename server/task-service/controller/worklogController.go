@@ -0,0 +1,475 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/middleware"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/rabbitmq"
+	"github.com/horatiucrisan/task-service/schemas"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+type worklogController struct {
+	worklogService interfaces.WorkLogService
+	loggerProducer *rabbitmq.TaskProducer
+}
+
+func NewWorkLogController(worklogService interfaces.WorkLogService, loggerProducer *rabbitmq.TaskProducer) interfaces.WorkLogController {
+	return &worklogController{worklogService: worklogService, loggerProducer: loggerProducer}
+}
+
+// POST methods
+func (c *worklogController) CreateWorkLog(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.CreateWorkLogSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	entry := model.WorkLog{
+		SubtaskID: inputData.SubtaskID,
+		StartedAt: inputData.StartedAt,
+		EndedAt:   inputData.EndedAt,
+		Duration:  inputData.Duration,
+		Note:      inputData.Note,
+	}
+
+	// Send the data to the service layer to log the time
+	worklog, duration, err := utils.MeasureTime("Create-Work-Log", func() (model.WorkLog, error) {
+		return c.worklogService.CreateWorkLog(r.Context(), inputData.UserID, inputData.TaskID, entry)
+	})
+	if err != nil {
+		worklogErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` logged %s on the task `%s`", inputData.UserID, time.Duration(worklog.Duration)*time.Millisecond, inputData.TaskID),
+		"audit",
+		http.StatusCreated,
+		duration,
+		worklog,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, worklog); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *worklogController) StartTimer(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.StartTimerSchema{
+		UserID: user.UID,
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to start the timer
+	timer, duration, err := utils.MeasureTime("Start-Timer", func() (model.Timer, error) {
+		return c.worklogService.StartTimer(r.Context(), inputData.UserID, inputData.TaskID, inputData.SubtaskID, inputData.Note)
+	})
+	if err != nil {
+		worklogErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` started a timer on the task `%s`", inputData.UserID, inputData.TaskID),
+		"info",
+		http.StatusCreated,
+		duration,
+		timer,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, timer); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *worklogController) StopTimer(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.StopTimerSchema{
+		UserID: user.UID,
+	}
+
+	// Validate the input data and the request body
+	if err = utils.ValidateBody(r, &inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to stop the timer
+	worklog, duration, err := utils.MeasureTime("Stop-Timer", func() (model.WorkLog, error) {
+		return c.worklogService.StopTimer(r.Context(), inputData.UserID, inputData.Note)
+	})
+	if err != nil {
+		worklogErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` stopped the timer and logged %s on the task `%s`", inputData.UserID, time.Duration(worklog.Duration)*time.Millisecond, worklog.TaskID),
+		"audit",
+		http.StatusCreated,
+		duration,
+		worklog,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, worklog); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// GET methods
+func (c *worklogController) GetTaskWorkLogs(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetTaskWorkLogsSchema{
+		UserID: user.UID,
+		TaskID: chi.URLParam(r, "taskId"),
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to get the task work logs
+	worklogs, duration, err := utils.MeasureTime("Get-Task-Work-Logs", func() ([]model.WorkLog, error) {
+		return c.worklogService.GetTaskWorkLogs(r.Context(), inputData.UserID, inputData.TaskID)
+	})
+	if err != nil {
+		worklogErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the work logs of the task `%s`", inputData.UserID, inputData.TaskID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		worklogs,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, worklogs); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *worklogController) GetTimer(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetTimerSchema{
+		UserID: user.UID,
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to get the running timer
+	timer, duration, err := utils.MeasureTime("Get-Timer", func() (model.Timer, error) {
+		return c.worklogService.GetTimer(r.Context(), inputData.UserID)
+	})
+	if err != nil {
+		worklogErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the running timer", inputData.UserID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		timer,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, timer); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (c *worklogController) GetTimeReport(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Get the date range of the report from the request query
+	from, err := utils.ParseInt64Param(r, "from")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	to, err := utils.ParseInt64Param(r, "to")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if from == nil || to == nil {
+		http.Error(w, "the `from` and `to` query parameters are required", http.StatusBadRequest)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.GetTimeReportSchema{
+		UserID:       user.UID,
+		ProjectID:    r.URL.Query().Get("projectId"),
+		ReportUserID: r.URL.Query().Get("userId"),
+		From:         *from,
+		To:           *to,
+		Format:       r.URL.Query().Get("format"),
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	worklogQuery := model.WorkLogQuery{
+		ProjectID: inputData.ProjectID,
+		UserID:    inputData.ReportUserID,
+		From:      inputData.From,
+		To:        inputData.To,
+	}
+
+	// Send the data to the service layer to get the time report
+	report, duration, err := utils.MeasureTime("Get-Time-Report", func() (model.TimeReport, error) {
+		return c.worklogService.GetTimeReport(r.Context(), inputData.UserID, worklogQuery)
+	})
+	if err != nil {
+		worklogErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` retrieved the time report of the project `%s` and the user `%s`", inputData.UserID, report.Query.ProjectID, report.Query.UserID),
+		"info",
+		http.StatusAccepted,
+		duration,
+		report,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Export the report as a CSV file when requested
+	if inputData.Format == "csv" {
+		if err = utils.EncodeCSV(w, r, "time-report.csv", timeReportRows(report)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, report); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// DELETE methods
+func (c *worklogController) DeleteWorkLog(w http.ResponseWriter, r *http.Request) {
+	// Get the user data from the user token
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Generate the request schema
+	inputData := schemas.DeleteWorkLogSchema{
+		UserID:    user.UID,
+		TaskID:    chi.URLParam(r, "taskId"),
+		WorkLogID: chi.URLParam(r, "worklogId"),
+	}
+
+	// Validate the input data
+	if err = utils.ValidateParams(inputData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the data to the service layer to delete the work log
+	worklog, duration, err := utils.MeasureTime("Delete-Work-Log", func() (model.WorkLog, error) {
+		return c.worklogService.DeleteWorkLog(r.Context(), inputData.UserID, inputData.TaskID, inputData.WorkLogID)
+	})
+	if err != nil {
+		worklogErrorStatus(w, err)
+		return
+	}
+
+	// Generate the log data
+	if err = rabbitmq.GenerateLogData(
+		w,
+		r,
+		c.loggerProducer,
+		fmt.Sprintf("User `%s` deleted the work log `%s` of the task `%s`", inputData.UserID, inputData.WorkLogID, inputData.TaskID),
+		"audit",
+		http.StatusOK,
+		duration,
+		worklog,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the data and return it
+	if err = utils.EncodeData(w, r, worklog); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// timeReportRows is a private function that converts the work logs of a time report into CSV rows
+//
+// Parameters:
+//   - report: The time report
+//
+// Returns:
+//   - [][]string: The header row followed by a row for every work log
+func timeReportRows(report model.TimeReport) [][]string {
+	rows := [][]string{{"userId", "projectId", "taskId", "subtaskId", "startedAt", "endedAt", "hours", "note"}}
+
+	for _, worklog := range report.WorkLogs {
+		rows = append(rows, []string{
+			worklog.UserID,
+			worklog.ProjectID,
+			worklog.TaskID,
+			worklog.SubtaskID,
+			time.UnixMilli(worklog.StartedAt).UTC().Format(time.RFC3339),
+			time.UnixMilli(worklog.EndedAt).UTC().Format(time.RFC3339),
+			strconv.FormatFloat(time.Duration(worklog.Duration*int64(time.Millisecond)).Hours(), 'f', 2, 64),
+			worklog.Note,
+		})
+	}
+
+	return rows
+}
+
+// worklogErrorStatus is a private function that writes the http status matching a work log error
+//
+// Parameters:
+//   - w: The http response writer
+//   - err: The error returned by the service layer
+func worklogErrorStatus(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, utils.ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, utils.ErrInvalidWorkLog):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, utils.ErrNoTimer):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, utils.ErrTimerRunning):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,10 @@
+package interfaces
+
+import "net/http"
+
+type AnalyticsController interface {
+	GetProjectAnalytics(w http.ResponseWriter, r *http.Request)
+	GetCumulativeFlow(w http.ResponseWriter, r *http.Request)
+	GetCycleTimeMetrics(w http.ResponseWriter, r *http.Request)
+	GetTaskTimeInStatus(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/horatiucrisan/task-service/model"
+)
+
+type AnalyticsRepository interface {
+	GetProjectTransitions(ctx context.Context, projectId string, to int64) ([]model.StatusTransition, error)
+	GetTaskTransitions(ctx context.Context, taskId string) ([]model.StatusTransition, error)
+}
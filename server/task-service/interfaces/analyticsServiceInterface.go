@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/horatiucrisan/task-service/model"
+)
+
+type AnalyticsService interface {
+	GetProjectAnalytics(ctx context.Context, userId, projectId string, from, to int64) (model.ProjectAnalytics, error)
+	GetCumulativeFlow(ctx context.Context, userId, projectId string, from, to int64) (model.CumulativeFlow, error)
+	GetCycleTimeMetrics(ctx context.Context, userId, projectId string, from, to int64) (model.CycleTimeMetrics, error)
+	GetTaskTimeInStatus(ctx context.Context, userId, taskId string) (model.TimeInStatus, error)
+}
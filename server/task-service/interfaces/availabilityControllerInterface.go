@@ -0,0 +1,13 @@
+package interfaces
+
+import "net/http"
+
+type AvailabilityController interface {
+	CreateAbsence(w http.ResponseWriter, r *http.Request)
+
+	GetAbsences(w http.ResponseWriter, r *http.Request)
+
+	GetTasksAtRisk(w http.ResponseWriter, r *http.Request)
+
+	DeleteAbsence(w http.ResponseWriter, r *http.Request)
+}
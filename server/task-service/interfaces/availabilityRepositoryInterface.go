@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/horatiucrisan/task-service/model"
+)
+
+type AvailabilityRepository interface {
+	CreateAbsence(ctx context.Context, absence model.Absence) (model.Absence, error)
+
+	GetAbsences(ctx context.Context, projectId string, from, to int64) ([]model.Absence, error)
+
+	GetAbsenceById(ctx context.Context, projectId, absenceId string) (model.Absence, error)
+
+	DeleteAbsence(ctx context.Context, projectId, absenceId string) error
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/horatiucrisan/task-service/model"
+)
+
+type AvailabilityService interface {
+	CreateAbsence(ctx context.Context, userId, projectId, memberId string, startAt, endAt int64, reason string) (model.Absence, error)
+
+	GetAbsences(ctx context.Context, userId, projectId string, from, to int64) ([]model.Absence, error)
+
+	GetTasksAtRisk(ctx context.Context, userId, projectId string, days int) ([]model.TaskAtRisk, error)
+
+	DeleteAbsence(ctx context.Context, userId, projectId, absenceId string) (model.Absence, error)
+}
@@ -0,0 +1,8 @@
+package interfaces
+
+import "context"
+
+type DeadlineController interface {
+	// SendDeadlineNotifications is run by the scheduler
+	SendDeadlineNotifications(ctx context.Context) error
+}
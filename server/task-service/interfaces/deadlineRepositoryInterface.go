@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/horatiucrisan/task-service/model"
+)
+
+type DeadlineRepository interface {
+	GetOpenTasksByDeadline(ctx context.Context, from, to int64) ([]model.Task, error)
+	GetOverdueTasks(ctx context.Context, to int64) ([]model.Task, error)
+	BackfillEscalatedAt(ctx context.Context) (int, error)
+
+	ClaimTaskReminder(ctx context.Context, taskId string, deadline int64, key string) (model.Task, bool, error)
+	MarkTaskOverdue(ctx context.Context, taskId string, deadline int64) (model.Task, bool, error)
+	EscalateOverdueTask(ctx context.Context, taskId string, deadline int64) (model.Task, bool, error)
+
+	ReleaseTaskReminder(ctx context.Context, taskId, key string) error
+	ReleaseTaskOverdue(ctx context.Context, taskId string) error
+	ReleaseTaskEscalation(ctx context.Context, taskId string) error
+}
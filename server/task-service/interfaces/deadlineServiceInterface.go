@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/horatiucrisan/task-service/model"
+)
+
+type DeadlineService interface {
+	CheckDeadlines(ctx context.Context) ([]model.DeadlineNotice, error)
+	ReleaseDeadlineNotice(ctx context.Context, notice model.DeadlineNotice) error
+}
@@ -0,0 +1,9 @@
+package interfaces
+
+import "net/http"
+
+type JobController interface {
+	GetJobById(w http.ResponseWriter, r *http.Request)
+
+	CancelJob(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/horatiucrisan/task-service/model"
+)
+
+type JobRepository interface {
+	CreateJob(ctx context.Context, job model.Job) (model.Job, error)
+
+	GetJobById(ctx context.Context, jobId string) (model.Job, error)
+	ClaimJobs(ctx context.Context, workerId string, lease time.Duration, limit int) ([]model.Job, error)
+
+	UpdateJobProgress(ctx context.Context, jobId, workerId string, processed, total int64) (model.Job, error)
+	CompleteJob(ctx context.Context, jobId, workerId string) (model.Job, error)
+	RetryJob(ctx context.Context, jobId, workerId string, errMessage string, runAt int64) (model.Job, error)
+	FailJob(ctx context.Context, jobId, workerId string, errMessage string) (model.Job, error)
+	CancelJob(ctx context.Context, jobId string) (model.Job, error)
+	MarkJobCancelled(ctx context.Context, jobId, workerId string) (model.Job, error)
+}
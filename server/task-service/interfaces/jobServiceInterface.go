@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/horatiucrisan/task-service/model"
+)
+
+// JobProgress reports the progress of a running job.
+// It returns an error when the job was cancelled and the handler must stop
+type JobProgress func(processed, total int64) error
+
+// JobHandler executes the work of a single job type
+type JobHandler func(ctx context.Context, job model.Job, progress JobProgress) error
+
+type JobService interface {
+	EnqueueJob(ctx context.Context, jobType string, createdBy string, payload map[string]any, total int64) (model.Job, error)
+
+	GetJobById(ctx context.Context, jobId string) (model.Job, error)
+
+	CancelJob(ctx context.Context, jobId string) (model.Job, error)
+}
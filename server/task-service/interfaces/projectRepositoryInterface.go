@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/horatiucrisan/task-service/model"
+)
+
+type ProjectRepository interface {
+	GetProjectById(ctx context.Context, projectId string) (model.Project, error)
+	GetProjectsByIds(ctx context.Context, projectIds []string) ([]model.Project, error)
+	GetUserProjects(ctx context.Context, userId string) ([]model.Project, error)
+	GetProjectLabels(ctx context.Context, projectId string) ([]model.Label, error)
+}
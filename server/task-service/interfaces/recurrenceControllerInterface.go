@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+	"net/http"
+)
+
+type RecurrenceController interface {
+	CreateSeries(w http.ResponseWriter, r *http.Request)
+
+	GetProjectSeries(w http.ResponseWriter, r *http.Request)
+	GetSeriesById(w http.ResponseWriter, r *http.Request)
+
+	UpdateSeries(w http.ResponseWriter, r *http.Request)
+	StopSeries(w http.ResponseWriter, r *http.Request)
+
+	// CreateDueOccurrences is run by the scheduler
+	CreateDueOccurrences(ctx context.Context) error
+}
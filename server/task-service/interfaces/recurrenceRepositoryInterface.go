@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/horatiucrisan/task-service/model"
+)
+
+type RecurrenceRepository interface {
+	CreateSeries(ctx context.Context, series model.TaskSeries) (model.SeriesOccurrence, error)
+
+	GetSeriesById(ctx context.Context, seriesId string) (model.TaskSeries, error)
+	GetProjectSeries(ctx context.Context, projectId string) ([]model.TaskSeries, error)
+	GetDueSeries(ctx context.Context, now int64, limit int) ([]model.TaskSeries, error)
+
+	UpdateSeries(ctx context.Context, seriesId string, update func(series *model.TaskSeries) error) (model.TaskSeries, error)
+	AdvanceSeries(ctx context.Context, seriesId string, advance func(series *model.TaskSeries) (*model.TaskDetails, error)) (*model.SeriesOccurrence, error)
+}
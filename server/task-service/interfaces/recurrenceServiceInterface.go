@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/horatiucrisan/task-service/model"
+)
+
+type RecurrenceService interface {
+	CreateSeries(ctx context.Context, userId, taskId string, rule model.RecurrenceRule) (model.SeriesOccurrence, error)
+	CreateDueOccurrences(ctx context.Context) ([]model.SeriesOccurrence, error)
+
+	GetProjectSeries(ctx context.Context, userId, projectId string) ([]model.TaskSeries, error)
+	GetSeriesById(ctx context.Context, userId, seriesId string) (model.TaskSeries, error)
+
+	UpdateSeries(ctx context.Context, userId, seriesId string, changes model.SeriesChanges) (model.TaskSeries, error)
+	StopSeries(ctx context.Context, userId, seriesId string) (model.TaskSeries, error)
+	ContinueSeries(ctx context.Context, task model.Task) (*model.SeriesOccurrence, error)
+}
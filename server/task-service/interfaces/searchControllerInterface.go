@@ -0,0 +1,7 @@
+package interfaces
+
+import "net/http"
+
+type SearchController interface {
+	Search(w http.ResponseWriter, r *http.Request)
+}
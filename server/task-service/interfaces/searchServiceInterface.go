@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/horatiucrisan/task-service/model"
+)
+
+type SearchService interface {
+	Search(ctx context.Context, userId string, query string, projectId string, limit int) ([]model.SearchHit, error)
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import "net/http"
+
+type SprintController interface {
+	CreateSprint(w http.ResponseWriter, r *http.Request)
+
+	GetProjectSprints(w http.ResponseWriter, r *http.Request)
+	GetSprintById(w http.ResponseWriter, r *http.Request)
+	GetSprintBurndown(w http.ResponseWriter, r *http.Request)
+	GetProjectVelocity(w http.ResponseWriter, r *http.Request)
+
+	UpdateSprint(w http.ResponseWriter, r *http.Request)
+	AssignTaskSprint(w http.ResponseWriter, r *http.Request)
+	UpdateTaskEstimate(w http.ResponseWriter, r *http.Request)
+
+	DeleteSprint(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/horatiucrisan/task-service/model"
+)
+
+type SprintRepository interface {
+	CreateSprint(ctx context.Context, sprint model.Sprint) (model.Sprint, error)
+
+	GetSprintById(ctx context.Context, sprintId string) (model.Sprint, error)
+	GetProjectSprints(ctx context.Context, projectId string) ([]model.Sprint, error)
+	GetFinishedSprints(ctx context.Context, projectId string, before int64, limit int) ([]model.Sprint, error)
+	GetSprintTasks(ctx context.Context, sprintId string) ([]model.Task, error)
+	GetStatusTransitions(ctx context.Context, taskIds []string) ([]model.StatusTransition, error)
+
+	UpdateSprint(ctx context.Context, sprintId string, update func(sprint *model.Sprint) error) (model.Sprint, error)
+	AssignTaskSprint(ctx context.Context, taskId, sprintId string) (model.Task, error)
+	UpdateTaskEstimate(ctx context.Context, taskId string, estimate model.TaskEstimate) (model.Task, error)
+
+	DeleteSprint(ctx context.Context, sprintId string) ([]model.Task, error)
+}
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/horatiucrisan/task-service/model"
+)
+
+type SprintService interface {
+	CreateSprint(ctx context.Context, userId, projectId, name, goal string, startAt, endAt int64) (model.Sprint, error)
+
+	GetProjectSprints(ctx context.Context, userId, projectId string) ([]model.Sprint, error)
+	GetSprintById(ctx context.Context, userId, sprintId string) (model.Sprint, error)
+	GetSprintBurndown(ctx context.Context, userId, sprintId string) (model.Burndown, error)
+	GetProjectVelocity(ctx context.Context, userId, projectId string, limit int) (model.Velocity, error)
+
+	UpdateSprint(ctx context.Context, userId, sprintId string, changes model.SprintChanges) (model.Sprint, error)
+	AssignTaskSprint(ctx context.Context, userId, taskId, sprintId string) (model.Task, error)
+	UpdateTaskEstimate(ctx context.Context, userId, taskId string, estimate model.TaskEstimate) (model.Task, error)
+
+	DeleteSprint(ctx context.Context, userId, sprintId string) ([]model.Task, error)
+}
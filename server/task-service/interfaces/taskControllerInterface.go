@@ -8,18 +8,40 @@ type TaskController interface {
 	CreateTask(w http.ResponseWriter, r *http.Request)
 	CreateSubtask(w http.ResponseWriter, r *http.Request)
 	CreateTaskResponse(w http.ResponseWriter, r *http.Request)
+	DuplicateTask(w http.ResponseWriter, r *http.Request)
+	PromoteSubtask(w http.ResponseWriter, r *http.Request)
+	BulkUpdateTasks(w http.ResponseWriter, r *http.Request)
 
 	GetTasks(w http.ResponseWriter, r *http.Request)
 	GetSubtasks(w http.ResponseWriter, r *http.Request)
 	GetResponses(w http.ResponseWriter, r *http.Request)
+	GetUserWork(w http.ResponseWriter, r *http.Request)
+	GetDependencyGraph(w http.ResponseWriter, r *http.Request)
+	GetHandlerSuggestions(w http.ResponseWriter, r *http.Request)
+	GetTaskTree(w http.ResponseWriter, r *http.Request)
 	GetTaskById(w http.ResponseWriter, r *http.Request)
 	GetSubtaskById(w http.ResponseWriter, r *http.Request)
 	GetResponseById(w http.ResponseWriter, r *http.Request)
 
 	UpdateTaskDescription(w http.ResponseWriter, r *http.Request)
 	AddTaskHandlers(w http.ResponseWriter, r *http.Request)
+	AcceptTaskAssignment(w http.ResponseWriter, r *http.Request)
+	DeclineTaskAssignment(w http.ResponseWriter, r *http.Request)
 	RemoveTaskHandlers(w http.ResponseWriter, r *http.Request)
+	AddTaskLabels(w http.ResponseWriter, r *http.Request)
+	RemoveTaskLabels(w http.ResponseWriter, r *http.Request)
+	AddTaskDependency(w http.ResponseWriter, r *http.Request)
+	RemoveTaskDependency(w http.ResponseWriter, r *http.Request)
+	AddTaskChildren(w http.ResponseWriter, r *http.Request)
+	RemoveTaskChildren(w http.ResponseWriter, r *http.Request)
+	MoveTask(w http.ResponseWriter, r *http.Request)
 	UpdateTaskStatus(w http.ResponseWriter, r *http.Request)
+	UpdateTaskRank(w http.ResponseWriter, r *http.Request)
+	SetTaskReviewers(w http.ResponseWriter, r *http.Request)
+	RequestTaskReview(w http.ResponseWriter, r *http.Request)
+	ApproveTaskReview(w http.ResponseWriter, r *http.Request)
+	RejectTaskReview(w http.ResponseWriter, r *http.Request)
+	UpdateTaskPriority(w http.ResponseWriter, r *http.Request)
 	UpdateSubtaskDescription(w http.ResponseWriter, r *http.Request)
 	UpdateSubtaskStatus(w http.ResponseWriter, r *http.Request)
 	UpdateSubtaskHandler(w http.ResponseWriter, r *http.Request)
@@ -10,18 +10,38 @@ type TaskRepository interface {
 	CreateTask(ctx context.Context, task model.Task) (model.Task, error)
 	CreateSubtask(ctx context.Context, taskId string, subtask model.Subtask) (model.Subtask, error)
 	CreateTaskResponse(ctx context.Context, taskId string, response model.Response) (model.Response, error)
+	CreateTaskDetails(ctx context.Context, details model.TaskDetails) (model.TaskDetails, error)
 
-	GetTasks(ctx context.Context, projectId string, limit int, orderBy string, orderDirection string, startAfter string) ([]model.Task, error)
+	GetTasks(ctx context.Context, taskQuery model.TaskQuery) ([]model.Task, string, error)
 	GetTaskById(ctx context.Context, taskId string) (model.Task, error)
-	GetSubtasks(ctx context.Context, taskId string) ([]model.Subtask, error)
+	GetSubtasks(ctx context.Context, taskId string, limit int, cursor string) ([]model.Subtask, string, error)
 	GetSubtaskById(ctx context.Context, taskId, subtaskId string) (model.Subtask, error)
-	GetResponses(ctx context.Context, taskId string) ([]model.Response, error)
+	GetResponses(ctx context.Context, taskId string, limit int, cursor string) ([]model.Response, string, error)
 	GetResponseById(ctx context.Context, taskId, responseId string) (model.Response, error)
+	GetHandlerTasks(ctx context.Context, taskQuery model.TaskQuery) ([]model.Task, error)
+	GetHandlerSubtasks(ctx context.Context, taskQuery model.TaskQuery) ([]model.AssignedSubtask, error)
+	GetProjectTasks(ctx context.Context, projectId string) ([]model.Task, error)
+	GetTasksByIds(ctx context.Context, taskIds []string) ([]model.Task, error)
+	GetTaskChildren(ctx context.Context, parentIds []string) ([]model.Task, error)
 
 	UpdateTaskDescription(ctx context.Context, taskId string, description string) (model.Task, error)
-	UpdateTaskStatus(ctx context.Context, taskId string, taskStatus string) (model.Task, error)
-	AddTaskHandlers(ctx context.Context, taskId string, handlerIds []string) (model.Task, error)
+	UpdateTaskStatus(ctx context.Context, taskId string, taskStatus string, override bool) (model.Task, []string, error)
+	UpdateTaskRank(ctx context.Context, taskId, taskStatus, afterId, beforeId string, override bool) (model.Task, []string, error)
+	SetTaskReviewers(ctx context.Context, taskId string, reviewerIds []string) (model.Task, error)
+	RequestTaskReview(ctx context.Context, taskId string, override bool) (model.Task, []string, error)
+	ReviewTask(ctx context.Context, taskId, reviewerId string, approved bool, response *model.Response) (model.Task, error)
+	UpdateTaskPriority(ctx context.Context, taskId string, priority string, priorityRank int, severity string) (model.Task, error)
+	AddTaskHandlers(ctx context.Context, taskId, assignerId string, handlerIds []string, override bool) (model.Task, []string, error)
+	AnswerTaskAssignment(ctx context.Context, taskId, handlerId string, accepted bool, reason string) (model.Task, error)
 	RemoveTaskHandlers(ctx context.Context, taskId string, handlerIds []string) (model.Task, error)
+	AddTaskLabels(ctx context.Context, taskId string, labels []string) (model.Task, error)
+	RemoveTaskLabels(ctx context.Context, taskId string, labels []string) (model.Task, error)
+	AddTaskDependency(ctx context.Context, taskId, blockerId string) (model.Task, error)
+	RemoveTaskDependency(ctx context.Context, taskId, blockerId string) (model.Task, error)
+	AddTaskChildren(ctx context.Context, parentId string, childIds []string) ([]model.Task, error)
+	RemoveTaskChildren(ctx context.Context, parentId string, childIds []string) ([]model.Task, error)
+	MoveTask(ctx context.Context, taskId, fromProjectId, toProjectId string, handlerIds, reviewerIds, labels []string) (model.Task, error)
+	PromoteSubtask(ctx context.Context, taskId, subtaskId string, task model.Task, keepSubtask bool) (model.SubtaskPromotion, error)
 	UpdateSubtaskDescription(ctx context.Context, taskId string, subtaskId string, description string) (model.Subtask, error)
 	UpdateSubtaskHandler(ctx context.Context, taskId string, subtaskId string, handlerId string) (model.Subtask, error)
 	UpdateSubtaskStatus(ctx context.Context, taskId string, subtaskId string, subtaskStatus bool) (model.Subtask, error)
@@ -30,7 +50,12 @@ type TaskRepository interface {
 	RerollSubtaskVersion(ctx context.Context, taskId, subtaskId string, subtask model.Subtask) (model.Subtask, error)
 
 	DeleteTaskById(ctx context.Context, taskId string) (model.Task, error)
+	ClearTaskDependencies(ctx context.Context, task model.Task) error
+	ClearTaskChildren(ctx context.Context, parentId string) error
 	DeleteSubtaskById(ctx context.Context, taskId string, subtaskId string) (model.Subtask, error)
 	DeleteResponseById(ctx context.Context, taskId string, responseId string) (model.Response, error)
-	DeleteTaskSubcollections(ctx context.Context, taskId string, batchSize int) (string, error)
+	DeleteTaskSubcollectionBatch(ctx context.Context, taskId string, batchSize int) (int, error)
+
+	BulkUpdateTasks(ctx context.Context, assignerId string, taskIds []string, operation model.BulkTaskOperation) ([]model.Task, []model.BulkItemResult, error)
+	BulkDeleteTasks(ctx context.Context, taskIds []string) ([]model.Task, []model.BulkItemResult, error)
 }
@@ -7,29 +7,55 @@ import (
 )
 
 type TaskService interface {
-	CreateTask(ctx context.Context, authorId string, projectId string, handlerIds []string, description string, deadline int64) (model.Task, error)
+	CreateTask(ctx context.Context, authorId string, projectId string, handlerIds []string, description string, deadline int64, priority string, severity string, autoAssign string) (model.Task, error)
 	CreateSubtask(ctx context.Context, authorId string, taskId string, handlerId string, description string) (model.Subtask, error)
 	CreateTaskResponse(ctx context.Context, authorId string, taskId string, message string) (model.Response, error)
+	DuplicateTask(ctx context.Context, userId, taskId string, includeResponses bool, deadline int64) (model.TaskDetails, error)
 
-	GetTasks(ctx context.Context, projectId string, limit int, orderBy string, orderDirection string, startAfter string) ([]model.Task, error)
+	GetTasks(ctx context.Context, taskQuery model.TaskQuery) ([]model.Task, string, error)
 	GetTaskById(ctx context.Context, taskId string) (model.Task, error)
-	GetSubtasks(ctx context.Context, taskId string) ([]model.Subtask, error)
+	GetSubtasks(ctx context.Context, taskId string, limit int, cursor string) ([]model.Subtask, string, error)
 	GetSubtaskById(ctx context.Context, taskId, subtaskId string) (model.Subtask, error)
-	GetResponses(ctx context.Context, taskId string) ([]model.Response, error)
+	GetResponses(ctx context.Context, taskId string, limit int, cursor string) ([]model.Response, string, error)
 	GetResponseById(ctx context.Context, taskId, responseId string) (model.Response, error)
+	GetUserWork(ctx context.Context, taskQuery model.TaskQuery) ([]model.ProjectWork, error)
+	GetDependencyGraph(ctx context.Context, projectId string) (model.DependencyGraph, error)
+	GetHandlerSuggestions(ctx context.Context, userId, projectId string, deadline int64) (model.HandlerSuggestions, error)
+	GetUnblockedTasks(ctx context.Context, completedTasks []model.Task) ([]model.Task, error)
+	GetTaskTree(ctx context.Context, taskId string, depth int) (*model.TaskTree, error)
 
 	UpdateTaskDescription(ctx context.Context, taskId string, description string) (model.Task, error)
-	UpdateTaskStatus(ctx context.Context, taskId string, status string) (model.Task, error)
+	UpdateTaskStatus(ctx context.Context, userId, taskId string, status string, override bool) (model.Task, []string, error)
+	UpdateTaskRank(ctx context.Context, userId, taskId, status, afterId, beforeId string, override bool) (model.Task, []string, error)
+	SetTaskReviewers(ctx context.Context, userId, taskId string, reviewerIds []string) (model.Task, error)
+	RequestTaskReview(ctx context.Context, userId, taskId string, override bool) (model.Task, []string, error)
+	ApproveTaskReview(ctx context.Context, userId, taskId, comment string) (model.TaskReview, error)
+	RejectTaskReview(ctx context.Context, userId, taskId, comment string) (model.TaskReview, error)
+	UpdateTaskPriority(ctx context.Context, taskId string, priority string, severity string) (model.Task, error)
 	UpdateSubtaskDescription(ctx context.Context, taskId string, subtaskId string, description string) (model.Subtask, error)
 	UpdateSubtaskHandler(ctx context.Context, taskId string, subtaskId string, handlerId string) (model.Subtask, error)
 	UpdateSubtaskStatus(ctx context.Context, taskId string, subtaskId string, status bool) (model.Subtask, error)
-	AddTaskHandlers(ctx context.Context, taskId string, handlers []string) (model.Task, error)
+	AddTaskHandlers(ctx context.Context, userId, taskId string, handlers []string, override bool) (model.Task, []string, error)
+	AcceptTaskAssignment(ctx context.Context, userId, taskId string) (model.Task, error)
+	DeclineTaskAssignment(ctx context.Context, userId, taskId, reason string) (model.Task, error)
 	RemoveTaskHandlers(ctx context.Context, taskId string, handlers []string) (model.Task, error)
+	AddTaskLabels(ctx context.Context, taskId string, labels []string) (model.Task, error)
+	RemoveTaskLabels(ctx context.Context, taskId string, labels []string) (model.Task, error)
+	AddTaskDependency(ctx context.Context, taskId, blockerId string) (model.Task, error)
+	RemoveTaskDependency(ctx context.Context, taskId, blockerId string) (model.Task, error)
+	AddTaskChildren(ctx context.Context, taskId string, childIds []string) ([]model.Task, error)
+	RemoveTaskChildren(ctx context.Context, taskId string, childIds []string) ([]model.Task, error)
+	MoveTask(ctx context.Context, userId, taskId, projectId, nonMembers string) (model.TaskMove, error)
+	PromoteSubtask(ctx context.Context, taskId, subtaskId string, deadline int64, priority string, keepSubtask bool) (model.SubtaskPromotion, error)
 	UpdateResponseMessage(ctx context.Context, taskId string, responseId string, message string) (model.Response, error)
 	RerollTaskVersion(ctx context.Context, taskId string, task model.Task) (model.Task, error)
 	RerollSubtaskVersion(ctx context.Context, taskId, subtaskId string, subtask model.Subtask) (model.Subtask, error)
 
-	DeleteTaskById(ctx context.Context, taskId string) (model.Task, error)
+	DeleteTaskById(ctx context.Context, userId string, taskId string) (model.Task, model.Job, error)
 	DeleteSubtaskById(ctx context.Context, taskId string, subtaskId string) (model.Subtask, error)
 	DeleteResponseById(ctx context.Context, taskId string, responseId string) (model.Response, error)
+
+	DeleteTaskSubcollections(ctx context.Context, job model.Job, progress JobProgress) error
+
+	BulkUpdateTasks(ctx context.Context, userId string, taskIds []string, operation model.BulkTaskOperation) (model.BulkResult, error)
 }
@@ -0,0 +1,14 @@
+package interfaces
+
+import "net/http"
+
+type TemplateController interface {
+	CreateTemplate(w http.ResponseWriter, r *http.Request)
+	CreateTemplateFromTask(w http.ResponseWriter, r *http.Request)
+	InstantiateTemplate(w http.ResponseWriter, r *http.Request)
+
+	GetProjectTemplates(w http.ResponseWriter, r *http.Request)
+	GetTemplateById(w http.ResponseWriter, r *http.Request)
+
+	DeleteTemplate(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/horatiucrisan/task-service/model"
+)
+
+type TemplateRepository interface {
+	CreateTemplate(ctx context.Context, template model.TaskTemplate) (model.TaskTemplate, error)
+
+	GetProjectTemplates(ctx context.Context, projectId string) ([]model.TaskTemplate, error)
+	GetTemplateById(ctx context.Context, templateId string) (model.TaskTemplate, error)
+
+	DeleteTemplate(ctx context.Context, templateId string) error
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/horatiucrisan/task-service/model"
+)
+
+type TemplateService interface {
+	CreateTemplate(ctx context.Context, userId string, template model.TaskTemplate) (model.TaskTemplate, error)
+	CreateTemplateFromTask(ctx context.Context, userId, taskId, name string) (model.TaskTemplate, error)
+	InstantiateTemplate(ctx context.Context, userId, templateId string, variables map[string]string, deadline int64, handlerIds, subtaskHandlerIds []string) (model.TaskDetails, error)
+
+	GetProjectTemplates(ctx context.Context, userId, projectId string) ([]model.TaskTemplate, error)
+	GetTemplateById(ctx context.Context, userId, templateId string) (model.TaskTemplate, error)
+
+	DeleteTemplate(ctx context.Context, userId, templateId string) (model.TaskTemplate, error)
+}
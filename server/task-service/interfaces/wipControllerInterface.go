@@ -0,0 +1,9 @@
+package interfaces
+
+import "net/http"
+
+type WipController interface {
+	GetWipLimits(w http.ResponseWriter, r *http.Request)
+
+	SetWipLimits(w http.ResponseWriter, r *http.Request)
+}
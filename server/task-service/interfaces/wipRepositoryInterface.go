@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/horatiucrisan/task-service/model"
+)
+
+type WipRepository interface {
+	GetWipLimits(ctx context.Context, projectId string) (model.WipLimits, error)
+
+	SetWipLimits(ctx context.Context, limits model.WipLimits) (model.WipLimits, error)
+}
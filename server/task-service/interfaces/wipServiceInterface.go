@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/horatiucrisan/task-service/model"
+)
+
+type WipService interface {
+	GetWipLimits(ctx context.Context, userId, projectId string) (model.WipLimits, error)
+
+	SetWipLimits(ctx context.Context, userId, projectId string, statusLimits, handlerLimits map[string]int) (model.WipLimits, error)
+}
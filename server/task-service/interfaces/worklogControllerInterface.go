@@ -0,0 +1,15 @@
+package interfaces
+
+import "net/http"
+
+type WorkLogController interface {
+	CreateWorkLog(w http.ResponseWriter, r *http.Request)
+	StartTimer(w http.ResponseWriter, r *http.Request)
+	StopTimer(w http.ResponseWriter, r *http.Request)
+
+	GetTaskWorkLogs(w http.ResponseWriter, r *http.Request)
+	GetTimer(w http.ResponseWriter, r *http.Request)
+	GetTimeReport(w http.ResponseWriter, r *http.Request)
+
+	DeleteWorkLog(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/horatiucrisan/task-service/model"
+)
+
+type WorkLogRepository interface {
+	CreateWorkLog(ctx context.Context, worklog model.WorkLog) (model.WorkLog, error)
+
+	GetWorkLogById(ctx context.Context, worklogId string) (model.WorkLog, error)
+	GetTaskWorkLogs(ctx context.Context, taskId string) ([]model.WorkLog, error)
+	GetWorkLogs(ctx context.Context, worklogQuery model.WorkLogQuery) ([]model.WorkLog, error)
+
+	DeleteWorkLog(ctx context.Context, worklogId string) (model.WorkLog, error)
+
+	StartTimer(ctx context.Context, timer model.Timer) (model.Timer, error)
+	GetTimer(ctx context.Context, userId string) (model.Timer, error)
+	StopTimer(ctx context.Context, userId string, stop func(timer model.Timer) (model.WorkLog, error)) (model.WorkLog, error)
+}
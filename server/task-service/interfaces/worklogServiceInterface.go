@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/horatiucrisan/task-service/model"
+)
+
+type WorkLogService interface {
+	CreateWorkLog(ctx context.Context, userId, taskId string, entry model.WorkLog) (model.WorkLog, error)
+
+	GetTaskWorkLogs(ctx context.Context, userId, taskId string) ([]model.WorkLog, error)
+	GetTimeReport(ctx context.Context, userId string, worklogQuery model.WorkLogQuery) (model.TimeReport, error)
+
+	DeleteWorkLog(ctx context.Context, userId, taskId, worklogId string) (model.WorkLog, error)
+
+	StartTimer(ctx context.Context, userId, taskId, subtaskId, note string) (model.Timer, error)
+	GetTimer(ctx context.Context, userId string) (model.Timer, error)
+	StopTimer(ctx context.Context, userId, note string) (model.WorkLog, error)
+}
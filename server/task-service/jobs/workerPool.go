@@ -0,0 +1,178 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+const (
+	// DefaultWorkers is the number of jobs a worker pool runs at the same time
+	DefaultWorkers = 4
+
+	// pollInterval is the time between two checks for new jobs
+	pollInterval = 2 * time.Second
+
+	// lease is the time after which a running job without a heartbeat is claimed by another worker
+	lease = time.Minute
+
+	// retryBackoff is the base delay before a failed job is attempted again
+	retryBackoff = 5 * time.Second
+)
+
+type WorkerPool struct {
+	jobRepository interfaces.JobRepository
+	handlers      map[string]interfaces.JobHandler
+	workers       int
+	workerId      string
+	mu            sync.RWMutex
+}
+
+// NewWorkerPool generates a new pool that runs the jobs stored in the jobs collection
+//
+// Parameters:
+//   - jobRepository: The job repository layer
+//   - workers: The number of jobs to run at the same time
+//
+// Returns:
+//   - *WorkerPool: The new worker pool
+func NewWorkerPool(jobRepository interfaces.JobRepository, workers int) *WorkerPool {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+
+	return &WorkerPool{
+		jobRepository: jobRepository,
+		handlers:      map[string]interfaces.JobHandler{},
+		workers:       workers,
+		workerId:      uuid.NewString(),
+	}
+}
+
+// RegisterHandler adds the handler that executes the jobs of a given type
+//
+// Parameters:
+//   - jobType: The type of the job
+//   - handler: The job handler
+func (p *WorkerPool) RegisterHandler(jobType string, handler interfaces.JobHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.handlers[jobType] = handler
+}
+
+// Start polls the jobs collection in the background and runs the claimed jobs until the context is done
+//
+// Parameters:
+//   - ctx: The context that stops the pool when cancelled
+func (p *WorkerPool) Start(ctx context.Context) {
+	// Generate a semaphore channel to limit the number of jobs running at a time
+	slots := make(chan struct{}, p.workers)
+
+	go func() {
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// Only claim as many jobs as there are free slots
+				free := p.workers - len(slots)
+				if free == 0 {
+					continue
+				}
+
+				jobs, err := p.jobRepository.ClaimJobs(ctx, p.workerId, lease, free)
+				if err != nil {
+					log.Printf("Failed to claim jobs: %v", err)
+					continue
+				}
+
+				// Run each claimed job in its own goroutine
+				for _, job := range jobs {
+					slots <- struct{}{}
+					go func(job model.Job) {
+						defer func() { <-slots }()
+						p.run(ctx, job)
+					}(job)
+				}
+			}
+		}
+	}()
+}
+
+// run is a private method that executes a claimed job and stores its final status
+//
+// Parameters:
+//   - ctx: The pool context
+//   - job: The claimed job
+func (p *WorkerPool) run(ctx context.Context, job model.Job) {
+	// Get the handler of the job type
+	p.mu.RLock()
+	handler, ok := p.handlers[job.Type]
+	p.mu.RUnlock()
+
+	if !ok {
+		if _, err := p.jobRepository.FailJob(ctx, job.ID, p.workerId, fmt.Sprintf("no handler registered for job type `%s`", job.Type)); err != nil {
+			log.Printf("Failed to update job %s: %v", job.ID, err)
+		}
+		return
+	}
+
+	// Generate the progress reporter that also renews the job heartbeat
+	// and stops the handler when the job was cancelled
+	progress := func(processed, total int64) error {
+		updatedJob, err := p.jobRepository.UpdateJobProgress(ctx, job.ID, p.workerId, processed, total)
+		if err != nil {
+			return err
+		}
+
+		if updatedJob.CancelRequested {
+			return utils.ErrJobCancelled
+		}
+
+		return nil
+	}
+
+	// Stop before doing any work if the job was cancelled while it was waiting
+	if job.CancelRequested {
+		if _, err := p.jobRepository.MarkJobCancelled(ctx, job.ID, p.workerId); err != nil {
+			log.Printf("Failed to update job %s: %v", job.ID, err)
+		}
+		return
+	}
+
+	// Execute the job
+	err := handler(ctx, job, progress)
+
+	switch {
+	case errors.Is(err, utils.ErrJobLeaseLost):
+		// Another worker claimed the job, it owns the job status from now on
+		log.Printf("Stopped job %s: %v", job.ID, err)
+		return
+	case err == nil:
+		_, err = p.jobRepository.CompleteJob(ctx, job.ID, p.workerId)
+	case errors.Is(err, utils.ErrJobCancelled):
+		_, err = p.jobRepository.MarkJobCancelled(ctx, job.ID, p.workerId)
+	case job.Attempts < job.MaxAttempts:
+		// Schedule the job again using an exponential backoff
+		delay := retryBackoff * time.Duration(1<<(job.Attempts-1))
+		_, err = p.jobRepository.RetryJob(ctx, job.ID, p.workerId, err.Error(), time.Now().Add(delay).UnixMilli())
+	default:
+		_, err = p.jobRepository.FailJob(ctx, job.ID, p.workerId, err.Error())
+	}
+
+	if err != nil {
+		log.Printf("Failed to update job %s: %v", job.ID, err)
+	}
+}
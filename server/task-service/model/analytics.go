@@ -0,0 +1,88 @@
+package model
+
+// ProjectAnalytics holds the progress of a project inside a time window.
+// The status counts, the completion rate and the subtask progress cover the tasks created inside the window,
+// the lead and cycle times cover the tasks completed inside the window and the workload covers the currently open tasks
+type ProjectAnalytics struct {
+	ProjectID        string            `json:"projectId"`
+	From             int64             `json:"from"`
+	To               int64             `json:"to"`
+	GeneratedAt      int64             `json:"generatedAt"`
+	TotalTasks       int               `json:"totalTasks"`
+	TasksByStatus    map[string]int    `json:"tasksByStatus"`
+	OverdueTasks     int               `json:"overdueTasks"`
+	CompletedTasks   int               `json:"completedTasks"`
+	CompletionRate   float64           `json:"completionRate"`
+	AverageLeadTime  int64             `json:"averageLeadTime"`
+	AverageCycleTime int64             `json:"averageCycleTime"`
+	HandlerWorkload  []HandlerWorkload `json:"handlerWorkload"`
+	Subtasks         SubtaskProgress   `json:"subtasks"`
+}
+
+// HandlerWorkload holds the open work assigned to a handler
+type HandlerWorkload struct {
+	HandlerID    string  `json:"handlerId"`
+	OpenTasks    int     `json:"openTasks"`
+	OpenPoints   float64 `json:"openPoints"`
+	OverdueTasks int     `json:"overdueTasks"`
+}
+
+// SubtaskProgress holds the completion of the subtasks, the average is computed over the tasks that have subtasks
+type SubtaskProgress struct {
+	Total                 int64   `json:"total"`
+	Completed             int64   `json:"completed"`
+	CompletionRate        float64 `json:"completionRate"`
+	AverageTaskCompletion float64 `json:"averageTaskCompletion"`
+}
+
+// FlowPoint holds the number of tasks in each status at the end of a day
+type FlowPoint struct {
+	Date     int64          `json:"date"`
+	Statuses map[string]int `json:"statuses"`
+}
+
+// CumulativeFlow holds the cumulative flow diagram series of a project
+type CumulativeFlow struct {
+	ProjectID string      `json:"projectId"`
+	From      int64       `json:"from"`
+	To        int64       `json:"to"`
+	Statuses  []string    `json:"statuses"`
+	Days      []FlowPoint `json:"days"`
+}
+
+// StatusPeriod holds an uninterrupted time a task spent in a status, the current period ends now
+type StatusPeriod struct {
+	Status    string `json:"status"`
+	StartedAt int64  `json:"startedAt"`
+	EndedAt   int64  `json:"endedAt"`
+	Duration  int64  `json:"duration"`
+}
+
+// TimeInStatus holds the time a task spent in each status
+type TimeInStatus struct {
+	TaskID  string           `json:"taskId"`
+	Status  string           `json:"status"`
+	Periods []StatusPeriod   `json:"periods"`
+	Totals  map[string]int64 `json:"totals"`
+}
+
+// Percentiles holds the distribution of a list of durations
+type Percentiles struct {
+	Count   int   `json:"count"`
+	Average int64 `json:"average"`
+	P50     int64 `json:"p50"`
+	P75     int64 `json:"p75"`
+	P85     int64 `json:"p85"`
+	P95     int64 `json:"p95"`
+}
+
+// CycleTimeMetrics holds the lead and cycle time distributions of the tasks completed inside a time window
+type CycleTimeMetrics struct {
+	ProjectID      string                 `json:"projectId"`
+	From           int64                  `json:"from"`
+	To             int64                  `json:"to"`
+	CompletedTasks int                    `json:"completedTasks"`
+	LeadTime       Percentiles            `json:"leadTime"`
+	CycleTime      Percentiles            `json:"cycleTime"`
+	TimeInStatus   map[string]Percentiles `json:"timeInStatus"`
+}
@@ -0,0 +1,28 @@
+package model
+
+// Absence holds a period a project member is not available to work on the project tasks.
+// The absences are stored in a subcollection of the project document next to its members
+type Absence struct {
+	ID        string `firestore:"id" json:"id"`
+	ProjectID string `firestore:"projectId" json:"projectId"`
+	UserID    string `firestore:"userId" json:"userId"`
+	StartAt   int64  `firestore:"startAt" json:"startAt"`
+	EndAt     int64  `firestore:"endAt" json:"endAt"`
+	Reason    string `firestore:"reason" json:"reason"`
+	CreatedBy string `firestore:"createdBy" json:"createdBy"`
+	CreatedAt int64  `firestore:"createdAt" json:"createdAt"`
+}
+
+// AbsenceWarning holds the absence of a handler that covers the deadline of a task
+type AbsenceWarning struct {
+	HandlerID string `json:"handlerId"`
+	AbsenceID string `json:"absenceId"`
+	StartAt   int64  `json:"startAt"`
+	EndAt     int64  `json:"endAt"`
+}
+
+// TaskAtRisk holds an open task whose deadline falls inside the upcoming absence of one of its handlers
+type TaskAtRisk struct {
+	Task     Task             `json:"task"`
+	Warnings []AbsenceWarning `json:"warnings"`
+}
@@ -0,0 +1,17 @@
+package model
+
+// Deadline notice types
+const (
+	NoticeReminder   = "reminder"
+	NoticeOverdue    = "overdue"
+	NoticeEscalation = "escalation"
+)
+
+// DeadlineNotice holds a notification that has to be sent about the deadline of a task.
+// Each notice is claimed on the task document before it is returned, so it is sent only once
+type DeadlineNotice struct {
+	Type             string `json:"type"`
+	Task             Task   `json:"task"`
+	Offset           int64  `json:"offset,omitempty"`
+	ProjectManagerID string `json:"projectManagerId,omitempty"`
+}
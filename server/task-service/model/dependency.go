@@ -0,0 +1,21 @@
+package model
+
+type DependencyNode struct {
+	ID          string   `json:"id"`
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	HandlerIDs  []string `json:"handlerIds"`
+	Blocked     bool     `json:"blocked"`
+}
+
+// DependencyEdge links a task to the task it blocks
+type DependencyEdge struct {
+	BlockerID string `json:"blockerId"`
+	TaskID    string `json:"taskId"`
+}
+
+type DependencyGraph struct {
+	ProjectID string           `json:"projectId"`
+	Nodes     []DependencyNode `json:"nodes"`
+	Edges     []DependencyEdge `json:"edges"`
+}
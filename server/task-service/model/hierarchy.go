@@ -0,0 +1,15 @@
+package model
+
+// TaskProgress is the progress of a parent task computed from the statuses of its children
+type TaskProgress struct {
+	Total     int            `json:"total"`
+	Completed int            `json:"completed"`
+	Percent   float64        `json:"percent"`
+	Statuses  map[string]int `json:"statuses"`
+}
+
+type TaskTree struct {
+	Task     Task         `json:"task"`
+	Progress TaskProgress `json:"progress"`
+	Children []*TaskTree  `json:"children"`
+}
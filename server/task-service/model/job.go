@@ -0,0 +1,35 @@
+package model
+
+// Job statuses
+const (
+	JobStatusPending   = "pending"
+	JobStatusRunning   = "running"
+	JobStatusCompleted = "completed"
+	JobStatusFailed    = "failed"
+	JobStatusCancelled = "cancelled"
+)
+
+// Job types
+const (
+	JobTypeDeleteTaskSubcollections = "delete-task-subcollections"
+)
+
+type Job struct {
+	ID              string         `firestore:"id" json:"id"`
+	Type            string         `firestore:"type" json:"type"`
+	Status          string         `firestore:"status" json:"status"`
+	Payload         map[string]any `firestore:"payload" json:"payload"`
+	CreatedBy       string         `firestore:"createdBy" json:"createdBy"`
+	Processed       int64          `firestore:"processed" json:"processed"`
+	Total           int64          `firestore:"total" json:"total"`
+	Attempts        int            `firestore:"attempts" json:"attempts"`
+	MaxAttempts     int            `firestore:"maxAttempts" json:"maxAttempts"`
+	Error           string         `firestore:"error" json:"error,omitempty"`
+	CancelRequested bool           `firestore:"cancelRequested" json:"cancelRequested"`
+	WorkerID        string         `firestore:"workerId" json:"workerId,omitempty"`
+	RunAt           int64          `firestore:"runAt" json:"runAt"`
+	HeartbeatAt     int64          `firestore:"heartbeatAt" json:"heartbeatAt"`
+	CreatedAt       int64          `firestore:"createdAt" json:"createdAt"`
+	UpdatedAt       int64          `firestore:"updatedAt" json:"updatedAt"`
+	CompletedAt     *int64         `firestore:"completedAt,omitempty" json:"completedAt,omitempty"`
+}
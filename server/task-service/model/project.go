@@ -0,0 +1,20 @@
+package model
+
+// Project holds the fields of a project document that the task service reads.
+// The projects are owned by the project service
+type Project struct {
+	ID               string   `firestore:"id" json:"id"`
+	Title            string   `firestore:"title" json:"title"`
+	ProjectManagerID string   `firestore:"projectManagerId" json:"projectManagerId"`
+	MemberIDs        []string `firestore:"memberIds" json:"memberIds"`
+}
+
+// Label holds the fields of a label from the project label catalog
+type Label struct {
+	ID          string `firestore:"id" json:"id"`
+	ProjectID   string `firestore:"projectId" json:"projectId"`
+	Name        string `firestore:"name" json:"name"`
+	Color       string `firestore:"color" json:"color"`
+	Description string `firestore:"description" json:"description"`
+	CreatedAt   int64  `firestore:"createdAt" json:"createdAt"`
+}
@@ -0,0 +1,63 @@
+package model
+
+// Recurrence frequencies
+const (
+	FrequencyDaily   = "daily"
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+)
+
+// Series statuses
+const (
+	SeriesStatusActive   = "active"
+	SeriesStatusStopped  = "stopped"
+	SeriesStatusFinished = "finished"
+)
+
+// RecurrenceRule holds the supported subset of an RRULE.
+// The series ends after Count occurrences or after the Until timestamp, whichever is set
+type RecurrenceRule struct {
+	Frequency string `firestore:"frequency" json:"frequency"`
+	Interval  int    `firestore:"interval" json:"interval"`
+	Until     *int64 `firestore:"until,omitempty" json:"until,omitempty"`
+	Count     int    `firestore:"count,omitempty" json:"count,omitempty"`
+}
+
+// TaskSeries holds a recurring task. Every occurrence is a task created from the blueprint of the series,
+// its deadline is computed from the anchor deadline and the recurrence rule
+type TaskSeries struct {
+	ID          string            `firestore:"id" json:"id"`
+	ProjectID   string            `firestore:"projectId" json:"projectId"`
+	AuthorID    string            `firestore:"authorId" json:"authorId"`
+	Rule        RecurrenceRule    `firestore:"rule" json:"rule"`
+	Status      string            `firestore:"status" json:"status"`
+	Description string            `firestore:"description" json:"description"`
+	HandlerIDs  []string          `firestore:"handlerIds" json:"handlerIds"`
+	Priority    string            `firestore:"priority" json:"priority"`
+	Severity    string            `firestore:"severity,omitempty" json:"severity,omitempty"`
+	Labels      []string          `firestore:"labels" json:"labels"`
+	Subtasks    []TemplateSubtask `firestore:"subtasks" json:"subtasks"`
+	AnchorAt    int64             `firestore:"anchorAt" json:"anchorAt"`
+	AnchorIndex int               `firestore:"anchorIndex" json:"anchorIndex"`
+	Occurrences int               `firestore:"occurrences" json:"occurrences"`
+	LastTaskID  string            `firestore:"lastTaskId" json:"lastTaskId"`
+	NextRunAt   int64             `firestore:"nextRunAt" json:"nextRunAt"`
+	CreatedAt   int64             `firestore:"createdAt" json:"createdAt"`
+	UpdatedAt   int64             `firestore:"updatedAt" json:"updatedAt"`
+}
+
+// SeriesOccurrence holds a task created by a series and the updated series
+type SeriesOccurrence struct {
+	Series   TaskSeries `json:"series"`
+	Task     Task       `json:"task"`
+	Subtasks []Subtask  `json:"subtasks"`
+}
+
+// SeriesChanges holds the edited fields of a task series, the empty fields are kept unchanged
+type SeriesChanges struct {
+	Rule        *RecurrenceRule `json:"rule,omitempty"`
+	Description string          `json:"description,omitempty"`
+	HandlerIDs  []string        `json:"handlerIds,omitempty"`
+	Priority    string          `json:"priority,omitempty"`
+	Severity    string          `json:"severity,omitempty"`
+}
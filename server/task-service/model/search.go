@@ -0,0 +1,17 @@
+package model
+
+// Searchable document types
+const (
+	SearchTypeTask     = "task"
+	SearchTypeSubtask  = "subtask"
+	SearchTypeResponse = "response"
+)
+
+type SearchHit struct {
+	ID        string  `json:"id"`
+	Type      string  `json:"type"`
+	TaskID    string  `json:"taskId"`
+	ProjectID string  `json:"projectId"`
+	Score     float64 `json:"score"`
+	Highlight string  `json:"highlight"`
+}
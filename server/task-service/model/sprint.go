@@ -0,0 +1,69 @@
+package model
+
+// Sprint holds a time-boxed iteration of a project that tasks can be planned into
+type Sprint struct {
+	ID        string `firestore:"id" json:"id"`
+	ProjectID string `firestore:"projectId" json:"projectId"`
+	AuthorID  string `firestore:"authorId" json:"authorId"`
+	Name      string `firestore:"name" json:"name"`
+	Goal      string `firestore:"goal" json:"goal"`
+	StartAt   int64  `firestore:"startAt" json:"startAt"`
+	EndAt     int64  `firestore:"endAt" json:"endAt"`
+	CreatedAt int64  `firestore:"createdAt" json:"createdAt"`
+	UpdatedAt int64  `firestore:"updatedAt" json:"updatedAt"`
+}
+
+type SprintChanges struct {
+	Name    string
+	Goal    *string
+	StartAt *int64
+	EndAt   *int64
+}
+
+// TaskEstimate holds the estimated effort of a task, a nil field removes the estimate
+type TaskEstimate struct {
+	StoryPoints   *float64 `json:"storyPoints"`
+	EstimatedTime *int64   `json:"estimatedTime"`
+}
+
+// StatusTransition holds a status change of a task, the history is used to rebuild the state of a sprint at any date
+type StatusTransition struct {
+	ID        string `firestore:"id" json:"id"`
+	TaskID    string `firestore:"taskId" json:"taskId"`
+	ProjectID string `firestore:"projectId" json:"projectId"`
+	From      string `firestore:"from" json:"from"`
+	To        string `firestore:"to" json:"to"`
+	ChangedAt int64  `firestore:"changedAt" json:"changedAt"`
+}
+
+// BurndownPoint holds the work left in a sprint at the end of a day
+type BurndownPoint struct {
+	Date            int64   `json:"date"`
+	RemainingPoints float64 `json:"remainingPoints"`
+	RemainingTime   int64   `json:"remainingTime"`
+	RemainingTasks  int     `json:"remainingTasks"`
+	IdealPoints     float64 `json:"idealPoints"`
+}
+
+type Burndown struct {
+	Sprint      Sprint          `json:"sprint"`
+	TotalPoints float64         `json:"totalPoints"`
+	TotalTime   int64           `json:"totalTime"`
+	TotalTasks  int             `json:"totalTasks"`
+	Days        []BurndownPoint `json:"days"`
+}
+
+// SprintVelocity holds the work planned into a finished sprint and the work completed by its end
+type SprintVelocity struct {
+	Sprint          Sprint  `json:"sprint"`
+	CommittedPoints float64 `json:"committedPoints"`
+	CompletedPoints float64 `json:"completedPoints"`
+	CommittedTasks  int     `json:"committedTasks"`
+	CompletedTasks  int     `json:"completedTasks"`
+}
+
+type Velocity struct {
+	ProjectID     string           `json:"projectId"`
+	AveragePoints float64          `json:"averagePoints"`
+	Sprints       []SprintVelocity `json:"sprints"`
+}
@@ -0,0 +1,29 @@
+package model
+
+// Auto assignment modes of a new task
+const (
+	AutoAssignRoundRobin  = "round-robin"
+	AutoAssignLeastLoaded = "least-loaded"
+)
+
+// HandlerCandidate holds the workload of a project member considered as the handler of a new task.
+// The open work covers the open project tasks and the recent work covers the work logs of the activity window
+type HandlerCandidate struct {
+	UserID            string  `json:"userId"`
+	OpenTasks         int     `json:"openTasks"`
+	OverdueTasks      int     `json:"overdueTasks"`
+	OpenPoints        float64 `json:"openPoints"`
+	OpenEstimatedTime int64   `json:"openEstimatedTime"`
+	RecentLoggedTime  int64   `json:"recentLoggedTime"`
+	LastActiveAt      *int64  `json:"lastActiveAt,omitempty"`
+	LastAssignedAt    *int64  `json:"lastAssignedAt,omitempty"`
+}
+
+// HandlerSuggestions holds the members of a project ranked from the most to the least available.
+// The members that are absent before the deadline of the new task are not candidates
+type HandlerSuggestions struct {
+	ProjectID      string             `json:"projectId"`
+	GeneratedAt    int64              `json:"generatedAt"`
+	Candidates     []HandlerCandidate `json:"candidates"`
+	UnavailableIDs []string           `json:"unavailableIds"`
+}
@@ -5,20 +5,98 @@ import (
 )
 
 type Task struct {
-	ID                    string   `firestore:"id" json:"id"`
-	AuthorID              string   `firestore:"authorId" json:"authorId"`
-	ProjectID             string   `firestore:"projectId" json:"projectId"`
-	HandlerIDs            []string `firestore:"handlerIds" json:"handlerIds"`
-	Description           string   `firestore:"description" json:"description"`
-	Status                string   `firestore:"status" json:"status"`
-	Deadline              int64    `firestore:"deadline" json:"deadline"`
-	CreatedAt             int64    `firestore:"createdAt" json:"createdAt"`
-	CompletedAt           *int64   `firestore:"completedAt,omitempty" json:"completedAt,omitempty"`
-	SubtaskCount          int64    `firestore:"subtaskCount" json:"subtaskCount"`
-	ResponseCount         int64    `firestore:"responseCount" json:"responseCount"`
-	CompletedSubtaskCount int64    `firestore:"completedSubtaskCount" json:"completedSubtaskCount"`
+	ID                    string                       `firestore:"id" json:"id"`
+	AuthorID              string                       `firestore:"authorId" json:"authorId"`
+	ProjectID             string                       `firestore:"projectId" json:"projectId"`
+	HandlerIDs            []string                     `firestore:"handlerIds" json:"handlerIds"`
+	Description           string                       `firestore:"description" json:"description"`
+	Status                string                       `firestore:"status" json:"status"`
+	Deadline              int64                        `firestore:"deadline" json:"deadline"`
+	CreatedAt             int64                        `firestore:"createdAt" json:"createdAt"`
+	CompletedAt           *int64                       `firestore:"completedAt,omitempty" json:"completedAt,omitempty"`
+	SubtaskCount          int64                        `firestore:"subtaskCount" json:"subtaskCount"`
+	ResponseCount         int64                        `firestore:"responseCount" json:"responseCount"`
+	CompletedSubtaskCount int64                        `firestore:"completedSubtaskCount" json:"completedSubtaskCount"`
+	Priority              string                       `firestore:"priority" json:"priority"`
+	PriorityRank          int                          `firestore:"priorityRank" json:"priorityRank"`
+	Severity              string                       `firestore:"severity,omitempty" json:"severity,omitempty"`
+	Labels                []string                     `firestore:"labels" json:"labels"`
+	BlockedBy             []string                     `firestore:"blockedBy" json:"blockedBy"`
+	Blocks                []string                     `firestore:"blocks" json:"blocks"`
+	ParentTaskID          string                       `firestore:"parentTaskId,omitempty" json:"parentTaskId,omitempty"`
+	SeriesID              string                       `firestore:"seriesId,omitempty" json:"seriesId,omitempty"`
+	Occurrence            int                          `firestore:"occurrence,omitempty" json:"occurrence,omitempty"`
+	Reminders             []string                     `firestore:"reminders,omitempty" json:"reminders,omitempty"`
+	OverdueAt             *int64                       `firestore:"overdueAt,omitempty" json:"overdueAt,omitempty"`
+	EscalatedAt           *int64                       `firestore:"escalatedAt" json:"escalatedAt,omitempty"`
+	LoggedTime            int64                        `firestore:"loggedTime" json:"loggedTime"`
+	StoryPoints           *float64                     `firestore:"storyPoints,omitempty" json:"storyPoints,omitempty"`
+	EstimatedTime         *int64                       `firestore:"estimatedTime,omitempty" json:"estimatedTime,omitempty"`
+	SprintID              string                       `firestore:"sprintId,omitempty" json:"sprintId,omitempty"`
+	Rank                  string                       `firestore:"rank" json:"rank"`
+	ReviewerIDs           []string                     `firestore:"reviewerIds,omitempty" json:"reviewerIds,omitempty"`
+	ApprovedBy            string                       `firestore:"approvedBy,omitempty" json:"approvedBy,omitempty"`
+	ApprovedAt            *int64                       `firestore:"approvedAt,omitempty" json:"approvedAt,omitempty"`
+	Assignments           map[string]HandlerAssignment `firestore:"assignments,omitempty" json:"assignments,omitempty"`
+	AbsenceWarnings       []AbsenceWarning             `firestore:"-" json:"absenceWarnings,omitempty"`
 }
 
+// Task statuses
+const (
+	TaskStatusNew         = "new"
+	TaskStatusDevelopment = "development"
+	TaskStatusOnHold      = "on-hold"
+	TaskStatusInReview    = "in-review"
+	TaskStatusCompleted   = "completed"
+)
+
+// TaskStatuses holds all the task statuses in the order of the workflow
+var TaskStatuses = []string{TaskStatusNew, TaskStatusDevelopment, TaskStatusOnHold, TaskStatusInReview, TaskStatusCompleted}
+
+// OpenTaskStatuses holds the statuses of the tasks that still have work left
+var OpenTaskStatuses = []string{TaskStatusNew, TaskStatusDevelopment, TaskStatusOnHold, TaskStatusInReview}
+
+// Handler assignment states
+const (
+	AssignmentPending  = "pending"
+	AssignmentAccepted = "accepted"
+	AssignmentDeclined = "declined"
+)
+
+// HandlerAssignment holds the answer of a handler to a task assignment. The handlers assigned
+// before the assignments were tracked have no assignment and are considered accepted
+type HandlerAssignment struct {
+	State      string `firestore:"state" json:"state"`
+	Reason     string `firestore:"reason,omitempty" json:"reason,omitempty"`
+	AssignedBy string `firestore:"assignedBy" json:"assignedBy"`
+	AssignedAt int64  `firestore:"assignedAt" json:"assignedAt"`
+	AnsweredAt *int64 `firestore:"answeredAt,omitempty" json:"answeredAt,omitempty"`
+}
+
+// Task priorities
+const (
+	PriorityCritical = "critical"
+	PriorityHigh     = "high"
+	PriorityMedium   = "medium"
+	PriorityLow      = "low"
+)
+
+// PriorityRanks maps each priority to the rank used for sorting, the most urgent priority has the highest rank
+var PriorityRanks = map[string]int{
+	PriorityCritical: 4,
+	PriorityHigh:     3,
+	PriorityMedium:   2,
+	PriorityLow:      1,
+}
+
+// Task severities
+const (
+	SeverityBlocker = "blocker"
+	SeverityMajor   = "major"
+	SeverityMinor   = "minor"
+	SeverityTrivial = "trivial"
+)
+
 type Subtask struct {
 	ID          string `firestore:"id" json:"id"`
 	TaskID      string `firestore:"taskId" json:"taskId"`
@@ -27,6 +105,23 @@ type Subtask struct {
 	Description string `firestore:"description" json:"description"`
 	CreatedAt   int64  `firestore:"createdAt" json:"createdAt"`
 	Done        bool   `firestore:"done" json:"done"`
+	PromotedTo  string `firestore:"promotedTo,omitempty" json:"promotedTo,omitempty"`
+	LoggedTime  int64  `firestore:"loggedTime" json:"loggedTime"`
+}
+
+// SubtaskPromotion holds the data changed when a subtask is converted to a task
+type SubtaskPromotion struct {
+	Task       Task    `json:"task"`
+	Subtask    Subtask `json:"subtask"`
+	ParentTask Task    `json:"parentTask"`
+	Removed    bool    `json:"removed"`
+}
+
+// TaskDetails holds a task together with its subtasks and responses
+type TaskDetails struct {
+	Task      Task       `json:"task"`
+	Subtasks  []Subtask  `json:"subtasks"`
+	Responses []Response `json:"responses"`
 }
 
 type Response struct {
@@ -97,7 +192,94 @@ type DataVersion struct {
 }
 
 type EncodedResponse struct {
+	Success    bool   `json:"success"`
+	Message    string `json:"message"`
+	Data       any    `json:"data"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// Bulk task operations
+const (
+	BulkSetStatus      = "set-status"
+	BulkAddHandlers    = "add-handlers"
+	BulkRemoveHandlers = "remove-handlers"
+	BulkSetDeadline    = "set-deadline"
+	BulkDelete         = "delete"
+)
+
+type BulkTaskOperation struct {
+	Operation  string   `json:"operation"`
+	Status     string   `json:"status,omitempty"`
+	HandlerIDs []string `json:"handlerIds,omitempty"`
+	Deadline   int64    `json:"deadline,omitempty"`
+	Override   bool     `json:"override,omitempty"`
+}
+
+type BulkItemResult struct {
+	TaskID  string `json:"taskId"`
 	Success bool   `json:"success"`
-	Message string `json:"message"`
-	Data    any    `json:"data"`
+	Error   string `json:"error,omitempty"`
 }
+
+type BulkResult struct {
+	Operation string           `json:"operation"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BulkItemResult `json:"results"`
+	Tasks     []Task           `json:"tasks"`
+	Jobs      []Job            `json:"jobs,omitempty"`
+}
+
+type TaskQuery struct {
+	ProjectID         string   `json:"projectId"`
+	Limit             int      `json:"limit"`
+	OrderBy           string   `json:"orderBy"`
+	OrderDirection    string   `json:"orderDirection"`
+	Cursor            string   `json:"cursor,omitempty"`
+	Statuses          []string `json:"statuses,omitempty"`
+	Priorities        []string `json:"priorities,omitempty"`
+	Labels            []string `json:"labels,omitempty"`
+	HandlerID         string   `json:"handlerId,omitempty"`
+	AuthorID          string   `json:"authorId,omitempty"`
+	DeadlineFrom      *int64   `json:"deadlineFrom,omitempty"`
+	DeadlineTo        *int64   `json:"deadlineTo,omitempty"`
+	CreatedFrom       *int64   `json:"createdFrom,omitempty"`
+	CreatedTo         *int64   `json:"createdTo,omitempty"`
+	HasOpenSubtasks   *bool    `json:"hasOpenSubtasks,omitempty"`
+	PendingAcceptance *bool    `json:"pendingAcceptance,omitempty"`
+}
+
+type AssignedSubtask struct {
+	Subtask Subtask `json:"subtask"`
+	Task    Task    `json:"task"`
+}
+
+type ProjectWork struct {
+	ProjectID    string            `json:"projectId"`
+	ProjectTitle string            `json:"projectTitle"`
+	Tasks        []Task            `json:"tasks"`
+	Subtasks     []AssignedSubtask `json:"subtasks"`
+}
+
+// TaskReview holds the result of a review decision, the review comment is stored as a task response
+type TaskReview struct {
+	Task     Task      `json:"task"`
+	Response *Response `json:"response,omitempty"`
+}
+
+// TaskMove holds the result of moving a task to another project
+type TaskMove struct {
+	Task               Task     `json:"task"`
+	FromProjectID      string   `json:"fromProjectId"`
+	ToProjectID        string   `json:"toProjectId"`
+	DroppedHandlerIDs  []string `json:"droppedHandlerIds"`
+	DroppedReviewerIDs []string `json:"droppedReviewerIds"`
+	DroppedLabels      []string `json:"droppedLabels"`
+}
+
+// Ways to handle the task handlers that are not members of the target project
+const (
+	NonMembersReject = "reject"
+	NonMembersDrop   = "drop"
+	NonMembersKeep   = "keep"
+)
@@ -0,0 +1,22 @@
+package model
+
+// TaskTemplate holds a reusable blueprint of a task and its subtasks.
+// The descriptions may contain `{{variable}}` placeholders that are filled in when the template is instantiated
+type TaskTemplate struct {
+	ID          string            `firestore:"id" json:"id"`
+	ProjectID   string            `firestore:"projectId" json:"projectId"`
+	AuthorID    string            `firestore:"authorId" json:"authorId"`
+	Name        string            `firestore:"name" json:"name"`
+	Description string            `firestore:"description" json:"description"`
+	Priority    string            `firestore:"priority" json:"priority"`
+	Severity    string            `firestore:"severity,omitempty" json:"severity,omitempty"`
+	Labels      []string          `firestore:"labels" json:"labels"`
+	Subtasks    []TemplateSubtask `firestore:"subtasks" json:"subtasks"`
+	Variables   []string          `firestore:"variables" json:"variables"`
+	CreatedAt   int64             `firestore:"createdAt" json:"createdAt"`
+}
+
+// TemplateSubtask holds the blueprint of a subtask from a task template
+type TemplateSubtask struct {
+	Description string `firestore:"description" json:"description"`
+}
@@ -0,0 +1,12 @@
+package model
+
+// WipLimits holds the work in progress limits of a project. The status limits bound the number of project
+// tasks in a status and the handler limits bound the number of tasks in a status assigned to a single handler.
+// A status without a limit is not bounded
+type WipLimits struct {
+	ProjectID     string         `firestore:"projectId" json:"projectId"`
+	StatusLimits  map[string]int `firestore:"statusLimits" json:"statusLimits"`
+	HandlerLimits map[string]int `firestore:"handlerLimits" json:"handlerLimits"`
+	UpdatedBy     string         `firestore:"updatedBy" json:"updatedBy"`
+	UpdatedAt     int64          `firestore:"updatedAt" json:"updatedAt"`
+}
@@ -0,0 +1,47 @@
+package model
+
+// WorkLog holds the effort a user spent on a task or on one of its subtasks
+type WorkLog struct {
+	ID        string `firestore:"id" json:"id"`
+	ProjectID string `firestore:"projectId" json:"projectId"`
+	TaskID    string `firestore:"taskId" json:"taskId"`
+	SubtaskID string `firestore:"subtaskId,omitempty" json:"subtaskId,omitempty"`
+	UserID    string `firestore:"userId" json:"userId"`
+	StartedAt int64  `firestore:"startedAt" json:"startedAt"`
+	EndedAt   int64  `firestore:"endedAt" json:"endedAt"`
+	Duration  int64  `firestore:"duration" json:"duration"`
+	Note      string `firestore:"note" json:"note"`
+	CreatedAt int64  `firestore:"createdAt" json:"createdAt"`
+}
+
+// Timer holds the running timer of a user, a user can run a single timer at a time
+type Timer struct {
+	UserID    string `firestore:"userId" json:"userId"`
+	ProjectID string `firestore:"projectId" json:"projectId"`
+	TaskID    string `firestore:"taskId" json:"taskId"`
+	SubtaskID string `firestore:"subtaskId,omitempty" json:"subtaskId,omitempty"`
+	Note      string `firestore:"note" json:"note"`
+	StartedAt int64  `firestore:"startedAt" json:"startedAt"`
+}
+
+type WorkLogQuery struct {
+	ProjectID string `json:"projectId,omitempty"`
+	UserID    string `json:"userId,omitempty"`
+	From      int64  `json:"from"`
+	To        int64  `json:"to"`
+}
+
+// TimeTotal holds the time a user logged on a task
+type TimeTotal struct {
+	UserID   string `json:"userId"`
+	TaskID   string `json:"taskId"`
+	Duration int64  `json:"duration"`
+}
+
+// TimeReport holds the work logs of a date range and their totals
+type TimeReport struct {
+	Query    WorkLogQuery `json:"query"`
+	Duration int64        `json:"duration"`
+	Totals   []TimeTotal  `json:"totals"`
+	WorkLogs []WorkLog    `json:"workLogs"`
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+
+	firestore "cloud.google.com/go/firestore"
+
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+type analyticsRepository struct {
+	client *firestore.Client
+}
+
+func NewAnalyticsRepository(client *firestore.Client) interfaces.AnalyticsRepository {
+	return &analyticsRepository{client: client}
+}
+
+// GetProjectTransitions retrieves the status changes of the tasks of a project made before a date
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - projectId: The ID of the project
+//   - to: The exclusive end of the history
+//
+// Returns:
+//   - []model.StatusTransition: The list of status transitions ordered by their date
+//   - error: An error that occured during the process
+func (r *analyticsRepository) GetProjectTransitions(ctx context.Context, projectId string, to int64) ([]model.StatusTransition, error) {
+	query := r.client.Collection(utils.EnvInstances.TRANSITIONS_COLLECTION).
+		Where("projectId", "==", projectId).
+		Where("changedAt", "<", to).
+		OrderBy("changedAt", firestore.Asc)
+
+	return collectTransitions(ctx, query)
+}
+
+// GetTaskTransitions retrieves the status changes of a task
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//
+// Returns:
+//   - []model.StatusTransition: The list of status transitions ordered by their date
+//   - error: An error that occured during the process
+func (r *analyticsRepository) GetTaskTransitions(ctx context.Context, taskId string) ([]model.StatusTransition, error) {
+	query := r.client.Collection(utils.EnvInstances.TRANSITIONS_COLLECTION).
+		Where("taskId", "==", taskId).
+		OrderBy("changedAt", firestore.Asc)
+
+	return collectTransitions(ctx, query)
+}
+
+// collectTransitions is a private function that runs a query and converts the snapshots into status transitions
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - query: The status transition query
+//
+// Returns:
+//   - []model.StatusTransition: The list of status transitions
+//   - error: An error that occured during the process
+func collectTransitions(ctx context.Context, query firestore.Query) ([]model.StatusTransition, error) {
+	docSnapshots, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Iterate over the document snapshots
+	transitions := []model.StatusTransition{}
+	for _, doc := range docSnapshots {
+		var transition model.StatusTransition
+		if err := doc.DataTo(&transition); err != nil {
+			return nil, err
+		}
+
+		transitions = append(transitions, transition)
+	}
+
+	return transitions, nil
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+
+	firestore "cloud.google.com/go/firestore"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+type availabilityRepository struct {
+	client *firestore.Client
+}
+
+func NewAvailabilityRepository(client *firestore.Client) interfaces.AvailabilityRepository {
+	return &availabilityRepository{client: client}
+}
+
+// CreateAbsence retrieves the data from the service layer and stores a new absence of a project member
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - absence: The absence data
+//
+// Returns:
+//   - model.Absence: The stored absence
+//   - error: An error that occured during the process
+func (r *availabilityRepository) CreateAbsence(ctx context.Context, absence model.Absence) (model.Absence, error) {
+	if _, err := r.absences(absence.ProjectID).Doc(absence.ID).Set(ctx, absence); err != nil {
+		return model.Absence{}, err
+	}
+
+	return absence, nil
+}
+
+// GetAbsences retrieves the data from the service layer and returns the absences of a project that overlap a date range
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - projectId: The ID of the project
+//   - from: The start of the date range
+//   - to: The end of the date range
+//
+// Returns:
+//   - []model.Absence: The list of absences ordered by their start
+//   - error: An error that occured during the process
+func (r *availabilityRepository) GetAbsences(ctx context.Context, projectId string, from, to int64) ([]model.Absence, error) {
+	// Firestore allows range filters on a single field, the absences that start after the range are filtered out below
+	docSnapshots, err := r.absences(projectId).Where("endAt", ">=", from).OrderBy("endAt", firestore.Asc).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	absences := []model.Absence{}
+	for _, doc := range docSnapshots {
+		var absence model.Absence
+		if err := doc.DataTo(&absence); err != nil {
+			return nil, err
+		}
+
+		if absence.StartAt > to {
+			continue
+		}
+
+		absences = append(absences, absence)
+	}
+
+	slices.SortStableFunc(absences, func(a, b model.Absence) int {
+		return cmp.Compare(a.StartAt, b.StartAt)
+	})
+
+	return absences, nil
+}
+
+// GetAbsenceById retrieves the data from the service layer and returns an absence of a project member
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - projectId: The ID of the project
+//   - absenceId: The ID of the absence
+//
+// Returns:
+//   - model.Absence: The absence data
+//   - error: An error that occured during the process
+func (r *availabilityRepository) GetAbsenceById(ctx context.Context, projectId, absenceId string) (model.Absence, error) {
+	docSnapshot, err := r.absences(projectId).Doc(absenceId).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return model.Absence{}, fmt.Errorf("absence with ID %s not found", absenceId)
+		}
+		return model.Absence{}, err
+	}
+
+	var absence model.Absence
+	if err = docSnapshot.DataTo(&absence); err != nil {
+		return model.Absence{}, err
+	}
+
+	return absence, nil
+}
+
+// DeleteAbsence retrieves the data from the service layer and deletes an absence of a project member
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - projectId: The ID of the project
+//   - absenceId: The ID of the absence
+//
+// Returns:
+//   - error: An error that occured during the process
+func (r *availabilityRepository) DeleteAbsence(ctx context.Context, projectId, absenceId string) error {
+	_, err := r.absences(projectId).Doc(absenceId).Delete(ctx)
+	return err
+}
+
+// absences is a private method that returns the absence subcollection of a project document
+//
+// Parameters:
+//   - projectId: The ID of the project
+//
+// Returns:
+//   - *firestore.CollectionRef: The absence subcollection
+func (r *availabilityRepository) absences(projectId string) *firestore.CollectionRef {
+	return r.client.Collection(utils.EnvInstances.PROJECTS_COLLECTION).Doc(projectId).Collection(utils.EnvInstances.ABSENCES_SUBCOLLECTION)
+}
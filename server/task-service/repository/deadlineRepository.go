@@ -0,0 +1,327 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	firestore "cloud.google.com/go/firestore"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+type deadlineRepository struct {
+	client *firestore.Client
+}
+
+func NewDeadlineRepository(client *firestore.Client) interfaces.DeadlineRepository {
+	return &deadlineRepository{client: client}
+}
+
+// GetOpenTasksByDeadline retrieves the open tasks of all the projects whose deadline is inside a time range
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - from: The exclusive start of the range
+//   - to: The inclusive end of the range
+//
+// Returns:
+//   - []model.Task: The list of open tasks ordered by their deadline
+//   - error: An error that occured during the process
+func (r *deadlineRepository) GetOpenTasksByDeadline(ctx context.Context, from, to int64) ([]model.Task, error) {
+	docSnapshots, err := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).
+		Where("status", "in", model.OpenTaskStatuses).
+		Where("deadline", ">", from).
+		Where("deadline", "<=", to).
+		OrderBy("deadline", firestore.Asc).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Iterate over the document snapshots
+	tasks := []model.Task{}
+	for _, doc := range docSnapshots {
+		var task model.Task
+		if err := doc.DataTo(&task); err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// GetOverdueTasks retrieves the open tasks of all the projects that passed their deadline and were not escalated yet.
+// The escalated tasks are left out, so the scan does not grow with the tasks that stay overdue,
+// and so are the tasks without a deadline
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - to: The inclusive end of the deadline range
+//
+// Returns:
+//   - []model.Task: The list of overdue tasks ordered by their deadline
+//   - error: An error that occured during the process
+func (r *deadlineRepository) GetOverdueTasks(ctx context.Context, to int64) ([]model.Task, error) {
+	docSnapshots, err := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).
+		Where("status", "in", model.OpenTaskStatuses).
+		Where("escalatedAt", "==", nil).
+		Where("deadline", ">", 0).
+		Where("deadline", "<=", to).
+		OrderBy("deadline", firestore.Asc).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []model.Task{}
+	for _, doc := range docSnapshots {
+		var task model.Task
+		if err := doc.DataTo(&task); err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// BackfillEscalatedAt stores an empty escalation date on the open tasks created before the escalations.
+// Firestore leaves the documents without the field out of the overdue scan, so they would never be escalated
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//
+// Returns:
+//   - int: The number of updated tasks
+//   - error: An error that occured during the process
+func (r *deadlineRepository) BackfillEscalatedAt(ctx context.Context) (int, error) {
+	// Only the escalation date of the open tasks is read
+	docSnapshots, err := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).
+		Where("status", "in", model.OpenTaskStatuses).
+		Select("escalatedAt").
+		Documents(ctx).GetAll()
+	if err != nil {
+		return 0, err
+	}
+
+	bulkWriter := r.client.BulkWriter(ctx)
+
+	var updateJobs []*firestore.BulkWriterJob
+	for _, doc := range docSnapshots {
+		if _, ok := doc.Data()["escalatedAt"]; ok {
+			continue
+		}
+
+		job, err := bulkWriter.Update(doc.Ref, []firestore.Update{{Path: "escalatedAt", Value: nil}})
+		if err != nil {
+			bulkWriter.End()
+			return 0, err
+		}
+
+		updateJobs = append(updateJobs, job)
+	}
+
+	// Commit the updates and wait for them to finish
+	bulkWriter.End()
+
+	for _, job := range updateJobs {
+		if _, err := job.Results(); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(updateJobs), nil
+}
+
+// ClaimTaskReminder records that a reminder was sent for the current deadline of a task.
+// Only the first caller claims the reminder, so it is never sent twice by restarted or concurrent schedulers
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - deadline: The deadline the reminder is sent for
+//   - key: The key of the reminder
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - bool: True if the reminder was claimed and has to be sent
+//   - error: An error that occured during the process
+func (r *deadlineRepository) ClaimTaskReminder(ctx context.Context, taskId string, deadline int64, key string) (model.Task, bool, error) {
+	return r.claimTaskNotice(ctx, taskId, deadline, func(task *model.Task) []firestore.Update {
+		if slices.Contains(task.Reminders, key) {
+			return nil
+		}
+
+		task.Reminders = append(task.Reminders, key)
+		return []firestore.Update{{Path: "reminders", Value: firestore.ArrayUnion(key)}}
+	})
+}
+
+// MarkTaskOverdue marks a task as overdue once its deadline passed
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - deadline: The deadline that passed
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - bool: True if the task was marked as overdue by this call
+//   - error: An error that occured during the process
+func (r *deadlineRepository) MarkTaskOverdue(ctx context.Context, taskId string, deadline int64) (model.Task, bool, error) {
+	return r.claimTaskNotice(ctx, taskId, deadline, func(task *model.Task) []firestore.Update {
+		if task.OverdueAt != nil {
+			return nil
+		}
+
+		now := time.Now().UnixMilli()
+		task.OverdueAt = &now
+		return []firestore.Update{{Path: "overdueAt", Value: now}}
+	})
+}
+
+// EscalateOverdueTask marks an overdue task as escalated to the project manager
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - deadline: The deadline that passed
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - bool: True if the task was escalated by this call
+//   - error: An error that occured during the process
+func (r *deadlineRepository) EscalateOverdueTask(ctx context.Context, taskId string, deadline int64) (model.Task, bool, error) {
+	return r.claimTaskNotice(ctx, taskId, deadline, func(task *model.Task) []firestore.Update {
+		if task.OverdueAt == nil || task.EscalatedAt != nil {
+			return nil
+		}
+
+		now := time.Now().UnixMilli()
+		task.EscalatedAt = &now
+		return []firestore.Update{{Path: "escalatedAt", Value: now}}
+	})
+}
+
+// ReleaseTaskReminder removes the claim of a reminder that could not be sent, so it is sent by the next check
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - key: The key of the reminder
+//
+// Returns:
+//   - error: An error that occured during the process
+func (r *deadlineRepository) ReleaseTaskReminder(ctx context.Context, taskId, key string) error {
+	return r.releaseTaskNotice(ctx, taskId, firestore.Update{Path: "reminders", Value: firestore.ArrayRemove(key)})
+}
+
+// ReleaseTaskOverdue removes the overdue mark of a task whose overdue notice could not be sent
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//
+// Returns:
+//   - error: An error that occured during the process
+func (r *deadlineRepository) ReleaseTaskOverdue(ctx context.Context, taskId string) error {
+	return r.releaseTaskNotice(ctx, taskId, firestore.Update{Path: "overdueAt", Value: firestore.Delete})
+}
+
+// ReleaseTaskEscalation removes the escalation mark of a task whose escalation could not be sent
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//
+// Returns:
+//   - error: An error that occured during the process
+func (r *deadlineRepository) ReleaseTaskEscalation(ctx context.Context, taskId string) error {
+	return r.releaseTaskNotice(ctx, taskId, firestore.Update{Path: "escalatedAt", Value: nil})
+}
+
+// releaseTaskNotice is a private method that removes the claim of a deadline notice.
+// A task deleted in the meantime has no notice left to release
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - update: The update that removes the claim
+//
+// Returns:
+//   - error: An error that occured during the process
+func (r *deadlineRepository) releaseTaskNotice(ctx context.Context, taskId string, update firestore.Update) error {
+	_, err := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId).Update(ctx, []firestore.Update{update})
+	if err != nil && status.Code(err) != codes.NotFound {
+		return err
+	}
+
+	return nil
+}
+
+// claimTaskNotice is a private method that claims a deadline notice of a task inside a transaction.
+// Nothing is claimed when the task was completed or its deadline changed in the meantime
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - deadline: The deadline the notice is sent for
+//   - claim: The function that changes the task and returns the updates, nil when the notice was already claimed
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - bool: True if the notice was claimed by this call
+//   - error: An error that occured during the process
+func (r *deadlineRepository) claimTaskNotice(ctx context.Context, taskId string, deadline int64, claim func(task *model.Task) []firestore.Update) (model.Task, bool, error) {
+	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
+
+	var claimedTask model.Task
+	claimed := false
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		claimed = false
+
+		docSnapshot, err := tx.Get(docRef)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("task with ID %s not found", taskId)
+			}
+			return err
+		}
+
+		var task model.Task
+		if err := docSnapshot.DataTo(&task); err != nil {
+			return err
+		}
+
+		if task.Status == model.TaskStatusCompleted || task.Deadline != deadline {
+			return nil
+		}
+
+		updates := claim(&task)
+		if updates == nil {
+			return nil
+		}
+
+		if err := tx.Update(docRef, updates); err != nil {
+			return err
+		}
+
+		claimedTask = task
+		claimed = true
+		return nil
+	})
+	if err != nil {
+		return model.Task{}, false, err
+	}
+
+	return claimedTask, claimed, nil
+}
@@ -0,0 +1,381 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	firestore "cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+type jobRepository struct {
+	client *firestore.Client
+}
+
+func NewJobRepository(client *firestore.Client) interfaces.JobRepository {
+	return &jobRepository{client: client}
+}
+
+// CreateJob retrieves the data from the service layer and adds a new job into the database
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - job: The job object
+//
+// Returns:
+//   - model.Job: The created job data
+//   - error: An error that occured during the process
+func (r *jobRepository) CreateJob(ctx context.Context, job model.Job) (model.Job, error) {
+	// Add the new job object into the jobs collection using the ID of the job object
+	_, err := r.client.Collection(utils.EnvInstances.JOBS_COLLECTION).Doc(job.ID).Create(ctx, job)
+	if err != nil {
+		return model.Job{}, err
+	}
+
+	return job, nil
+}
+
+// GetJobById retrieves the data from the service layer and returns the data of the job
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - jobId: The ID of the job
+//
+// Returns:
+//   - model.Job: The data of the job
+//   - error: An error that occured during the process
+func (r *jobRepository) GetJobById(ctx context.Context, jobId string) (model.Job, error) {
+	// Get the job document snapshot
+	docSnapshot, err := r.client.Collection(utils.EnvInstances.JOBS_COLLECTION).Doc(jobId).Get(ctx)
+	if err != nil {
+		// Check if the job exists
+		if status.Code(err) == codes.NotFound {
+			return model.Job{}, fmt.Errorf("job with ID %s not found", jobId)
+		}
+		return model.Job{}, err
+	}
+
+	// Add the snapshot data to the job object
+	var job model.Job
+	if err = docSnapshot.DataTo(&job); err != nil {
+		return model.Job{}, err
+	}
+
+	return job, nil
+}
+
+// ClaimJobs retrieves the jobs that are ready to run and marks them as running for the worker.
+// Running jobs whose heartbeat is older than the lease are claimed again, so the work of a
+// crashed worker is resumed by another one
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - workerId: The ID of the worker that claims the jobs
+//   - lease: The time after which a running job without a heartbeat is considered abandoned
+//   - limit: The max number of jobs to claim
+//
+// Returns:
+//   - []model.Job: The list of claimed jobs
+//   - error: An error that occured during the process
+func (r *jobRepository) ClaimJobs(ctx context.Context, workerId string, lease time.Duration, limit int) ([]model.Job, error) {
+	now := time.Now().UnixMilli()
+	staleBefore := now - lease.Milliseconds()
+
+	jobsRef := r.client.Collection(utils.EnvInstances.JOBS_COLLECTION)
+
+	// Get the pending jobs that are due to run
+	pendingSnapshots, err := jobsRef.
+		Where("status", "==", model.JobStatusPending).
+		Where("runAt", "<=", now).
+		OrderBy("runAt", firestore.Asc).
+		Limit(limit).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the running jobs that stopped sending heartbeats
+	staleSnapshots, err := jobsRef.
+		Where("status", "==", model.JobStatusRunning).
+		Where("heartbeatAt", "<", staleBefore).
+		OrderBy("heartbeatAt", firestore.Asc).
+		Limit(limit).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Iterate over the candidates and claim each one inside a transaction
+	// so two workers can never run the same job
+	jobs := []model.Job{}
+	for _, candidate := range append(pendingSnapshots, staleSnapshots...) {
+		if len(jobs) == limit {
+			break
+		}
+
+		var claimed model.Job
+		err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+			snapshot, err := tx.Get(candidate.Ref)
+			if err != nil {
+				return err
+			}
+
+			var job model.Job
+			if err := snapshot.DataTo(&job); err != nil {
+				return err
+			}
+
+			// Check if the job is still claimable
+			isPending := job.Status == model.JobStatusPending && job.RunAt <= now
+			isStale := job.Status == model.JobStatusRunning && job.HeartbeatAt < staleBefore
+			if !isPending && !isStale {
+				return nil
+			}
+
+			// Mark the job as running for the current worker
+			job.Status = model.JobStatusRunning
+			job.WorkerID = workerId
+			job.Attempts++
+			job.HeartbeatAt = now
+			job.UpdatedAt = now
+
+			if err := tx.Set(candidate.Ref, job); err != nil {
+				return err
+			}
+
+			claimed = job
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// Add the job only if the transaction claimed it
+		if claimed.ID != "" {
+			jobs = append(jobs, claimed)
+		}
+	}
+
+	return jobs, nil
+}
+
+// UpdateJobProgress retrieves the progress of a running job and updates the job heartbeat
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - jobId: The ID of the job
+//   - workerId: The ID of the worker that claimed the job
+//   - processed: The number of processed items
+//   - total: The total number of items
+//
+// Returns:
+//   - model.Job: The updated job data
+//   - error: An error that occured during the process
+func (r *jobRepository) UpdateJobProgress(ctx context.Context, jobId, workerId string, processed, total int64) (model.Job, error) {
+	now := time.Now().UnixMilli()
+
+	return r.updateClaimedJob(ctx, jobId, workerId, func(job *model.Job) error {
+		job.Processed = processed
+		if total > job.Total {
+			job.Total = total
+		}
+		job.HeartbeatAt = now
+		job.UpdatedAt = now
+		return nil
+	})
+}
+
+// CompleteJob marks a job as completed
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - jobId: The ID of the job
+//   - workerId: The ID of the worker that claimed the job
+//
+// Returns:
+//   - model.Job: The updated job data
+//   - error: An error that occured during the process
+func (r *jobRepository) CompleteJob(ctx context.Context, jobId, workerId string) (model.Job, error) {
+	now := time.Now().UnixMilli()
+
+	return r.updateClaimedJob(ctx, jobId, workerId, func(job *model.Job) error {
+		job.Status = model.JobStatusCompleted
+		job.Error = ""
+		job.UpdatedAt = now
+		job.CompletedAt = &now
+		return nil
+	})
+}
+
+// RetryJob stores the error of a failed attempt and schedules the job to run again
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - jobId: The ID of the job
+//   - workerId: The ID of the worker that claimed the job
+//   - errMessage: The error of the failed attempt
+//   - runAt: The timestamp after which the job can run again
+//
+// Returns:
+//   - model.Job: The updated job data
+//   - error: An error that occured during the process
+func (r *jobRepository) RetryJob(ctx context.Context, jobId, workerId string, errMessage string, runAt int64) (model.Job, error) {
+	now := time.Now().UnixMilli()
+
+	return r.updateClaimedJob(ctx, jobId, workerId, func(job *model.Job) error {
+		job.Status = model.JobStatusPending
+		job.Error = errMessage
+		job.WorkerID = ""
+		job.RunAt = runAt
+		job.UpdatedAt = now
+		return nil
+	})
+}
+
+// FailJob marks a job as failed after all the attempts were used
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - jobId: The ID of the job
+//   - workerId: The ID of the worker that claimed the job
+//   - errMessage: The error of the last attempt
+//
+// Returns:
+//   - model.Job: The updated job data
+//   - error: An error that occured during the process
+func (r *jobRepository) FailJob(ctx context.Context, jobId, workerId string, errMessage string) (model.Job, error) {
+	now := time.Now().UnixMilli()
+
+	return r.updateClaimedJob(ctx, jobId, workerId, func(job *model.Job) error {
+		job.Status = model.JobStatusFailed
+		job.Error = errMessage
+		job.UpdatedAt = now
+		job.CompletedAt = &now
+		return nil
+	})
+}
+
+// CancelJob requests the cancellation of a job.
+// A pending job is cancelled right away, a running job is stopped by its worker at the next progress report
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - jobId: The ID of the job
+//
+// Returns:
+//   - model.Job: The updated job data
+//   - error: An error that occured during the process
+func (r *jobRepository) CancelJob(ctx context.Context, jobId string) (model.Job, error) {
+	now := time.Now().UnixMilli()
+
+	return r.updateJob(ctx, jobId, func(job *model.Job) error {
+		switch job.Status {
+		case model.JobStatusPending:
+			job.Status = model.JobStatusCancelled
+			job.CompletedAt = &now
+		case model.JobStatusRunning:
+			job.CancelRequested = true
+		default:
+			return fmt.Errorf("job with ID %s is already %s", jobId, job.Status)
+		}
+
+		job.UpdatedAt = now
+		return nil
+	})
+}
+
+// MarkJobCancelled marks a running job as cancelled after its worker stopped it
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - jobId: The ID of the job
+//   - workerId: The ID of the worker that claimed the job
+//
+// Returns:
+//   - model.Job: The updated job data
+//   - error: An error that occured during the process
+func (r *jobRepository) MarkJobCancelled(ctx context.Context, jobId, workerId string) (model.Job, error) {
+	now := time.Now().UnixMilli()
+
+	return r.updateClaimedJob(ctx, jobId, workerId, func(job *model.Job) error {
+		job.Status = model.JobStatusCancelled
+		job.UpdatedAt = now
+		job.CompletedAt = &now
+		return nil
+	})
+}
+
+// updateClaimedJob is a private method that updates a job only while it is running under the claim of the worker.
+// A job whose lease expired can be claimed by another worker, the original worker must not change it anymore
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - jobId: The ID of the job
+//   - workerId: The ID of the worker that claimed the job
+//   - update: The function that modifies the job data
+//
+// Returns:
+//   - model.Job: The updated job data
+//   - error: An error that occured during the process
+func (r *jobRepository) updateClaimedJob(ctx context.Context, jobId, workerId string, update func(job *model.Job) error) (model.Job, error) {
+	return r.updateJob(ctx, jobId, func(job *model.Job) error {
+		if job.Status != model.JobStatusRunning || job.WorkerID != workerId {
+			return fmt.Errorf("%w: job with ID %s is %s under worker `%s`", utils.ErrJobLeaseLost, jobId, job.Status, job.WorkerID)
+		}
+
+		return update(job)
+	})
+}
+
+// updateJob is a private method that reads a job inside a transaction, applies the update function and stores the result
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - jobId: The ID of the job
+//   - update: The function that modifies the job data
+//
+// Returns:
+//   - model.Job: The updated job data
+//   - error: An error that occured during the process
+func (r *jobRepository) updateJob(ctx context.Context, jobId string, update func(job *model.Job) error) (model.Job, error) {
+	// Get the job document reference
+	docRef := r.client.Collection(utils.EnvInstances.JOBS_COLLECTION).Doc(jobId)
+
+	var updatedJob model.Job
+
+	// Run a transaction to avoid overwriting concurrent cancellation requests
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		docSnapshot, err := tx.Get(docRef)
+		if err != nil {
+			// Check if the job exists
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("job with ID %s not found", jobId)
+			}
+			return err
+		}
+
+		var job model.Job
+		if err := docSnapshot.DataTo(&job); err != nil {
+			return err
+		}
+
+		// Apply the changes to the job
+		if err := update(&job); err != nil {
+			return err
+		}
+
+		updatedJob = job
+		return tx.Set(docRef, job)
+	})
+	if err != nil {
+		return model.Job{}, err
+	}
+
+	return updatedJob, nil
+}
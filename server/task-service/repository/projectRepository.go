@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	firestore "cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+type projectRepository struct {
+	client *firestore.Client
+}
+
+// NewProjectRepository generates a read-only repository for the projects collection of the project service
+func NewProjectRepository(client *firestore.Client) interfaces.ProjectRepository {
+	return &projectRepository{client: client}
+}
+
+// GetProjectById retrieves the data from the service layer and returns the data of the project
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - projectId: The ID of the project
+//
+// Returns:
+//   - model.Project: The data of the project
+//   - error: An error that occured during the process
+func (r *projectRepository) GetProjectById(ctx context.Context, projectId string) (model.Project, error) {
+	// Get the project document snapshot
+	docSnapshot, err := r.client.Collection(utils.EnvInstances.PROJECTS_COLLECTION).Doc(projectId).Get(ctx)
+	if err != nil {
+		// Check if the project exists
+		if status.Code(err) == codes.NotFound {
+			return model.Project{}, fmt.Errorf("project with ID %s not found", projectId)
+		}
+		return model.Project{}, err
+	}
+
+	// Add the snapshot data to the project object
+	var project model.Project
+	if err = docSnapshot.DataTo(&project); err != nil {
+		return model.Project{}, err
+	}
+
+	return project, nil
+}
+
+// GetProjectsByIds retrieves the data from the service layer and returns the data of the projects.
+// The projects that were not found are skipped
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - projectIds: The list of project IDs
+//
+// Returns:
+//   - []model.Project: The list of projects
+//   - error: An error that occured during the process
+func (r *projectRepository) GetProjectsByIds(ctx context.Context, projectIds []string) ([]model.Project, error) {
+	if len(projectIds) == 0 {
+		return []model.Project{}, nil
+	}
+
+	// Get the project document references
+	docRefs := make([]*firestore.DocumentRef, len(projectIds))
+	for i, projectId := range projectIds {
+		docRefs[i] = r.client.Collection(utils.EnvInstances.PROJECTS_COLLECTION).Doc(projectId)
+	}
+
+	// Get the document snapshots with a single request
+	docSnapshots, err := r.client.GetAll(ctx, docRefs)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := []model.Project{}
+	for _, doc := range docSnapshots {
+		if !doc.Exists() {
+			continue
+		}
+
+		var project model.Project
+		if err := doc.DataTo(&project); err != nil {
+			return nil, err
+		}
+
+		projects = append(projects, project)
+	}
+
+	return projects, nil
+}
+
+// GetUserProjects retrieves the data from the service layer and returns the projects
+// where the user is the project manager or a member
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user
+//
+// Returns:
+//   - []model.Project: The list of projects
+//   - error: An error that occured during the process
+func (r *projectRepository) GetUserProjects(ctx context.Context, userId string) ([]model.Project, error) {
+	// Get the projects where the user is the manager or a member of the project
+	docs, err := r.client.Collection(utils.EnvInstances.PROJECTS_COLLECTION).WhereEntity(firestore.OrFilter{
+		Filters: []firestore.EntityFilter{
+			firestore.PropertyFilter{Path: "projectManagerId", Operator: "==", Value: userId},
+			firestore.PropertyFilter{Path: "memberIds", Operator: "array-contains", Value: userId},
+		},
+	}).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	projects := []model.Project{}
+	for _, doc := range docs {
+		var project model.Project
+		if err := doc.DataTo(&project); err != nil {
+			return nil, err
+		}
+
+		projects = append(projects, project)
+	}
+
+	return projects, nil
+}
+
+// GetProjectLabels retrieves the project ID from the service layer and returns the project label catalog
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - projectId: The ID of the project
+//
+// Returns:
+//   - []model.Label: The list of project labels
+//   - error: An error that occured during the process
+func (r *projectRepository) GetProjectLabels(ctx context.Context, projectId string) ([]model.Label, error) {
+	// Get the documents of the project label subcollection
+	docSnapshots, err := r.client.Collection(utils.EnvInstances.PROJECTS_COLLECTION).Doc(projectId).Collection(utils.EnvInstances.LABELS_SUBCOLLECTION).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert the documents to label objects
+	labels := []model.Label{}
+	for _, doc := range docSnapshots {
+		var label model.Label
+		if err := doc.DataTo(&label); err != nil {
+			return nil, err
+		}
+
+		labels = append(labels, label)
+	}
+
+	return labels, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"strings"
+)
+
+// rankDigits are the ordered digits of a board rank, the ranks are compared as plain strings
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// maxRankLength is the length after which the column is rebalanced instead of generating a longer rank
+const maxRankLength = 10
+
+// rebalanceRankLength is the length of the ranks generated when a column is rebalanced
+const rebalanceRankLength = 4
+
+// rankBetween is a private function that generates a rank strictly between two ranks without changing them.
+// The ranks are read as base 36 fractions, so there is always room for a new rank unless the ranks get too long
+//
+// Parameters:
+//   - lower: The rank of the task above, empty for the top of the column
+//   - upper: The rank of the task below, empty for the bottom of the column
+//
+// Returns:
+//   - string: The new rank
+//   - bool: False if no rank shorter than maxRankLength fits between the two ranks
+func rankBetween(lower, upper string) (string, bool) {
+	if upper != "" && lower >= upper {
+		return "", false
+	}
+
+	base := len(rankDigits)
+	unbounded := upper == ""
+
+	var rank strings.Builder
+	for i := 0; i < maxRankLength; i++ {
+		low := 0
+		if i < len(lower) {
+			low = strings.IndexByte(rankDigits, lower[i])
+		}
+
+		high := base
+		if !unbounded {
+			high = 0
+			if i < len(upper) {
+				high = strings.IndexByte(rankDigits, upper[i])
+			}
+		}
+
+		switch {
+		case high-low > 1:
+			// There is a free digit between the two ranks
+			rank.WriteByte(rankDigits[(low+high)/2])
+			return rank.String(), true
+		case high-low == 1:
+			// Keep the lower digit, every rank that follows it is below the upper rank
+			rank.WriteByte(rankDigits[low])
+			unbounded = true
+		default:
+			rank.WriteByte(rankDigits[low])
+		}
+	}
+
+	return "", false
+}
+
+// evenRanks is a private function that generates evenly spaced ranks for a whole column
+//
+// Parameters:
+//   - count: The number of tasks of the column
+//
+// Returns:
+//   - []string: The sorted ranks
+func evenRanks(count int) []string {
+	base := len(rankDigits)
+
+	space := 1
+	for range rebalanceRankLength {
+		space *= base
+	}
+
+	step := space / (count + 1)
+
+	ranks := make([]string, count)
+	for i := range ranks {
+		value := step * (i + 1)
+
+		// Write the value as a fixed length base 36 number
+		digits := make([]byte, rebalanceRankLength)
+		for j := rebalanceRankLength - 1; j >= 0; j-- {
+			digits[j] = rankDigits[value%base]
+			value /= base
+		}
+
+		// A trailing zero would leave no room right before the rank
+		ranks[i] = strings.TrimRight(string(digits), "0")
+	}
+
+	return ranks
+}
@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	firestore "cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+type recurrenceRepository struct {
+	client *firestore.Client
+}
+
+func NewRecurrenceRepository(client *firestore.Client) interfaces.RecurrenceRepository {
+	return &recurrenceRepository{client: client}
+}
+
+// CreateSeries retrieves the data from the service layer and adds a new task series into the database.
+// The last task of the series becomes its first occurrence inside the same transaction
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - series: The series object
+//
+// Returns:
+//   - model.SeriesOccurrence: The created series and its first occurrence
+//   - error: An error that occured during the process
+func (r *recurrenceRepository) CreateSeries(ctx context.Context, series model.TaskSeries) (model.SeriesOccurrence, error) {
+	seriesRef := r.client.Collection(utils.EnvInstances.SERIES_COLLECTION).Doc(series.ID)
+	taskRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(series.LastTaskID)
+
+	var occurrence model.SeriesOccurrence
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Get the task data
+		taskSnapshot, err := tx.Get(taskRef)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("task with ID %s not found", series.LastTaskID)
+			}
+			return err
+		}
+
+		var task model.Task
+		if err := taskSnapshot.DataTo(&task); err != nil {
+			return err
+		}
+
+		// A task can be part of a single series
+		if task.SeriesID != "" {
+			return fmt.Errorf("%w: the task is already part of the series `%s`", utils.ErrInvalidRecurrence, task.SeriesID)
+		}
+
+		if err := tx.Create(seriesRef, series); err != nil {
+			return err
+		}
+
+		if err := tx.Update(taskRef, []firestore.Update{
+			{Path: "seriesId", Value: series.ID},
+			{Path: "occurrence", Value: 1},
+		}); err != nil {
+			return err
+		}
+
+		task.SeriesID = series.ID
+		task.Occurrence = 1
+
+		occurrence = model.SeriesOccurrence{Series: series, Task: task, Subtasks: []model.Subtask{}}
+		return nil
+	})
+	if err != nil {
+		return model.SeriesOccurrence{}, err
+	}
+
+	return occurrence, nil
+}
+
+// GetSeriesById retrieves the data from the service layer and returns the data of the task series
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - seriesId: The ID of the series
+//
+// Returns:
+//   - model.TaskSeries: The data of the series
+//   - error: An error that occured during the process
+func (r *recurrenceRepository) GetSeriesById(ctx context.Context, seriesId string) (model.TaskSeries, error) {
+	// Get the series document snapshot
+	docSnapshot, err := r.client.Collection(utils.EnvInstances.SERIES_COLLECTION).Doc(seriesId).Get(ctx)
+	if err != nil {
+		// Check if the series exists
+		if status.Code(err) == codes.NotFound {
+			return model.TaskSeries{}, fmt.Errorf("series with ID %s not found", seriesId)
+		}
+		return model.TaskSeries{}, err
+	}
+
+	// Add the snapshot data to the series object
+	var series model.TaskSeries
+	if err = docSnapshot.DataTo(&series); err != nil {
+		return model.TaskSeries{}, err
+	}
+
+	return series, nil
+}
+
+// GetProjectSeries retrieves the task series of a project ordered by their creation date
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - projectId: The ID of the project
+//
+// Returns:
+//   - []model.TaskSeries: The list of project series
+//   - error: An error that occured during the process
+func (r *recurrenceRepository) GetProjectSeries(ctx context.Context, projectId string) ([]model.TaskSeries, error) {
+	query := r.client.Collection(utils.EnvInstances.SERIES_COLLECTION).
+		Where("projectId", "==", projectId).
+		OrderBy("createdAt", firestore.Asc)
+
+	return collectSeries(ctx, query)
+}
+
+// GetDueSeries retrieves the active series whose next occurrence has to be created
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - now: The current timestamp
+//   - limit: The max number of series to return
+//
+// Returns:
+//   - []model.TaskSeries: The list of due series
+//   - error: An error that occured during the process
+func (r *recurrenceRepository) GetDueSeries(ctx context.Context, now int64, limit int) ([]model.TaskSeries, error) {
+	query := r.client.Collection(utils.EnvInstances.SERIES_COLLECTION).
+		Where("status", "==", model.SeriesStatusActive).
+		Where("nextRunAt", "<=", now).
+		OrderBy("nextRunAt", firestore.Asc).
+		Limit(limit)
+
+	return collectSeries(ctx, query)
+}
+
+// UpdateSeries applies a change to a task series inside a transaction
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - seriesId: The ID of the series
+//   - update: The function that changes the series data
+//
+// Returns:
+//   - model.TaskSeries: The updated series data
+//   - error: An error that occured during the process
+func (r *recurrenceRepository) UpdateSeries(ctx context.Context, seriesId string, update func(series *model.TaskSeries) error) (model.TaskSeries, error) {
+	docRef := r.client.Collection(utils.EnvInstances.SERIES_COLLECTION).Doc(seriesId)
+
+	var updatedSeries model.TaskSeries
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		series, err := getSeries(tx, docRef)
+		if err != nil {
+			return err
+		}
+
+		// Apply the changes to the series
+		if err := update(&series); err != nil {
+			return err
+		}
+
+		updatedSeries = series
+		return tx.Set(docRef, series)
+	})
+	if err != nil {
+		return model.TaskSeries{}, err
+	}
+
+	return updatedSeries, nil
+}
+
+// AdvanceSeries creates the next occurrence of a task series inside a transaction, so the scheduler and
+// the completion of the previous occurrence can never create the same occurrence twice. An occurrence that
+// exceeds the work in progress limits of the project is not created and the series is advanced on a later run
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - seriesId: The ID of the series
+//   - advance: The function that updates the series and returns the next occurrence, nil when there is nothing to create
+//
+// Returns:
+//   - *model.SeriesOccurrence: The created occurrence, nil when no occurrence was created
+//   - error: An error that occured during the process
+func (r *recurrenceRepository) AdvanceSeries(ctx context.Context, seriesId string, advance func(series *model.TaskSeries) (*model.TaskDetails, error)) (*model.SeriesOccurrence, error) {
+	seriesRef := r.client.Collection(utils.EnvInstances.SERIES_COLLECTION).Doc(seriesId)
+
+	var occurrence *model.SeriesOccurrence
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		occurrence = nil
+
+		series, err := getSeries(tx, seriesRef)
+		if err != nil {
+			return err
+		}
+
+		details, err := advance(&series)
+		if err != nil {
+			return err
+		}
+
+		// Check if the occurrence and its handlers fit into the initial status column
+		if details != nil {
+			if _, err := checkWipLimits(r.client, tx, model.Task{ProjectID: details.Task.ProjectID}, details.Task.Status, details.Task.HandlerIDs, false); err != nil {
+				return err
+			}
+		}
+
+		// Store the series even when there is no occurrence to create, it may have finished
+		if err := tx.Set(seriesRef, series); err != nil {
+			return err
+		}
+
+		if details == nil {
+			return nil
+		}
+
+		// Create the task and its subtasks
+		taskRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(details.Task.ID)
+		if err := tx.Create(taskRef, details.Task); err != nil {
+			return fmt.Errorf("failed to create task: %w", err)
+		}
+
+		for _, subtask := range details.Subtasks {
+			if err := tx.Create(taskRef.Collection(utils.EnvInstances.TASKS_SUBCOLLECTION).Doc(subtask.ID), subtask); err != nil {
+				return fmt.Errorf("failed to create subtask: %w", err)
+			}
+		}
+
+		occurrence = &model.SeriesOccurrence{Series: series, Task: details.Task, Subtasks: details.Subtasks}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return occurrence, nil
+}
+
+// getSeries is a private function that reads a task series inside a transaction
+//
+// Parameters:
+//   - tx: The firestore transaction
+//   - docRef: The series document reference
+//
+// Returns:
+//   - model.TaskSeries: The data of the series
+//   - error: An error that occured during the process
+func getSeries(tx *firestore.Transaction, docRef *firestore.DocumentRef) (model.TaskSeries, error) {
+	docSnapshot, err := tx.Get(docRef)
+	if err != nil {
+		// Check if the series exists
+		if status.Code(err) == codes.NotFound {
+			return model.TaskSeries{}, fmt.Errorf("series with ID %s not found", docRef.ID)
+		}
+		return model.TaskSeries{}, err
+	}
+
+	var series model.TaskSeries
+	if err := docSnapshot.DataTo(&series); err != nil {
+		return model.TaskSeries{}, err
+	}
+
+	return series, nil
+}
+
+// collectSeries is a private function that runs a query and returns the series it matched
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - query: The series query
+//
+// Returns:
+//   - []model.TaskSeries: The list of series
+//   - error: An error that occured during the process
+func collectSeries(ctx context.Context, query firestore.Query) ([]model.TaskSeries, error) {
+	docSnapshots, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	seriesList := []model.TaskSeries{}
+	for _, doc := range docSnapshots {
+		var series model.TaskSeries
+		if err := doc.DataTo(&series); err != nil {
+			return nil, err
+		}
+
+		seriesList = append(seriesList, series)
+	}
+
+	return seriesList, nil
+}
@@ -0,0 +1,405 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	firestore "cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+type sprintRepository struct {
+	client *firestore.Client
+}
+
+func NewSprintRepository(client *firestore.Client) interfaces.SprintRepository {
+	return &sprintRepository{client: client}
+}
+
+// CreateSprint retrieves the data from the service layer and adds a new sprint into the database
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - sprint: The sprint object
+//
+// Returns:
+//   - model.Sprint: The created sprint
+//   - error: An error that occured during the process
+func (r *sprintRepository) CreateSprint(ctx context.Context, sprint model.Sprint) (model.Sprint, error) {
+	_, err := r.client.Collection(utils.EnvInstances.SPRINTS_COLLECTION).Doc(sprint.ID).Create(ctx, sprint)
+	if err != nil {
+		return model.Sprint{}, err
+	}
+
+	return sprint, nil
+}
+
+// GetSprintById retrieves the data from the service layer and returns the data of the sprint
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - sprintId: The ID of the sprint
+//
+// Returns:
+//   - model.Sprint: The data of the sprint
+//   - error: An error that occured during the process
+func (r *sprintRepository) GetSprintById(ctx context.Context, sprintId string) (model.Sprint, error) {
+	// Get the sprint document snapshot
+	docSnapshot, err := r.client.Collection(utils.EnvInstances.SPRINTS_COLLECTION).Doc(sprintId).Get(ctx)
+	if err != nil {
+		// Check if the sprint exists
+		if status.Code(err) == codes.NotFound {
+			return model.Sprint{}, fmt.Errorf("sprint with ID %s not found", sprintId)
+		}
+		return model.Sprint{}, err
+	}
+
+	// Add the snapshot data to the sprint object
+	var sprint model.Sprint
+	if err = docSnapshot.DataTo(&sprint); err != nil {
+		return model.Sprint{}, err
+	}
+
+	return sprint, nil
+}
+
+// GetProjectSprints retrieves the sprints of a project ordered by their start date
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - projectId: The ID of the project
+//
+// Returns:
+//   - []model.Sprint: The list of project sprints
+//   - error: An error that occured during the process
+func (r *sprintRepository) GetProjectSprints(ctx context.Context, projectId string) ([]model.Sprint, error) {
+	query := r.client.Collection(utils.EnvInstances.SPRINTS_COLLECTION).
+		Where("projectId", "==", projectId).
+		OrderBy("startAt", firestore.Asc)
+
+	return collectSprints(ctx, query)
+}
+
+// GetFinishedSprints retrieves the last sprints of a project that ended before a date
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - projectId: The ID of the project
+//   - before: The timestamp the sprints have to end before
+//   - limit: The max number of sprints to return
+//
+// Returns:
+//   - []model.Sprint: The list of finished sprints, the most recent first
+//   - error: An error that occured during the process
+func (r *sprintRepository) GetFinishedSprints(ctx context.Context, projectId string, before int64, limit int) ([]model.Sprint, error) {
+	query := r.client.Collection(utils.EnvInstances.SPRINTS_COLLECTION).
+		Where("projectId", "==", projectId).
+		Where("endAt", "<=", before).
+		OrderBy("endAt", firestore.Desc).
+		Limit(limit)
+
+	return collectSprints(ctx, query)
+}
+
+// GetSprintTasks retrieves the tasks planned into a sprint
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - sprintId: The ID of the sprint
+//
+// Returns:
+//   - []model.Task: The list of sprint tasks
+//   - error: An error that occured during the process
+func (r *sprintRepository) GetSprintTasks(ctx context.Context, sprintId string) ([]model.Task, error) {
+	docSnapshots, err := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Where("sprintId", "==", sprintId).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Iterate over the document snapshots
+	tasks := []model.Task{}
+	for _, doc := range docSnapshots {
+		var task model.Task
+		if err := doc.DataTo(&task); err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// GetStatusTransitions retrieves the status history of a list of tasks
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskIds: The list of task IDs
+//
+// Returns:
+//   - []model.StatusTransition: The list of status transitions, not sorted
+//   - error: An error that occured during the process
+func (r *sprintRepository) GetStatusTransitions(ctx context.Context, taskIds []string) ([]model.StatusTransition, error) {
+	transitions := []model.StatusTransition{}
+
+	// Firestore accepts at most 30 values for an `in` filter
+	for start := 0; start < len(taskIds); start += 30 {
+		chunk := taskIds[start:min(start+30, len(taskIds))]
+
+		docSnapshots, err := r.client.Collection(utils.EnvInstances.TRANSITIONS_COLLECTION).Where("taskId", "in", chunk).Documents(ctx).GetAll()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, doc := range docSnapshots {
+			var transition model.StatusTransition
+			if err := doc.DataTo(&transition); err != nil {
+				return nil, err
+			}
+
+			transitions = append(transitions, transition)
+		}
+	}
+
+	return transitions, nil
+}
+
+// UpdateSprint applies a change to a sprint inside a transaction
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - sprintId: The ID of the sprint
+//   - update: The function that changes the sprint data
+//
+// Returns:
+//   - model.Sprint: The updated sprint data
+//   - error: An error that occured during the process
+func (r *sprintRepository) UpdateSprint(ctx context.Context, sprintId string, update func(sprint *model.Sprint) error) (model.Sprint, error) {
+	docRef := r.client.Collection(utils.EnvInstances.SPRINTS_COLLECTION).Doc(sprintId)
+
+	var sprint model.Sprint
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		docSnapshot, err := tx.Get(docRef)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("sprint with ID %s not found", sprintId)
+			}
+			return err
+		}
+
+		if err := docSnapshot.DataTo(&sprint); err != nil {
+			return err
+		}
+
+		// Apply the changes to the sprint
+		if err := update(&sprint); err != nil {
+			return err
+		}
+
+		return tx.Set(docRef, sprint)
+	})
+	if err != nil {
+		return model.Sprint{}, err
+	}
+
+	return sprint, nil
+}
+
+// DeleteSprint removes a sprint from the database and moves its tasks back to the backlog
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - sprintId: The ID of the sprint
+//
+// Returns:
+//   - []model.Task: The list of tasks removed from the sprint
+//   - error: An error that occured during the process
+func (r *sprintRepository) DeleteSprint(ctx context.Context, sprintId string) ([]model.Task, error) {
+	if _, err := r.client.Collection(utils.EnvInstances.SPRINTS_COLLECTION).Doc(sprintId).Delete(ctx); err != nil {
+		return nil, err
+	}
+
+	tasks, err := r.GetSprintTasks(ctx, sprintId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate a new bulk writer that batches the updates
+	bulkWriter := r.client.BulkWriter(ctx)
+
+	writeJobs := make([]*firestore.BulkWriterJob, len(tasks))
+	for i, task := range tasks {
+		docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(task.ID)
+		job, err := bulkWriter.Update(docRef, []firestore.Update{{Path: "sprintId", Value: firestore.Delete}})
+		if err != nil {
+			bulkWriter.End()
+			return nil, err
+		}
+
+		writeJobs[i] = job
+	}
+
+	// Commit the updates and wait for them to finish
+	bulkWriter.End()
+
+	for i, job := range writeJobs {
+		if _, err := job.Results(); err != nil {
+			return nil, err
+		}
+
+		tasks[i].SprintID = ""
+	}
+
+	return tasks, nil
+}
+
+// AssignTaskSprint plans a task into a sprint of its project, an empty sprint ID moves the task back to the backlog
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - sprintId: The ID of the sprint
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - error: An error that occured during the process
+func (r *sprintRepository) AssignTaskSprint(ctx context.Context, taskId, sprintId string) (model.Task, error) {
+	taskRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
+
+	var task model.Task
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		taskSnapshot, err := tx.Get(taskRef)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("task with ID %s not found", taskId)
+			}
+			return err
+		}
+
+		if err := taskSnapshot.DataTo(&task); err != nil {
+			return err
+		}
+
+		if sprintId == "" {
+			task.SprintID = ""
+			return tx.Update(taskRef, []firestore.Update{{Path: "sprintId", Value: firestore.Delete}})
+		}
+
+		// The sprint has to be part of the task project
+		sprintSnapshot, err := tx.Get(r.client.Collection(utils.EnvInstances.SPRINTS_COLLECTION).Doc(sprintId))
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("sprint with ID %s not found", sprintId)
+			}
+			return err
+		}
+
+		var sprint model.Sprint
+		if err := sprintSnapshot.DataTo(&sprint); err != nil {
+			return err
+		}
+
+		if sprint.ProjectID != task.ProjectID {
+			return fmt.Errorf("%w: the sprint `%s` is not part of the project `%s`", utils.ErrInvalidSprint, sprintId, task.ProjectID)
+		}
+
+		task.SprintID = sprintId
+		return tx.Update(taskRef, []firestore.Update{{Path: "sprintId", Value: sprintId}})
+	})
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	return task, nil
+}
+
+// UpdateTaskEstimate sets the story points and the estimated time of a task
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - estimate: The new estimate, the nil fields are removed
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - error: An error that occured during the process
+func (r *sprintRepository) UpdateTaskEstimate(ctx context.Context, taskId string, estimate model.TaskEstimate) (model.Task, error) {
+	taskRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
+
+	var task model.Task
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		taskSnapshot, err := tx.Get(taskRef)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("task with ID %s not found", taskId)
+			}
+			return err
+		}
+
+		if err := taskSnapshot.DataTo(&task); err != nil {
+			return err
+		}
+
+		task.StoryPoints = estimate.StoryPoints
+		task.EstimatedTime = estimate.EstimatedTime
+
+		return tx.Update(taskRef, []firestore.Update{
+			{Path: "storyPoints", Value: estimateValue(estimate.StoryPoints)},
+			{Path: "estimatedTime", Value: estimateValue(estimate.EstimatedTime)},
+		})
+	})
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	return task, nil
+}
+
+// estimateValue is a private function that returns the stored value of an estimate field
+//
+// Parameters:
+//   - value: The estimate value, nil to remove it
+//
+// Returns:
+//   - any: The value or the firestore delete sentinel
+func estimateValue[T any](value *T) any {
+	if value == nil {
+		return firestore.Delete
+	}
+
+	return *value
+}
+
+// collectSprints is a private function that runs a query and converts the snapshots into sprints
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - query: The sprint query
+//
+// Returns:
+//   - []model.Sprint: The list of sprints
+//   - error: An error that occured during the process
+func collectSprints(ctx context.Context, query firestore.Query) ([]model.Sprint, error) {
+	docSnapshots, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Iterate over the document snapshots
+	sprints := []model.Sprint{}
+	for _, doc := range docSnapshots {
+		var sprint model.Sprint
+		if err := doc.DataTo(&sprint); err != nil {
+			return nil, err
+		}
+
+		sprints = append(sprints, sprint)
+	}
+
+	return sprints, nil
+}
@@ -1,22 +1,40 @@
 package repository
 
 import (
+	"cmp"
 	"context"
 	"fmt"
-	"sync"
+	"math"
+	"strings"
+	"time"
 
 	"golang.org/x/exp/slices"
 
 	firestore "cloud.google.com/go/firestore"
-	"google.golang.org/api/iterator"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	cursorlib "github.com/horatiucrisan/cursor-lib"
+
 	"github.com/horatiucrisan/task-service/interfaces"
 	"github.com/horatiucrisan/task-service/model"
 	"github.com/horatiucrisan/task-service/utils"
 )
 
+// sortableTaskFields maps the fields Firestore sorts the tasks by to the stored fields
+var sortableTaskFields = map[string]string{
+	"createdAt": "createdAt",
+	"status":    "status",
+}
+
+// memorySortedTaskFields lists the sort fields the tasks created before them do not have.
+// Firestore leaves the documents without the field out of an ordered query, so these fields are sorted in memory
+var memorySortedTaskFields = []string{"deadline", "priority", "rank"}
+
+// The max number of tasks of a board column that can be rebalanced inside a transaction,
+// the moved task and its status transition are written together with the column
+const maxRebalanceTasks = 498
+
 type taskRepository struct {
 	client *firestore.Client
 }
@@ -25,7 +43,8 @@ func NewTaskRepository(client *firestore.Client) interfaces.TaskRepository {
 	return &taskRepository{client: client}
 }
 
-// CreateTask retrieves the data from the service layer and adds a new task into the database
+// CreateTask retrieves the data from the service layer and adds a new task into the database.
+// The work in progress limits of the initial column are checked inside the same transaction
 //
 // Parameters:
 //   - ctx: Request-scoped context
@@ -35,8 +54,15 @@ func NewTaskRepository(client *firestore.Client) interfaces.TaskRepository {
 //   - model.Task: The created task data
 //   - error: An error that occured during the process
 func (r *taskRepository) CreateTask(ctx context.Context, task model.Task) (model.Task, error) {
-	// Add the new task object into the tasks collection using the ID of the task object
-	_, err := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(task.ID).Create(ctx, task)
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Check if the task and its handlers fit into the initial status column
+		if _, err := checkWipLimits(r.client, tx, model.Task{ProjectID: task.ProjectID}, task.Status, task.HandlerIDs, false); err != nil {
+			return err
+		}
+
+		// Add the new task object into the tasks collection using the ID of the task object
+		return tx.Create(r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(task.ID), task)
+	})
 	if err != nil {
 		return model.Task{}, err
 	}
@@ -124,64 +150,130 @@ func (r *taskRepository) CreateTaskResponse(ctx context.Context, taskId string,
 	return response, nil
 }
 
-// GetTasks retrieves the data from the service layer and returns a list of tasks
+// CreateTaskDetails retrieves the data from the service layer and adds a new task together with
+// its subtasks and responses into the database inside a single transaction.
+// The work in progress limits of the initial column are checked inside the same transaction
 //
 // Parameters:
 //   - ctx: Request-scoped context
-//   - projectId: The ID of the project that the tasks are part of
-//   - limit: The number of tasks to retrieve
-//   - orderBy: The order criteria
-//   - orderDirection: The direction of the order
-//   - *startAfter: The ID of the last task retrieved at the previous fetching request
+//   - details: The task object with the counters set, its subtasks and its responses
 //
 // Returns:
-//   - []model.Task: The list of retrieved tasks
+//   - model.TaskDetails: The created task, subtasks and responses
 //   - error: An error that occured during the process
-func (r *taskRepository) GetTasks(ctx context.Context, projectId string, limit int, orderBy string, orderDirection string, startAfter string) ([]model.Task, error) {
-	// Get the tasks that are part of the project
-	query := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Where("projectId", "==", projectId)
+func (r *taskRepository) CreateTaskDetails(ctx context.Context, details model.TaskDetails) (model.TaskDetails, error) {
+	taskRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(details.Task.ID)
 
-	// Check the order direction and query based on it
-	if orderDirection == "asc" {
-		query = query.OrderBy(orderBy, firestore.Asc)
-	} else {
-		query = query.OrderBy(orderBy, firestore.Desc)
-	}
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Check if the task and its handlers fit into the initial status column
+		if _, err := checkWipLimits(r.client, tx, model.Task{ProjectID: details.Task.ProjectID}, details.Task.Status, details.Task.HandlerIDs, false); err != nil {
+			return err
+		}
 
-	//Check if the ID of the last task was sent as a parameter
-	if startAfter != "" && len(startAfter) == 0 && startAfter != "null" {
-		lastDocSnapshot, err := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(startAfter).Get(ctx)
-		if err != nil {
-			return nil, err
+		// Create the task
+		if err := tx.Create(taskRef, details.Task); err != nil {
+			return fmt.Errorf("failed to create task: %w", err)
+		}
+
+		// Create the subtasks of the task
+		for _, subtask := range details.Subtasks {
+			if err := tx.Create(taskRef.Collection(utils.EnvInstances.TASKS_SUBCOLLECTION).Doc(subtask.ID), subtask); err != nil {
+				return fmt.Errorf("failed to create subtask: %w", err)
+			}
+		}
+
+		// Create the responses of the task
+		for _, response := range details.Responses {
+			if err := tx.Create(taskRef.Collection(utils.EnvInstances.RESPONSES_COLLECTION).Doc(response.ID), response); err != nil {
+				return fmt.Errorf("failed to create response: %w", err)
+			}
 		}
 
-		query = query.StartAfter(lastDocSnapshot.Data()[orderBy])
+		return nil
+	})
+	if err != nil {
+		return model.TaskDetails{}, err
 	}
 
-	// Limit the number of tasks to retrieve
-	query = query.Limit(limit)
+	return details, nil
+}
 
-	// Get the tasks snapshots
-	docs, err := query.Documents(ctx).GetAll()
+// GetTasks retrieves the data from the service layer and returns a list of tasks.
+// The filters Firestore can serve with the composite indexes from `firestore.indexes.json`
+// are added to the query, the rest of them are applied in memory. If Firestore rejects the
+// query because of a missing index, the tasks are filtered in memory only
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskQuery: The filtering, ordering and pagination criteria
+//
+// Returns:
+//   - []model.Task: The list of retrieved tasks
+//   - string: The cursor of the next page, empty if there are no more tasks
+//   - error: An error that occured during the process
+func (r *taskRepository) GetTasks(ctx context.Context, taskQuery model.TaskQuery) ([]model.Task, string, error) {
+	// Firestore leaves the tasks without the ordering field out of the query, so these tasks are ordered in memory
+	if slices.Contains(memorySortedTaskFields, taskQuery.OrderBy) {
+		return r.getSortedTasks(ctx, taskQuery)
+	}
+
+	// Get the tasks that are part of the project
+	query := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Where("projectId", "==", taskQuery.ProjectID)
+
+	// Add the filters that have a composite index to the query
+	filteredQuery := query
+	if len(taskQuery.Statuses) == 1 {
+		filteredQuery = filteredQuery.Where("status", "==", taskQuery.Statuses[0])
+	} else if len(taskQuery.Statuses) > 1 {
+		filteredQuery = filteredQuery.Where("status", "in", taskQuery.Statuses)
+	} else if len(taskQuery.Priorities) > 0 {
+		// Only one disjunction is sent to Firestore, the priorities
+		// are filtered in memory when the statuses are filtered
+		filteredQuery = filteredQuery.Where("priority", "in", taskQuery.Priorities)
+	}
+	if taskQuery.AuthorID != "" {
+		filteredQuery = filteredQuery.Where("authorId", "==", taskQuery.AuthorID)
+	}
+	if taskQuery.HandlerID != "" {
+		filteredQuery = filteredQuery.Where("handlerIds", "array-contains", taskQuery.HandlerID)
+	} else if len(taskQuery.Labels) == 1 {
+		// A query supports a single array filter, so the labels
+		// are filtered in memory when the handler is filtered
+		filteredQuery = filteredQuery.Where("labels", "array-contains", taskQuery.Labels[0])
+	} else if len(taskQuery.Labels) > 1 && len(taskQuery.Statuses) == 0 && len(taskQuery.Priorities) == 0 {
+		filteredQuery = filteredQuery.Where("labels", "array-contains-any", taskQuery.Labels)
+	}
+
+	// Add the range filter only for the ordering field, since Firestore
+	// orders the documents by the field that has the inequality first
+	if taskQuery.OrderBy == "createdAt" {
+		filteredQuery = addRangeFilter(filteredQuery, "createdAt", taskQuery.CreatedFrom, taskQuery.CreatedTo)
+	}
+
+	// Order the query and move it after the cursor of the previous page
+	filteredQuery, err := utils.CursorSigner.OrderPageQuery(filteredQuery, sortableTaskFields[taskQuery.OrderBy], cursorlib.QueryDirection(taskQuery.OrderDirection), taskQuery.Cursor)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	// Iterate over the snapshots
-	tasks := []model.Task{}
-	for _, doc := range docs {
-		var task model.Task
+	// Get the tasks using the filtered query
+	tasks, nextCursor, err := collectTasks(ctx, filteredQuery, taskQuery)
+	if err == nil {
+		return tasks, nextCursor, nil
+	}
 
-		// Add the data of each snapshot to the tasks list
-		err := doc.DataTo(&task)
-		if err != nil {
-			return nil, err
-		}
+	// Fallback to filtering the tasks of the project in memory when
+	// the combination of filters has no composite index
+	if status.Code(err) != codes.FailedPrecondition {
+		return nil, "", err
+	}
 
-		tasks = append(tasks, task)
+	orderedQuery, err := utils.CursorSigner.OrderPageQuery(query, sortableTaskFields[taskQuery.OrderBy], cursorlib.QueryDirection(taskQuery.OrderDirection), taskQuery.Cursor)
+	if err != nil {
+		return nil, "", err
 	}
 
-	return tasks, nil
+	return collectTasks(ctx, orderedQuery, taskQuery)
 }
 
 // GetTaskById retrieves the data from the service layer and returns the data of the task
@@ -221,18 +313,41 @@ func (r *taskRepository) GetTaskById(ctx context.Context, taskId string) (model.
 // Parameteres:
 //   - ctx: Request-scoped context
 //   - taskId: The ID of the task
+//   - limit: The max number of subtasks to retrieve, 0 to retrieve all of them
+//   - cursor: The cursor returned with the previous page
 //
 // Returns:
 //   - []model.Subtask: The list of task subtasks
+//   - string: The cursor of the next page, empty if there are no more subtasks
 //   - error: An error that occured during the process
-func (r *taskRepository) GetSubtasks(ctx context.Context, taskId string) ([]model.Subtask, error) {
+func (r *taskRepository) GetSubtasks(ctx context.Context, taskId string, limit int, cursor string) ([]model.Subtask, string, error) {
 	// Get the references of the subtasks documents
 	docRefs := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId).Collection(utils.EnvInstances.TASKS_SUBCOLLECTION)
 
+	// Order the subtasks by their creation date
+	query, err := utils.CursorSigner.OrderPageQuery(docRefs.Query, "createdAt", firestore.Asc, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Get one more subtask to check if there is a next page
+	if limit > 0 {
+		query = query.Limit(limit + 1)
+	}
+
 	// Get the document snapshots
-	docSnapshots, err := docRefs.Documents(ctx).GetAll()
+	docSnapshots, err := query.Documents(ctx).GetAll()
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	// Generate the cursor of the next page
+	nextCursor := ""
+	if limit > 0 && len(docSnapshots) > limit {
+		docSnapshots = docSnapshots[:limit]
+		if nextCursor, err = utils.CursorSigner.NextPageCursor(docSnapshots[limit-1], "createdAt"); err != nil {
+			return nil, "", err
+		}
 	}
 
 	// Iterate over the document snapshots
@@ -243,13 +358,13 @@ func (r *taskRepository) GetSubtasks(ctx context.Context, taskId string) ([]mode
 		// Add the snapshot data of each document to the subtask object
 		err := doc.DataTo(&subtask)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
 		subtasks = append(subtasks, subtask)
 	}
 
-	return subtasks, nil
+	return subtasks, nextCursor, nil
 }
 
 // GetSubtaskById retrieves the data from the service layer and returns the data of the subtask
@@ -291,18 +406,41 @@ func (r *taskRepository) GetSubtaskById(ctx context.Context, taskId, subtaskId s
 // Parameters:
 //   - ctx: Request-scoped context
 //   - taskId: The ID of the task for which to retrieve the responses
+//   - limit: The max number of responses to retrieve, 0 to retrieve all of them
+//   - cursor: The cursor returned with the previous page
 //
 // Returns:
 //   - []model.Response: The list of task responses
+//   - string: The cursor of the next page, empty if there are no more responses
 //   - error: An error that occured during the process
-func (r *taskRepository) GetResponses(ctx context.Context, taskId string) ([]model.Response, error) {
+func (r *taskRepository) GetResponses(ctx context.Context, taskId string, limit int, cursor string) ([]model.Response, string, error) {
 	// Get the document references for each response
 	docRefs := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId).Collection(utils.EnvInstances.RESPONSES_COLLECTION)
 
+	// Order the responses by the time they were sent
+	query, err := utils.CursorSigner.OrderPageQuery(docRefs.Query, "timestamp", firestore.Asc, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Get one more response to check if there is a next page
+	if limit > 0 {
+		query = query.Limit(limit + 1)
+	}
+
 	// Get the snapshot for each document
-	docSnapshots, err := docRefs.Documents(ctx).GetAll()
+	docSnapshots, err := query.Documents(ctx).GetAll()
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	// Generate the cursor of the next page
+	nextCursor := ""
+	if limit > 0 && len(docSnapshots) > limit {
+		docSnapshots = docSnapshots[:limit]
+		if nextCursor, err = utils.CursorSigner.NextPageCursor(docSnapshots[limit-1], "timestamp"); err != nil {
+			return nil, "", err
+		}
 	}
 
 	// Iterate over each document snapshot
@@ -313,13 +451,13 @@ func (r *taskRepository) GetResponses(ctx context.Context, taskId string) ([]mod
 		// Add the data of each snapshot to a reponse document
 		err := doc.DataTo(&response)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
 		responses = append(responses, response)
 	}
 
-	return responses, nil
+	return responses, nextCursor, nil
 }
 
 // GetResponseById retrieves the data from the service layer and returns the data of the response
@@ -356,150 +494,227 @@ func (r *taskRepository) GetResponseById(ctx context.Context, taskId, responseId
 	return response, nil
 }
 
-// UpdateTaskDescription retrieves the data from the service layer and updates the description of the task
+// GetHandlerTasks retrieves the data from the service layer and returns the tasks of all the projects
+// where the user is a handler, ordered by their deadline
 //
 // Parameters:
 //   - ctx: Request-scoped context
-//   - taskId: The ID of the task to update
-//   - description: The new task description
+//   - taskQuery: The handler and the filtering criteria
 //
 // Returns:
-//   - model.Task: The updated task data
+//   - []model.Task: The list of tasks
 //   - error: An error that occured during the process
-func (r *taskRepository) UpdateTaskDescription(ctx context.Context, taskId string, description string) (model.Task, error) {
-	// Get the document reference
-	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
+func (r *taskRepository) GetHandlerTasks(ctx context.Context, taskQuery model.TaskQuery) ([]model.Task, error) {
+	// Get the tasks where the user is a handler inside the due window
+	query := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).
+		Where("handlerIds", "array-contains", taskQuery.HandlerID)
+	query = addRangeFilter(query, "deadline", taskQuery.DeadlineFrom, taskQuery.DeadlineTo).
+		OrderBy("deadline", firestore.Asc)
 
-	// Get the document snapshot and check if the document exists
-	docSnapshot, err := docRef.Get(ctx)
+	// Get the tasks snapshots
+	docs, err := query.Documents(ctx).GetAll()
 	if err != nil {
-		if status.Code(err) == codes.NotFound {
-			return model.Task{}, fmt.Errorf("task with ID %s not found", taskId)
-		}
-		return model.Task{}, err
+		return nil, err
 	}
 
-	// Add the snapshot data to the task object
-	var task model.Task
-	docSnapshot.DataTo(&task)
-
-	// Update the description
-	task.Description = description
+	// Iterate over the snapshots and keep the tasks that match the rest of the filters
+	tasks := []model.Task{}
+	for _, doc := range docs {
+		var task model.Task
+		if err := doc.DataTo(&task); err != nil {
+			return nil, err
+		}
 
-	// Update the task data in the database
-	_, err = docRef.Set(ctx, task)
-	if err != nil {
-		return model.Task{}, err
+		if matchesTaskQuery(task, taskQuery) {
+			tasks = append(tasks, task)
+		}
 	}
 
-	return task, nil
+	return tasks, nil
 }
 
-// UpdateTaskStatus retrieves the data from the service layer and updates the status of the task
+// GetHandlerSubtasks retrieves the data from the service layer and returns the subtasks of all the projects
+// where the user is the handler, together with their parent tasks.
+// The filters of the query are applied to the parent tasks, since the subtasks have no status or deadline
 //
 // Parameters:
 //   - ctx: Request-scoped context
-//   - taskId: The ID of the task
-//   - taskStatus: The new task status
+//   - taskQuery: The handler and the filtering criteria
 //
 // Returns:
-//   - model.Task: The updated task data
+//   - []model.AssignedSubtask: The list of subtasks with their parent tasks
 //   - error: An error that occured during the process
-func (r *taskRepository) UpdateTaskStatus(ctx context.Context, taskId string, taskStatus string) (model.Task, error) {
-	// Get the task document reference
-	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
+func (r *taskRepository) GetHandlerSubtasks(ctx context.Context, taskQuery model.TaskQuery) ([]model.AssignedSubtask, error) {
+	// Get the subtasks of every task where the user is the handler
+	docs, err := r.client.CollectionGroup(utils.EnvInstances.TASKS_SUBCOLLECTION).
+		Where("handlerId", "==", taskQuery.HandlerID).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
 
-	// Get the document snapshot
-	docSnapshot, err := docRef.Get(ctx)
+	subtasks := make([]model.Subtask, 0, len(docs))
+	taskIds := []string{}
+	for _, doc := range docs {
+		var subtask model.Subtask
+		if err := doc.DataTo(&subtask); err != nil {
+			return nil, err
+		}
 
-	// Check if the document exists
-	if err != nil {
-		if status.Code(err) == codes.NotFound {
-			return model.Task{}, fmt.Errorf("task with ID %s not found", taskId)
+		subtasks = append(subtasks, subtask)
+		if !slices.Contains(taskIds, subtask.TaskID) {
+			taskIds = append(taskIds, subtask.TaskID)
 		}
 	}
 
-	// Add the document snapshot data to the task object
-	var task model.Task
-	err = docSnapshot.DataTo(&task)
+	if len(taskIds) == 0 {
+		return []model.AssignedSubtask{}, nil
+	}
+
+	// Get the parent tasks with a single request
+	parentTasks, _, err := r.getBulkTasks(ctx, taskIds)
 	if err != nil {
-		return model.Task{}, err
+		return nil, err
 	}
 
-	// Update the task status
-	task.Status = taskStatus
+	tasksById := make(map[string]model.Task, len(taskIds))
+	for _, task := range parentTasks {
+		if task != nil {
+			tasksById[task.ID] = *task
+		}
+	}
 
-	// Update the task inside the database
-	_, err = docRef.Set(ctx, task)
-	if err != nil {
-		return model.Task{}, err
+	// The user does not have to be a handler of the parent task
+	parentQuery := taskQuery
+	parentQuery.HandlerID = ""
+
+	// Keep the subtasks whose parent task matches the filters
+	assignedSubtasks := []model.AssignedSubtask{}
+	for _, subtask := range subtasks {
+		task, ok := tasksById[subtask.TaskID]
+		if !ok || !matchesTaskQuery(task, parentQuery) {
+			continue
+		}
+
+		assignedSubtasks = append(assignedSubtasks, model.AssignedSubtask{Subtask: subtask, Task: task})
 	}
 
-	return task, nil
+	return assignedSubtasks, nil
 }
 
-// AddTaskHandlers retrieves data from the service layer and adds new handlers to a project task
+// GetProjectTasks retrieves the project ID from the service layer and returns all the tasks of the project
 //
 // Parameters:
 //   - ctx: Request-scoped context
-//   - taskId: The ID of the task to update
-//   - handlerIds: The list of new handler IDs
+//   - projectId: The ID of the project
 //
 // Returns:
-//   - model.Task: The updated task data
+//   - []model.Task: The list of project tasks
 //   - error: An error that occured during the process
-func (r *taskRepository) AddTaskHandlers(ctx context.Context, taskId string, handlerIds []string) (model.Task, error) {
-	// Get the task document reference
-	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
-
-	// Get the document snpashot
-	docSnapshot, err := docRef.Get(ctx)
-
-	// Check if the document exists
+func (r *taskRepository) GetProjectTasks(ctx context.Context, projectId string) ([]model.Task, error) {
+	// Get the task documents of the project
+	docSnapshots, err := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Where("projectId", "==", projectId).Documents(ctx).GetAll()
 	if err != nil {
-		if status.Code(err) == codes.NotFound {
-			return model.Task{}, fmt.Errorf("task with ID %s not found", taskId)
+		return nil, err
+	}
+
+	// Convert the documents to task objects
+	tasks := []model.Task{}
+	for _, doc := range docSnapshots {
+		var task model.Task
+		if err := doc.DataTo(&task); err != nil {
+			return nil, err
 		}
-		return model.Task{}, err
+
+		tasks = append(tasks, task)
 	}
 
-	// Add the data from the snapshot to the task object
-	var task model.Task
-	err = docSnapshot.DataTo(&task)
+	return tasks, nil
+}
+
+// GetTasksByIds retrieves the list of task IDs from the service layer and returns the data of the tasks.
+// The tasks that were not found are skipped
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskIds: The list of task IDs
+//
+// Returns:
+//   - []model.Task: The list of tasks
+//   - error: An error that occured during the process
+func (r *taskRepository) GetTasksByIds(ctx context.Context, taskIds []string) ([]model.Task, error) {
+	if len(taskIds) == 0 {
+		return []model.Task{}, nil
+	}
+
+	// Get the tasks with a single request
+	bulkTasks, _, err := r.getBulkTasks(ctx, taskIds)
 	if err != nil {
-		return model.Task{}, err
+		return nil, err
 	}
 
-	// Add the new handlers to the task handlers
-	task.HandlerIDs = append(task.HandlerIDs, handlerIds...)
+	tasks := []model.Task{}
+	for _, task := range bulkTasks {
+		if task != nil {
+			tasks = append(tasks, *task)
+		}
+	}
 
-	// Update the task data into the database
-	_, err = docRef.Set(ctx, task)
-	if err != nil {
-		return model.Task{}, err
+	return tasks, nil
+}
+
+// GetTaskChildren retrieves the list of parent task IDs from the service layer and returns their child tasks
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - parentIds: The list of parent task IDs
+//
+// Returns:
+//   - []model.Task: The list of child tasks ordered by their creation date
+//   - error: An error that occured during the process
+func (r *taskRepository) GetTaskChildren(ctx context.Context, parentIds []string) ([]model.Task, error) {
+	children := []model.Task{}
+
+	// Firestore accepts at most 30 values for an in filter
+	for start := 0; start < len(parentIds); start += 30 {
+		chunk := parentIds[start:min(start+30, len(parentIds))]
+
+		docSnapshots, err := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Where("parentTaskId", "in", chunk).Documents(ctx).GetAll()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, doc := range docSnapshots {
+			var child model.Task
+			if err := doc.DataTo(&child); err != nil {
+				return nil, err
+			}
+
+			children = append(children, child)
+		}
 	}
 
-	return task, nil
+	slices.SortFunc(children, func(a, b model.Task) int { return cmp.Compare(a.CreatedAt, b.CreatedAt) })
+
+	return children, nil
 }
 
-// RemoveTaskHandlers retrieves the data from the service layer and removes handlers from a task handler list
+// UpdateTaskDescription retrieves the data from the service layer and updates the description of the task
 //
 // Parameters:
 //   - ctx: Request-scoped context
 //   - taskId: The ID of the task to update
-//   - handlerIds: The list of handler IDs to remove
+//   - description: The new task description
 //
 // Returns:
 //   - model.Task: The updated task data
 //   - error: An error that occured during the process
-func (r *taskRepository) RemoveTaskHandlers(ctx context.Context, taskId string, handlerIds []string) (model.Task, error) {
-	// Get the task document reference
+func (r *taskRepository) UpdateTaskDescription(ctx context.Context, taskId string, description string) (model.Task, error) {
+	// Get the document reference
 	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
 
-	// Get the document snapshot
+	// Get the document snapshot and check if the document exists
 	docSnapshot, err := docRef.Get(ctx)
-
-	// Check if the document exists
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
 			return model.Task{}, fmt.Errorf("task with ID %s not found", taskId)
@@ -509,23 +724,12 @@ func (r *taskRepository) RemoveTaskHandlers(ctx context.Context, taskId string,
 
 	// Add the snapshot data to the task object
 	var task model.Task
-	err = docSnapshot.DataTo(&task)
-	if err != nil {
-		return model.Task{}, err
-	}
-
-	// Iterate over the task handlers and check if the list contains the handlers to remove and remove them
-	var filteredHnalderIds []string
-	for _, taskHandler := range task.HandlerIDs {
-		if slices.Contains(handlerIds, taskHandler) == false {
-			filteredHnalderIds = append(filteredHnalderIds, taskHandler)
-		}
-	}
+	docSnapshot.DataTo(&task)
 
-	// Update the task handlers list
-	task.HandlerIDs = filteredHnalderIds
+	// Update the description
+	task.Description = description
 
-	// Update the task data into the database
+	// Update the task data in the database
 	_, err = docRef.Set(ctx, task)
 	if err != nil {
 		return model.Task{}, err
@@ -534,511 +738,2862 @@ func (r *taskRepository) RemoveTaskHandlers(ctx context.Context, taskId string,
 	return task, nil
 }
 
-// UpdateSubtaskDescription retrieves the data from the service layer and uptates the description of the subtask
+// UpdateTaskStatus retrieves the data from the service layer and updates the status of the task.
+// The work in progress limits of the project are checked inside the same transaction
 //
 // Parameters:
 //   - ctx: Request-scoped context
-//   - taskId: The ID of the task the subtask is part of
-//   - subtaskId: The subtask ID
-//   - description: The new subtask description
+//   - taskId: The ID of the task
+//   - taskStatus: The new task status
+//   - override: Whether the exceeded work in progress limits are ignored
 //
 // Returns:
-//   - model.Subtask: The updated subtask data
+//   - model.Task: The updated task data
+//   - []string: The work in progress limits that were overridden
 //   - error: An error that occured during the process
-func (r *taskRepository) UpdateSubtaskDescription(ctx context.Context, taskId string, subtaskId string, description string) (model.Subtask, error) {
-	// Get the subtask document reference
-	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId).Collection(utils.EnvInstances.TASKS_SUBCOLLECTION).Doc(subtaskId)
+func (r *taskRepository) UpdateTaskStatus(ctx context.Context, taskId string, taskStatus string, override bool) (model.Task, []string, error) {
+	// Get the task document reference
+	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
 
-	// Get the document snapshot
-	docSnapshot, err := docRef.Get(ctx)
+	var task model.Task
+	var exceeded []string
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Get the document snapshot
+		docSnapshot, err := tx.Get(docRef)
 
-	// Check if the document exists
-	if err != nil {
-		if status.Code(err) == codes.NotFound {
-			return model.Subtask{}, fmt.Errorf("subtask with the ID %s for the task with the ID %s not found", subtaskId, taskId)
+		// Check if the document exists
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("task with ID %s not found", taskId)
+			}
+			return err
 		}
-		return model.Subtask{}, err
-	}
 
-	// Add the snapshot data to the subtask object
-	var subtask model.Subtask
-	err = docSnapshot.DataTo(&subtask)
-	if err != nil {
-		return model.Subtask{}, err
-	}
+		// Add the document snapshot data to the task object
+		if err := docSnapshot.DataTo(&task); err != nil {
+			return err
+		}
 
-	// Update the subtask description
-	subtask.Description = description
+		exceeded = nil
+		if task.Status != taskStatus {
+			// The reviewed tasks enter and leave the review only through the review workflow
+			if err := checkReviewTransition(task, taskStatus); err != nil {
+				return err
+			}
 
-	// Update the subtask data into the database
-	_, err = docRef.Set(ctx, subtask)
+			// Check if the task and its handlers fit into the new status column
+			if exceeded, err = checkWipLimits(r.client, tx, task, taskStatus, task.HandlerIDs, override); err != nil {
+				return err
+			}
+
+			// Record the status change in the task history
+			transition := r.newStatusTransition(task, taskStatus)
+			if err := tx.Create(r.client.Collection(utils.EnvInstances.TRANSITIONS_COLLECTION).Doc(transition.ID), transition); err != nil {
+				return err
+			}
+		}
+
+		// Update the task status
+		task.Status = taskStatus
+
+		// Update the task inside the database
+		return tx.Set(docRef, task)
+	})
 	if err != nil {
-		return model.Subtask{}, err
+		return model.Task{}, nil, err
 	}
 
-	return subtask, nil
+	return task, exceeded, nil
 }
 
-// UpdateSubtaskHandler retrieves the data from the service layer and updates the handler of the subtask
+// UpdateTaskRank retrieves the data from the service layer and moves a task between two neighbors of a board column.
+// The status and the rank are changed inside a single transaction. When there is no room left between the
+// neighbors, the whole column is rebalanced inside the same transaction
 //
 // Parameters:
-//   - ctx: Reqeust-scoped context
-//   - taskId: The ID of the task the subtask is part of
-//   - subtaskId: The subtask ID
-//   - handlerId: The new subtask handler ID
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - taskStatus: The status of the target column
+//   - afterId: The ID of the task above the new position, empty for the top of the column
+//   - beforeId: The ID of the task below the new position, empty for the bottom of the column
+//   - override: Whether the exceeded work in progress limits are ignored
 //
 // Returns:
-//   - model.Subtask: The updated subtask data
+//   - model.Task: The updated task data
+//   - []string: The work in progress limits that were overridden
 //   - error: An error that occured during the process
-func (r *taskRepository) UpdateSubtaskHandler(ctx context.Context, taskId string, subtaskId string, handlerId string) (model.Subtask, error) {
-	// Get the subtask document reference
-	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId).Collection(utils.EnvInstances.TASKS_SUBCOLLECTION).Doc(subtaskId)
+func (r *taskRepository) UpdateTaskRank(ctx context.Context, taskId, taskStatus, afterId, beforeId string, override bool) (model.Task, []string, error) {
+	tasksRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION)
 
-	// Get the document snapshot
-	docSnapshot, err := docRef.Get(ctx)
+	var task model.Task
+	var exceeded []string
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Get the moved task and its new neighbors
+		neighbors := map[string]*model.Task{}
+		for _, id := range []string{taskId, afterId, beforeId} {
+			if id == "" {
+				continue
+			}
 
-	// Check if the document exists
-	if err != nil {
-		if status.Code(err) == codes.NotFound {
-			return model.Subtask{}, fmt.Errorf("subtask with the ID %s for the task with the ID %s not found", subtaskId, taskId)
+			docSnapshot, err := tx.Get(tasksRef.Doc(id))
+			if err != nil {
+				if status.Code(err) == codes.NotFound {
+					return fmt.Errorf("task with ID %s not found", id)
+				}
+				return err
+			}
+
+			var neighbor model.Task
+			if err := docSnapshot.DataTo(&neighbor); err != nil {
+				return err
+			}
+
+			neighbors[id] = &neighbor
 		}
-		return model.Subtask{}, err
-	}
 
-	// Add the snapshot data to the subtask object
-	var subtask model.Subtask
-	err = docSnapshot.DataTo(&subtask)
+		task = *neighbors[taskId]
+
+		// The neighbors have to be part of the target column
+		var lower, upper string
+		for _, id := range []string{afterId, beforeId} {
+			if id == "" {
+				continue
+			}
+
+			neighbor := neighbors[id]
+			if id == taskId || neighbor.ProjectID != task.ProjectID || neighbor.Status != taskStatus {
+				return fmt.Errorf("%w: task `%s` is not part of the `%s` column", utils.ErrInvalidRank, id, taskStatus)
+			}
+		}
+
+		if afterId != "" {
+			lower = neighbors[afterId].Rank
+		}
+
+		if beforeId != "" {
+			upper = neighbors[beforeId].Rank
+		}
+
+		if lower != "" && upper != "" && lower >= upper {
+			return fmt.Errorf("%w: task `%s` is not above task `%s`", utils.ErrInvalidRank, afterId, beforeId)
+		}
+
+		// Check if the task and its handlers fit into the target column
+		exceeded = nil
+		if task.Status != taskStatus {
+			if err := checkReviewTransition(task, taskStatus); err != nil {
+				return err
+			}
+
+			var err error
+			if exceeded, err = checkWipLimits(r.client, tx, task, taskStatus, task.HandlerIDs, override); err != nil {
+				return err
+			}
+		}
+
+		// An unranked neighbor below the position leaves no room, so the column is rebalanced
+		rank, ok := "", false
+		if beforeId == "" || upper != "" {
+			rank, ok = rankBetween(lower, upper)
+		}
+
+		var rebalanced map[string]string
+		if !ok {
+			var err error
+			if rebalanced, err = r.rebalanceColumn(tx, task, taskStatus, afterId); err != nil {
+				return err
+			}
+
+			rank = rebalanced[taskId]
+		}
+
+		// Record the status change in the task history
+		if task.Status != taskStatus {
+			transition := r.newStatusTransition(task, taskStatus)
+			if err := tx.Create(r.client.Collection(utils.EnvInstances.TRANSITIONS_COLLECTION).Doc(transition.ID), transition); err != nil {
+				return err
+			}
+		}
+
+		for id, columnRank := range rebalanced {
+			if id == taskId {
+				continue
+			}
+
+			if err := tx.Update(tasksRef.Doc(id), []firestore.Update{{Path: "rank", Value: columnRank}}); err != nil {
+				return err
+			}
+		}
+
+		task.Status = taskStatus
+		task.Rank = rank
+
+		return tx.Set(tasksRef.Doc(taskId), task)
+	})
 	if err != nil {
-		return model.Subtask{}, err
+		return model.Task{}, nil, err
 	}
 
-	// Update the subtask handler
-	subtask.HandlerID = handlerId
+	return task, exceeded, nil
+}
 
-	// Update the subtask data into the database
-	_, err = docRef.Set(ctx, subtask)
+// SetTaskReviewers retrieves the data from the service layer and replaces the reviewers of a task
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - reviewerIds: The IDs of the new reviewers, empty to remove the review from the task workflow
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - error: An error that occured during the process
+func (r *taskRepository) SetTaskReviewers(ctx context.Context, taskId string, reviewerIds []string) (model.Task, error) {
+	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
+
+	var task model.Task
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		docSnapshot, err := tx.Get(docRef)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("task with ID %s not found", taskId)
+			}
+			return err
+		}
+
+		task = model.Task{}
+		if err := docSnapshot.DataTo(&task); err != nil {
+			return err
+		}
+
+		// A task waiting for a review needs someone to decide it
+		if task.Status == model.TaskStatusInReview && len(reviewerIds) == 0 {
+			return fmt.Errorf("%w: the task `%s` is in review and needs at least one reviewer", utils.ErrInvalidReview, taskId)
+		}
+
+		task.ReviewerIDs = reviewerIds
+
+		return tx.Set(docRef, task)
+	})
 	if err != nil {
-		return model.Subtask{}, err
+		return model.Task{}, err
 	}
 
-	return subtask, nil
+	return task, nil
 }
 
-// UpdateSubtaskStatus retrieves the data from the service layer and updates the status of the subtask
+// RequestTaskReview retrieves the data from the service layer and moves a task into the review.
+// The work in progress limits of the review column are checked inside the same transaction
 //
 // Parameters:
 //   - ctx: Request-scoped context
-//   - taskId: The ID of the task that the subtask is part of
-//   - subtaskId: The subtask ID
-//   - subtaskStatus: The new subtask status
+//   - taskId: The ID of the task
+//   - override: Whether the exceeded work in progress limits are ignored
 //
 // Returns:
-//   - model.Subtask: The updated subtask data
+//   - model.Task: The updated task data
+//   - []string: The work in progress limits that were overridden
 //   - error: An error that occured during the process
-func (r *taskRepository) UpdateSubtaskStatus(ctx context.Context, taskId string, subtaskId string, subtaskStatus bool) (model.Subtask, error) {
-	// Get the task document reference
-	taskRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
+func (r *taskRepository) RequestTaskReview(ctx context.Context, taskId string, override bool) (model.Task, []string, error) {
+	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
 
-	// Get the subtask document reference
-	subtaskRef := taskRef.Collection(utils.EnvInstances.TASKS_SUBCOLLECTION).Doc(subtaskId)
+	var task model.Task
+	var exceeded []string
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		docSnapshot, err := tx.Get(docRef)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("task with ID %s not found", taskId)
+			}
+			return err
+		}
 
-	var updatedSubtask model.Subtask
+		task = model.Task{}
+		if err := docSnapshot.DataTo(&task); err != nil {
+			return err
+		}
 
-	// Run a transaction to avoid race conditions
+		if len(task.ReviewerIDs) == 0 {
+			return fmt.Errorf("%w: the task `%s` has no reviewers", utils.ErrInvalidReview, taskId)
+		}
+
+		if task.Status == model.TaskStatusInReview || task.Status == model.TaskStatusCompleted {
+			return fmt.Errorf("%w: the task `%s` is already `%s`", utils.ErrInvalidReview, taskId, task.Status)
+		}
+
+		// Check if the task and its handlers fit into the review column
+		if exceeded, err = checkWipLimits(r.client, tx, task, model.TaskStatusInReview, task.HandlerIDs, override); err != nil {
+			return err
+		}
+
+		// Record the status change in the task history
+		transition := r.newStatusTransition(task, model.TaskStatusInReview)
+		if err := tx.Create(r.client.Collection(utils.EnvInstances.TRANSITIONS_COLLECTION).Doc(transition.ID), transition); err != nil {
+			return err
+		}
+
+		// A new review clears the approval of a previous one
+		task.Status = model.TaskStatusInReview
+		task.ApprovedBy = ""
+		task.ApprovedAt = nil
+
+		return tx.Set(docRef, task)
+	})
+	if err != nil {
+		return model.Task{}, nil, err
+	}
+
+	return task, exceeded, nil
+}
+
+// ReviewTask retrieves the data from the service layer and records the decision of a reviewer.
+// An approval completes the task and a rejection sends it back to development. The review comment
+// is added to the task responses inside the same transaction
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - reviewerId: The ID of the reviewer
+//   - approved: Whether the reviewer approved the task
+//   - response: The review comment, nil when the reviewer left no comment
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - error: An error that occured during the process
+func (r *taskRepository) ReviewTask(ctx context.Context, taskId, reviewerId string, approved bool, response *model.Response) (model.Task, error) {
+	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
+
+	var task model.Task
 	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
-		// Get the subtask snapshot
-		subtaskSnapshot, err := tx.Get(subtaskRef)
+		docSnapshot, err := tx.Get(docRef)
 		if err != nil {
 			if status.Code(err) == codes.NotFound {
-				return fmt.Errorf("subtask with the ID %s for the task with the ID %s not found", subtaskId, taskId)
+				return fmt.Errorf("task with ID %s not found", taskId)
 			}
 			return err
 		}
 
-		// Parse existing subtask data
-		var subtask model.Subtask
-		if err := subtaskSnapshot.DataTo(&subtask); err != nil {
+		task = model.Task{}
+		if err := docSnapshot.DataTo(&task); err != nil {
 			return err
 		}
 
-		// Only increment/decrement the counter if the value has actually changed
-		if subtask.Done != subtaskStatus {
-			counterDelta := int64(1)
-			if !subtaskStatus {
-				counterDelta = -1
-			}
+		if task.Status != model.TaskStatusInReview {
+			return fmt.Errorf("%w: the task `%s` is not in review", utils.ErrInvalidReview, taskId)
+		}
 
-			// Update the parent task's completedSubtaskCount
-			if err := tx.Update(taskRef, []firestore.Update{
-				{Path: "completedSubtaskCount", Value: firestore.Increment(counterDelta)},
-			}); err != nil {
-				return fmt.Errorf("failed to update completedSubtaskCount in parent task: %w", err)
-			}
+		// The reviewers can change while the task is in review
+		if !slices.Contains(task.ReviewerIDs, reviewerId) {
+			return fmt.Errorf("%w: user `%s` is not a reviewer of the task `%s`", utils.ErrForbidden, reviewerId, taskId)
 		}
 
-		// Update the subtask `done` status
-		if err := tx.Update(subtaskRef, []firestore.Update{
-			{Path: "done", Value: subtaskStatus},
-		}); err != nil {
-			return fmt.Errorf("failed to update subtask status: %w", err)
+		// A reviewer that was added as a handler afterwards cannot review their own work
+		if slices.Contains(task.HandlerIDs, reviewerId) {
+			return fmt.Errorf("%w: user `%s` is a handler of the task `%s`", utils.ErrForbidden, reviewerId, taskId)
 		}
 
-		// Return the updated subtask
-		subtask.Done = subtaskStatus
-		updatedSubtask = subtask
+		taskStatus := model.TaskStatusDevelopment
+		if approved {
+			taskStatus = model.TaskStatusCompleted
+		}
 
-		return nil
+		// Record the status change in the task history
+		transition := r.newStatusTransition(task, taskStatus)
+		if err := tx.Create(r.client.Collection(utils.EnvInstances.TRANSITIONS_COLLECTION).Doc(transition.ID), transition); err != nil {
+			return err
+		}
+
+		// Add the review comment to the task responses
+		if response != nil {
+			if err := tx.Create(docRef.Collection(utils.EnvInstances.RESPONSES_COLLECTION).Doc(response.ID), *response); err != nil {
+				return fmt.Errorf("failed to create response: %w", err)
+			}
+
+			task.ResponseCount++
+		}
+
+		task.Status = taskStatus
+		if approved {
+			task.ApprovedBy = reviewerId
+			task.ApprovedAt = &transition.ChangedAt
+		}
+
+		return tx.Set(docRef, task)
 	})
 	if err != nil {
-		return model.Subtask{}, err
+		return model.Task{}, err
 	}
 
-	return updatedSubtask, nil
+	return task, nil
 }
 
-// UpdateResponseMessage retrieves the data from the service layer and updates the text message of a task response
+// UpdateTaskPriority retrieves the data from the service layer and updates the priority and the severity of the task
 //
 // Parameters:
 //   - ctx: Request-scoped context
-//   - taskId: The ID of the task the response is part of
-//   - responseId: The ID of the resmpose
+//   - taskId: The ID of the task
+//   - priority: The new task priority
+//   - priorityRank: The sorting rank of the priority
+//   - severity: The new task severity, empty to remove it
 //
 // Returns:
-//   - model.Response: The updated response data
+//   - model.Task: The updated task data
 //   - error: An error that occured during the process
-func (r *taskRepository) UpdateResponseMessage(ctx context.Context, taskId string, responseId, message string) (model.Response, error) {
-	// Get the response document reference
-	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId).Collection(utils.EnvInstances.RESPONSES_COLLECTION).Doc(responseId)
+func (r *taskRepository) UpdateTaskPriority(ctx context.Context, taskId string, priority string, priorityRank int, severity string) (model.Task, error) {
+	// Get the task document reference
+	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
 
 	// Get the document snapshot
 	docSnapshot, err := docRef.Get(ctx)
-
-	// Check if the document exists
 	if err != nil {
+		// Check if the document exists
 		if status.Code(err) == codes.NotFound {
-			return model.Response{}, fmt.Errorf("response with ID %s not found. Failed to update response message", responseId)
+			return model.Task{}, fmt.Errorf("task with ID %s not found", taskId)
 		}
-		return model.Response{}, err
+		return model.Task{}, err
 	}
 
-	// Add the snapshot data to the response object
-	var response model.Response
-	if err = docSnapshot.DataTo(&response); err != nil {
-		return model.Response{}, err
+	// Add the document snapshot data to the task object
+	var task model.Task
+	if err = docSnapshot.DataTo(&task); err != nil {
+		return model.Task{}, err
+	}
+
+	// Update the task priority and severity
+	task.Priority = priority
+	task.PriorityRank = priorityRank
+	task.Severity = severity
+
+	// Update the task inside the database
+	if _, err = docRef.Set(ctx, task); err != nil {
+		return model.Task{}, err
+	}
+
+	return task, nil
+}
+
+// AddTaskHandlers retrieves data from the service layer and adds new handlers to a project task.
+// The work in progress limits of the new handlers are checked inside the same transaction and
+// each new handler has to accept the assignment
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task to update
+//   - assignerId: The ID of the user that assigns the handlers
+//   - handlerIds: The list of new handler IDs
+//   - override: Whether the exceeded work in progress limits are ignored
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - []string: The work in progress limits that were overridden
+//   - error: An error that occured during the process
+func (r *taskRepository) AddTaskHandlers(ctx context.Context, taskId, assignerId string, handlerIds []string, override bool) (model.Task, []string, error) {
+	// Get the task document reference
+	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
+
+	var task model.Task
+	var exceeded []string
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Get the document snpashot
+		docSnapshot, err := tx.Get(docRef)
+
+		// Check if the document exists
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("task with ID %s not found", taskId)
+			}
+			return err
+		}
+
+		// Add the data from the snapshot to the task object
+		task = model.Task{}
+		if err := docSnapshot.DataTo(&task); err != nil {
+			return err
+		}
+
+		// Check if the handlers that are not assigned yet can take the task
+		newHandlerIds := []string{}
+		for _, handlerId := range handlerIds {
+			if !slices.Contains(task.HandlerIDs, handlerId) {
+				newHandlerIds = append(newHandlerIds, handlerId)
+			}
+		}
+
+		if exceeded, err = checkWipLimits(r.client, tx, task, task.Status, newHandlerIds, override); err != nil {
+			return err
+		}
+
+		// Add the new handlers to the task handlers
+		if task.Assignments == nil {
+			task.Assignments = map[string]model.HandlerAssignment{}
+		}
+
+		now := time.Now().UnixMilli()
+		for _, handlerId := range newHandlerIds {
+			if slices.Contains(task.HandlerIDs, handlerId) {
+				continue
+			}
+
+			task.HandlerIDs = append(task.HandlerIDs, handlerId)
+			task.Assignments[handlerId] = newHandlerAssignment(handlerId, assignerId, now)
+		}
+
+		// Update the task data into the database
+		return tx.Set(docRef, task)
+	})
+	if err != nil {
+		return model.Task{}, nil, err
+	}
+
+	return task, exceeded, nil
+}
+
+// RemoveTaskHandlers retrieves the data from the service layer and removes handlers from a task handler list
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task to update
+//   - handlerIds: The list of handler IDs to remove
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - error: An error that occured during the process
+func (r *taskRepository) RemoveTaskHandlers(ctx context.Context, taskId string, handlerIds []string) (model.Task, error) {
+	// Get the task document reference
+	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
+
+	// Get the document snapshot
+	docSnapshot, err := docRef.Get(ctx)
+
+	// Check if the document exists
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return model.Task{}, fmt.Errorf("task with ID %s not found", taskId)
+		}
+		return model.Task{}, err
+	}
+
+	// Add the snapshot data to the task object
+	var task model.Task
+	err = docSnapshot.DataTo(&task)
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	// Iterate over the task handlers and check if the list contains the handlers to remove and remove them
+	var filteredHnalderIds []string
+	for _, taskHandler := range task.HandlerIDs {
+		if slices.Contains(handlerIds, taskHandler) == false {
+			filteredHnalderIds = append(filteredHnalderIds, taskHandler)
+		}
+	}
+
+	// Update the task handlers list
+	task.HandlerIDs = filteredHnalderIds
+
+	// The removed handlers no longer have to answer the assignment
+	for _, handlerId := range handlerIds {
+		delete(task.Assignments, handlerId)
+	}
+
+	// Update the task data into the database
+	_, err = docRef.Set(ctx, task)
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	return task, nil
+}
+
+// AnswerTaskAssignment retrieves the data from the service layer and records the answer of a handler to a task assignment.
+// A handler that declines the assignment is removed from the task handlers
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - handlerId: The ID of the handler
+//   - accepted: Whether the handler accepted the assignment
+//   - reason: The reason of the decline, empty when the assignment is accepted
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - error: An error that occured during the process
+func (r *taskRepository) AnswerTaskAssignment(ctx context.Context, taskId, handlerId string, accepted bool, reason string) (model.Task, error) {
+	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
+
+	var task model.Task
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		docSnapshot, err := tx.Get(docRef)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("task with ID %s not found", taskId)
+			}
+			return err
+		}
+
+		task = model.Task{}
+		if err := docSnapshot.DataTo(&task); err != nil {
+			return err
+		}
+
+		// Only the pending assignments of the current handlers can be answered
+		assignment, ok := task.Assignments[handlerId]
+		if !ok || assignment.State != model.AssignmentPending || !slices.Contains(task.HandlerIDs, handlerId) {
+			return fmt.Errorf("%w: user `%s` has no pending assignment on the task `%s`", utils.ErrInvalidAssignment, handlerId, taskId)
+		}
+
+		now := time.Now().UnixMilli()
+		assignment.AnsweredAt = &now
+		if accepted {
+			assignment.State = model.AssignmentAccepted
+		} else {
+			assignment.State = model.AssignmentDeclined
+			assignment.Reason = reason
+
+			task.HandlerIDs = slices.DeleteFunc(task.HandlerIDs, func(id string) bool { return id == handlerId })
+		}
+
+		task.Assignments[handlerId] = assignment
+
+		return tx.Set(docRef, task)
+	})
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	return task, nil
+}
+
+// AddTaskLabels retrieves the data from the service layer and adds labels to a task.
+// The labels already on the task are not duplicated
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - labels: The list of label names to add
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - error: An error that occured during the process
+func (r *taskRepository) AddTaskLabels(ctx context.Context, taskId string, labels []string) (model.Task, error) {
+	return r.updateTaskLabels(ctx, taskId, firestore.ArrayUnion(labelValues(labels)...))
+}
+
+// RemoveTaskLabels retrieves the data from the service layer and removes labels from a task
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - labels: The list of label names to remove
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - error: An error that occured during the process
+func (r *taskRepository) RemoveTaskLabels(ctx context.Context, taskId string, labels []string) (model.Task, error) {
+	return r.updateTaskLabels(ctx, taskId, firestore.ArrayRemove(labelValues(labels)...))
+}
+
+// AddTaskDependency retrieves the data from the service layer and marks a task as blocked by another task.
+// The project dependencies are read inside the same transaction, so the new dependency is rejected
+// when the blocker already depends on the task, directly or through other tasks
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the blocked task
+//   - blockerId: The ID of the task that blocks it
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - error: An error that occured during the process
+func (r *taskRepository) AddTaskDependency(ctx context.Context, taskId, blockerId string) (model.Task, error) {
+	tasksRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION)
+	taskRef := tasksRef.Doc(taskId)
+	blockerRef := tasksRef.Doc(blockerId)
+
+	var task model.Task
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Get the data of both tasks
+		docSnapshots, err := tx.GetAll([]*firestore.DocumentRef{taskRef, blockerRef})
+		if err != nil {
+			return err
+		}
+
+		for _, doc := range docSnapshots {
+			if !doc.Exists() {
+				return fmt.Errorf("task with ID %s not found", doc.Ref.ID)
+			}
+		}
+
+		var blocker model.Task
+		if err := docSnapshots[0].DataTo(&task); err != nil {
+			return err
+		}
+		if err := docSnapshots[1].DataTo(&blocker); err != nil {
+			return err
+		}
+
+		// Only the tasks of the same project can depend on each other
+		if task.ProjectID != blocker.ProjectID {
+			return fmt.Errorf("%w: the tasks are part of different projects", utils.ErrInvalidDependency)
+		}
+
+		// Get the dependencies of the project tasks
+		projectDocs, err := tx.Documents(tasksRef.Where("projectId", "==", task.ProjectID).Select("id", "blocks")).GetAll()
+		if err != nil {
+			return err
+		}
+
+		graph := map[string][]string{}
+		for _, doc := range projectDocs {
+			var node struct {
+				ID     string   `firestore:"id"`
+				Blocks []string `firestore:"blocks"`
+			}
+			if err := doc.DataTo(&node); err != nil {
+				return err
+			}
+
+			graph[node.ID] = node.Blocks
+		}
+
+		// The new dependency closes a cycle when the blocker is already blocked by the task
+		if hasDependencyPath(graph, taskId, blockerId) {
+			return fmt.Errorf("%w: task `%s` already depends on task `%s`", utils.ErrDependencyCycle, blockerId, taskId)
+		}
+
+		// Store the dependency on both tasks
+		if err := tx.Update(taskRef, []firestore.Update{{Path: "blockedBy", Value: firestore.ArrayUnion(blockerId)}}); err != nil {
+			return err
+		}
+
+		if !slices.Contains(task.BlockedBy, blockerId) {
+			task.BlockedBy = append(task.BlockedBy, blockerId)
+		}
+
+		return tx.Update(blockerRef, []firestore.Update{{Path: "blocks", Value: firestore.ArrayUnion(taskId)}})
+	})
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	return task, nil
+}
+
+// RemoveTaskDependency retrieves the data from the service layer and removes the dependency between two tasks
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the blocked task
+//   - blockerId: The ID of the task that blocks it
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - error: An error that occured during the process
+func (r *taskRepository) RemoveTaskDependency(ctx context.Context, taskId, blockerId string) (model.Task, error) {
+	tasksRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION)
+	taskRef := tasksRef.Doc(taskId)
+	blockerRef := tasksRef.Doc(blockerId)
+
+	var task model.Task
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Get the data of both tasks
+		docSnapshots, err := tx.GetAll([]*firestore.DocumentRef{taskRef, blockerRef})
+		if err != nil {
+			return err
+		}
+
+		if !docSnapshots[0].Exists() {
+			return fmt.Errorf("task with ID %s not found", taskId)
+		}
+
+		if err := docSnapshots[0].DataTo(&task); err != nil {
+			return err
+		}
+
+		// Remove the dependency from both tasks, the blocker might have been deleted already
+		if err := tx.Update(taskRef, []firestore.Update{{Path: "blockedBy", Value: firestore.ArrayRemove(blockerId)}}); err != nil {
+			return err
+		}
+
+		task.BlockedBy = slices.DeleteFunc(task.BlockedBy, func(id string) bool { return id == blockerId })
+
+		if docSnapshots[1].Exists() {
+			return tx.Update(blockerRef, []firestore.Update{{Path: "blocks", Value: firestore.ArrayRemove(taskId)}})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	return task, nil
+}
+
+// AddTaskChildren retrieves the data from the service layer and attaches a list of tasks to a parent task.
+// The ancestors of the parent are read inside the same transaction, so a task cannot become a descendant of itself
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - parentId: The ID of the parent task
+//   - childIds: The list of child task IDs
+//
+// Returns:
+//   - []model.Task: The list of updated child tasks
+//   - error: An error that occured during the process
+func (r *taskRepository) AddTaskChildren(ctx context.Context, parentId string, childIds []string) ([]model.Task, error) {
+	tasksRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION)
+
+	var children []model.Task
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		children = nil
+
+		// Get the parent task data
+		parentSnapshot, err := tx.Get(tasksRef.Doc(parentId))
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("task with ID %s not found", parentId)
+			}
+			return err
+		}
+
+		var parent model.Task
+		if err := parentSnapshot.DataTo(&parent); err != nil {
+			return err
+		}
+
+		// Get the chain of ancestors of the parent task
+		ancestorIds := []string{parent.ID}
+		for ancestorId := parent.ParentTaskID; ancestorId != "" && !slices.Contains(ancestorIds, ancestorId); {
+			ancestorSnapshot, err := tx.Get(tasksRef.Doc(ancestorId))
+			if err != nil {
+				if status.Code(err) == codes.NotFound {
+					break
+				}
+				return err
+			}
+
+			var ancestor model.Task
+			if err := ancestorSnapshot.DataTo(&ancestor); err != nil {
+				return err
+			}
+
+			ancestorIds = append(ancestorIds, ancestor.ID)
+			ancestorId = ancestor.ParentTaskID
+		}
+
+		// Get the child tasks data
+		childRefs := make([]*firestore.DocumentRef, len(childIds))
+		for i, childId := range childIds {
+			childRefs[i] = tasksRef.Doc(childId)
+		}
+
+		childSnapshots, err := tx.GetAll(childRefs)
+		if err != nil {
+			return err
+		}
+
+		for _, doc := range childSnapshots {
+			if !doc.Exists() {
+				return fmt.Errorf("task with ID %s not found", doc.Ref.ID)
+			}
+
+			var child model.Task
+			if err := doc.DataTo(&child); err != nil {
+				return err
+			}
+
+			// Only the tasks of the same project can be part of the hierarchy
+			if child.ProjectID != parent.ProjectID {
+				return fmt.Errorf("%w: task `%s` is part of another project", utils.ErrInvalidHierarchy, child.ID)
+			}
+
+			// The parent cannot become a child of its own descendant
+			if slices.Contains(ancestorIds, child.ID) {
+				return fmt.Errorf("%w: task `%s` is an ancestor of task `%s`", utils.ErrHierarchyCycle, child.ID, parentId)
+			}
+
+			child.ParentTaskID = parentId
+			children = append(children, child)
+		}
+
+		// Set the parent of each child task
+		for _, childRef := range childRefs {
+			if err := tx.Update(childRef, []firestore.Update{{Path: "parentTaskId", Value: parentId}}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return children, nil
+}
+
+// RemoveTaskChildren retrieves the data from the service layer and detaches a list of tasks from a parent task.
+// The tasks that are not children of the parent are skipped
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - parentId: The ID of the parent task
+//   - childIds: The list of child task IDs
+//
+// Returns:
+//   - []model.Task: The list of detached tasks
+//   - error: An error that occured during the process
+func (r *taskRepository) RemoveTaskChildren(ctx context.Context, parentId string, childIds []string) ([]model.Task, error) {
+	tasksRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION)
+
+	var children []model.Task
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		children = []model.Task{}
+
+		// Get the child tasks data
+		childRefs := make([]*firestore.DocumentRef, len(childIds))
+		for i, childId := range childIds {
+			childRefs[i] = tasksRef.Doc(childId)
+		}
+
+		childSnapshots, err := tx.GetAll(childRefs)
+		if err != nil {
+			return err
+		}
+
+		for _, doc := range childSnapshots {
+			if !doc.Exists() {
+				continue
+			}
+
+			var child model.Task
+			if err := doc.DataTo(&child); err != nil {
+				return err
+			}
+
+			if child.ParentTaskID != parentId {
+				continue
+			}
+
+			// Remove the parent of the child task
+			if err := tx.Update(doc.Ref, []firestore.Update{{Path: "parentTaskId", Value: firestore.Delete}}); err != nil {
+				return err
+			}
+
+			child.ParentTaskID = ""
+			children = append(children, child)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return children, nil
+}
+
+// MoveTask retrieves the data from the service layer and moves a task to another project.
+// The subtasks and the responses are stored under the task document, so they are moved with it.
+// The dependencies, the hierarchy links and the series are removed since they are part of the source project.
+// The task and its handlers have to fit into the work in progress limits of the target project and the task
+// is placed at the bottom of its status column. The work logs and the status history are moved after the task
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - fromProjectId: The ID of the project the task is part of
+//   - toProjectId: The ID of the target project
+//   - handlerIds: The handlers the task keeps
+//   - reviewerIds: The reviewers the task keeps
+//   - labels: The labels the task keeps
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - error: An error that occured during the process
+func (r *taskRepository) MoveTask(ctx context.Context, taskId, fromProjectId, toProjectId string, handlerIds, reviewerIds, labels []string) (model.Task, error) {
+	// Get the task document reference
+	tasksRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION)
+	docRef := tasksRef.Doc(taskId)
+
+	var task model.Task
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Get the current task data
+		docSnapshot, err := tx.Get(docRef)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("task with ID %s not found", taskId)
+			}
+			return err
+		}
+
+		if err := docSnapshot.DataTo(&task); err != nil {
+			return err
+		}
+
+		// Check that the task was not moved in the meantime
+		if task.ProjectID != fromProjectId {
+			return fmt.Errorf("%w: task `%s` is no longer part of the project `%s`", utils.ErrInvalidMove, taskId, fromProjectId)
+		}
+
+		// Check if the task and its handlers fit into the same status column of the target project
+		if _, err := checkWipLimits(r.client, tx, model.Task{ProjectID: toProjectId}, task.Status, handlerIds, false); err != nil {
+			return err
+		}
+
+		// Set the new project and the data that is still valid in it
+		task.ProjectID = toProjectId
+
+		// Get the last task of the status column in the target project
+		lastSnapshots, err := tx.Documents(tasksRef.
+			Where("projectId", "==", toProjectId).
+			Where("status", "==", task.Status).
+			OrderBy("rank", firestore.Desc).
+			Limit(1)).GetAll()
+		if err != nil {
+			return err
+		}
+
+		var lastId, lastRank string
+		if len(lastSnapshots) > 0 {
+			var lastTask model.Task
+			if err := lastSnapshots[0].DataTo(&lastTask); err != nil {
+				return err
+			}
+
+			lastId, lastRank = lastTask.ID, lastTask.Rank
+		}
+
+		// Place the task at the bottom of the column, the column is rebalanced when there is no room left
+		rank, ok := rankBetween(lastRank, "")
+
+		var rebalanced map[string]string
+		if !ok {
+			if rebalanced, err = r.rebalanceColumn(tx, task, task.Status, lastId); err != nil {
+				return err
+			}
+
+			rank = rebalanced[taskId]
+		}
+
+		for id, columnRank := range rebalanced {
+			if id == taskId {
+				continue
+			}
+
+			if err := tx.Update(tasksRef.Doc(id), []firestore.Update{{Path: "rank", Value: columnRank}}); err != nil {
+				return err
+			}
+		}
+
+		task.Rank = rank
+		// The dropped handlers no longer have to answer the assignment
+		for _, handlerId := range task.HandlerIDs {
+			if !slices.Contains(handlerIds, handlerId) {
+				delete(task.Assignments, handlerId)
+			}
+		}
+
+		task.HandlerIDs = handlerIds
+		task.ReviewerIDs = reviewerIds
+		task.Labels = labels
+		task.BlockedBy = []string{}
+		task.Blocks = []string{}
+		task.ParentTaskID = ""
+		task.SprintID = ""
+
+		// The series keeps creating its occurrences in the source project
+		task.SeriesID = ""
+		task.Occurrence = 0
+
+		return tx.Set(docRef, task)
+	})
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	// Move the work logs and the status history of the task, they are not bounded so they are not part of the transaction
+	if err := r.moveTaskHistory(ctx, taskId, toProjectId); err != nil {
+		return model.Task{}, err
+	}
+
+	return task, nil
+}
+
+// PromoteSubtask retrieves the data from the service layer and converts a subtask into a task of the same project.
+// The task is created, the subtask is removed or marked as promoted and the counters of the parent task
+// are updated inside a single transaction, after the work in progress limits of the initial column are checked
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task the subtask is part of
+//   - subtaskId: The ID of the subtask
+//   - task: The new task data, the description, author, handler and status are taken from the subtask
+//   - keepSubtask: True to keep the subtask marked as promoted, false to remove it
+//
+// Returns:
+//   - model.SubtaskPromotion: The created task, the subtask and the updated parent task
+//   - error: An error that occured during the process
+func (r *taskRepository) PromoteSubtask(ctx context.Context, taskId, subtaskId string, task model.Task, keepSubtask bool) (model.SubtaskPromotion, error) {
+	tasksRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION)
+	parentRef := tasksRef.Doc(taskId)
+	subtaskRef := parentRef.Collection(utils.EnvInstances.TASKS_SUBCOLLECTION).Doc(subtaskId)
+
+	var promotion model.SubtaskPromotion
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Get the parent task and the subtask data
+		parentSnapshot, err := tx.Get(parentRef)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("task with ID %s not found", taskId)
+			}
+			return err
+		}
+
+		subtaskSnapshot, err := tx.Get(subtaskRef)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("subtask with ID %s not found", subtaskId)
+			}
+			return err
+		}
+
+		var parent model.Task
+		if err := parentSnapshot.DataTo(&parent); err != nil {
+			return err
+		}
+
+		var subtask model.Subtask
+		if err := subtaskSnapshot.DataTo(&subtask); err != nil {
+			return err
+		}
+
+		// A subtask can be promoted only once
+		if subtask.PromotedTo != "" {
+			return fmt.Errorf("subtask with ID %s was already promoted to the task %s", subtaskId, subtask.PromotedTo)
+		}
+
+		// Carry the subtask data over to the new task
+		newTask := task
+		newTask.ProjectID = parent.ProjectID
+		newTask.ParentTaskID = parent.ID
+		newTask.AuthorID = subtask.AuthorID
+		newTask.Description = subtask.Description
+		newTask.HandlerIDs = []string{}
+		newTask.Assignments = map[string]model.HandlerAssignment{}
+		if subtask.HandlerID != "" {
+			newTask.HandlerIDs = append(newTask.HandlerIDs, subtask.HandlerID)
+			newTask.Assignments[subtask.HandlerID] = newHandlerAssignment(subtask.HandlerID, newTask.AuthorID, newTask.CreatedAt)
+		}
+		if subtask.Done {
+			newTask.Status = model.TaskStatusCompleted
+		}
+
+		// Check if the task and its handler fit into the initial status column
+		if _, err := checkWipLimits(r.client, tx, model.Task{ProjectID: newTask.ProjectID}, newTask.Status, newTask.HandlerIDs, false); err != nil {
+			return err
+		}
+
+		if err := tx.Create(tasksRef.Doc(newTask.ID), newTask); err != nil {
+			return err
+		}
+
+		var updates []firestore.Update
+		if keepSubtask {
+			// Mark the subtask as promoted, the work is tracked by the new task so the subtask counts as done
+			if err := tx.Update(subtaskRef, []firestore.Update{
+				{Path: "promotedTo", Value: newTask.ID},
+				{Path: "done", Value: true},
+			}); err != nil {
+				return err
+			}
+
+			if !subtask.Done {
+				updates = append(updates, firestore.Update{Path: "completedSubtaskCount", Value: firestore.Increment(1)})
+				parent.CompletedSubtaskCount++
+			}
+
+			subtask.PromotedTo = newTask.ID
+			subtask.Done = true
+		} else {
+			// Delete the subtask and decrement the counters of the parent task
+			if err := tx.Delete(subtaskRef); err != nil {
+				return err
+			}
+
+			updates = append(updates, firestore.Update{Path: "subtaskCount", Value: firestore.Increment(-1)})
+			parent.SubtaskCount--
+
+			if subtask.Done {
+				updates = append(updates, firestore.Update{Path: "completedSubtaskCount", Value: firestore.Increment(-1)})
+				parent.CompletedSubtaskCount--
+			}
+		}
+
+		if len(updates) > 0 {
+			if err := tx.Update(parentRef, updates); err != nil {
+				return err
+			}
+		}
+
+		promotion = model.SubtaskPromotion{
+			Task:       newTask,
+			Subtask:    subtask,
+			ParentTask: parent,
+			Removed:    !keepSubtask,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return model.SubtaskPromotion{}, err
+	}
+
+	return promotion, nil
+}
+
+// UpdateSubtaskDescription retrieves the data from the service layer and uptates the description of the subtask
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task the subtask is part of
+//   - subtaskId: The subtask ID
+//   - description: The new subtask description
+//
+// Returns:
+//   - model.Subtask: The updated subtask data
+//   - error: An error that occured during the process
+func (r *taskRepository) UpdateSubtaskDescription(ctx context.Context, taskId string, subtaskId string, description string) (model.Subtask, error) {
+	// Get the subtask document reference
+	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId).Collection(utils.EnvInstances.TASKS_SUBCOLLECTION).Doc(subtaskId)
+
+	// Get the document snapshot
+	docSnapshot, err := docRef.Get(ctx)
+
+	// Check if the document exists
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return model.Subtask{}, fmt.Errorf("subtask with the ID %s for the task with the ID %s not found", subtaskId, taskId)
+		}
+		return model.Subtask{}, err
+	}
+
+	// Add the snapshot data to the subtask object
+	var subtask model.Subtask
+	err = docSnapshot.DataTo(&subtask)
+	if err != nil {
+		return model.Subtask{}, err
+	}
+
+	// Update the subtask description
+	subtask.Description = description
+
+	// Update the subtask data into the database
+	_, err = docRef.Set(ctx, subtask)
+	if err != nil {
+		return model.Subtask{}, err
+	}
+
+	return subtask, nil
+}
+
+// UpdateSubtaskHandler retrieves the data from the service layer and updates the handler of the subtask
+//
+// Parameters:
+//   - ctx: Reqeust-scoped context
+//   - taskId: The ID of the task the subtask is part of
+//   - subtaskId: The subtask ID
+//   - handlerId: The new subtask handler ID
+//
+// Returns:
+//   - model.Subtask: The updated subtask data
+//   - error: An error that occured during the process
+func (r *taskRepository) UpdateSubtaskHandler(ctx context.Context, taskId string, subtaskId string, handlerId string) (model.Subtask, error) {
+	// Get the subtask document reference
+	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId).Collection(utils.EnvInstances.TASKS_SUBCOLLECTION).Doc(subtaskId)
+
+	// Get the document snapshot
+	docSnapshot, err := docRef.Get(ctx)
+
+	// Check if the document exists
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return model.Subtask{}, fmt.Errorf("subtask with the ID %s for the task with the ID %s not found", subtaskId, taskId)
+		}
+		return model.Subtask{}, err
+	}
+
+	// Add the snapshot data to the subtask object
+	var subtask model.Subtask
+	err = docSnapshot.DataTo(&subtask)
+	if err != nil {
+		return model.Subtask{}, err
+	}
+
+	// Update the subtask handler
+	subtask.HandlerID = handlerId
+
+	// Update the subtask data into the database
+	_, err = docRef.Set(ctx, subtask)
+	if err != nil {
+		return model.Subtask{}, err
+	}
+
+	return subtask, nil
+}
+
+// UpdateSubtaskStatus retrieves the data from the service layer and updates the status of the subtask
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task that the subtask is part of
+//   - subtaskId: The subtask ID
+//   - subtaskStatus: The new subtask status
+//
+// Returns:
+//   - model.Subtask: The updated subtask data
+//   - error: An error that occured during the process
+func (r *taskRepository) UpdateSubtaskStatus(ctx context.Context, taskId string, subtaskId string, subtaskStatus bool) (model.Subtask, error) {
+	// Get the task document reference
+	taskRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
+
+	// Get the subtask document reference
+	subtaskRef := taskRef.Collection(utils.EnvInstances.TASKS_SUBCOLLECTION).Doc(subtaskId)
+
+	var updatedSubtask model.Subtask
+
+	// Run a transaction to avoid race conditions
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Get the subtask snapshot
+		subtaskSnapshot, err := tx.Get(subtaskRef)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("subtask with the ID %s for the task with the ID %s not found", subtaskId, taskId)
+			}
+			return err
+		}
+
+		// Parse existing subtask data
+		var subtask model.Subtask
+		if err := subtaskSnapshot.DataTo(&subtask); err != nil {
+			return err
+		}
+
+		// Only increment/decrement the counter if the value has actually changed
+		if subtask.Done != subtaskStatus {
+			counterDelta := int64(1)
+			if !subtaskStatus {
+				counterDelta = -1
+			}
+
+			// Update the parent task's completedSubtaskCount
+			if err := tx.Update(taskRef, []firestore.Update{
+				{Path: "completedSubtaskCount", Value: firestore.Increment(counterDelta)},
+			}); err != nil {
+				return fmt.Errorf("failed to update completedSubtaskCount in parent task: %w", err)
+			}
+		}
+
+		// Update the subtask `done` status
+		if err := tx.Update(subtaskRef, []firestore.Update{
+			{Path: "done", Value: subtaskStatus},
+		}); err != nil {
+			return fmt.Errorf("failed to update subtask status: %w", err)
+		}
+
+		// Return the updated subtask
+		subtask.Done = subtaskStatus
+		updatedSubtask = subtask
+
+		return nil
+	})
+	if err != nil {
+		return model.Subtask{}, err
+	}
+
+	return updatedSubtask, nil
+}
+
+// UpdateResponseMessage retrieves the data from the service layer and updates the text message of a task response
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task the response is part of
+//   - responseId: The ID of the resmpose
+//
+// Returns:
+//   - model.Response: The updated response data
+//   - error: An error that occured during the process
+func (r *taskRepository) UpdateResponseMessage(ctx context.Context, taskId string, responseId, message string) (model.Response, error) {
+	// Get the response document reference
+	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId).Collection(utils.EnvInstances.RESPONSES_COLLECTION).Doc(responseId)
+
+	// Get the document snapshot
+	docSnapshot, err := docRef.Get(ctx)
+
+	// Check if the document exists
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return model.Response{}, fmt.Errorf("response with ID %s not found. Failed to update response message", responseId)
+		}
+		return model.Response{}, err
+	}
+
+	// Add the snapshot data to the response object
+	var response model.Response
+	if err = docSnapshot.DataTo(&response); err != nil {
+		return model.Response{}, err
+	}
+
+	// update the response text message
+	response.Message = message
+
+	// Update the reponse object into the database
+	_, err = docRef.Set(ctx, response)
+	if err != nil {
+		return model.Response{}, nil
+	}
+
+	return response, nil
+
+}
+
+// RerollTaskVersion retrieves the data from the service layer and rolls the content of a task back to an older version.
+// Only the description, the priority, the severity, the deadline and the status are restored, a status change
+// goes through the same review and work in progress checks as a regular status update
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - task: An old task version
+//
+// Returns:
+//   - model.Task: The updated task version
+//   - error: An error that occured during the process
+func (r *taskRepository) RerollTaskVersion(ctx context.Context, taskId string, task model.Task) (model.Task, error) {
+	// Get the task document reference
+	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
+
+	var currentTask model.Task
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Get the document snapshot
+		docSnapshot, err := tx.Get(docRef)
+
+		// Check if the document exists
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("task with ID `%s` not found", taskId)
+			}
+			return err
+		}
+
+		// Add the document snapshot data to the task object
+		if err := docSnapshot.DataTo(&currentTask); err != nil {
+			return err
+		}
+
+		if task.Status != "" && task.Status != currentTask.Status {
+			// The reviewed tasks enter and leave the review only through the review workflow
+			if err := checkReviewTransition(currentTask, task.Status); err != nil {
+				return err
+			}
+
+			// Check if the task and its handlers fit into the old status column
+			if _, err := checkWipLimits(r.client, tx, currentTask, task.Status, currentTask.HandlerIDs, false); err != nil {
+				return err
+			}
+
+			// Record the status change in the task history
+			transition := r.newStatusTransition(currentTask, task.Status)
+			if err := tx.Create(r.client.Collection(utils.EnvInstances.TRANSITIONS_COLLECTION).Doc(transition.ID), transition); err != nil {
+				return err
+			}
+
+			currentTask.Status = task.Status
+		}
+
+		// An older deadline clears the overdue state of the current one
+		if task.Deadline != currentTask.Deadline {
+			currentTask.Deadline = task.Deadline
+			currentTask.OverdueAt = nil
+			currentTask.EscalatedAt = nil
+		}
+
+		// Restore the content of the old version
+		currentTask.Description = task.Description
+		currentTask.Priority = task.Priority
+		currentTask.PriorityRank = task.PriorityRank
+		currentTask.Severity = task.Severity
+
+		// Update the task inside the database
+		return tx.Set(docRef, currentTask)
+	})
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	return currentTask, nil
+}
+
+// RerollSubtaskVersion retrieves the data from the service layer and updates a subtask of a task to an older version
+//
+// Parameters:
+//   - ctx: Reqeust-scoped context
+//   - taskId: The ID of the task the subtask is part of
+//   - subtaskId: The ID of the subtask
+//   - subtask: The old subtask version
+//
+// Returns:
+//   - model.Subtask: The updated subtask version
+//   - error: An error that occured during the process
+func (r *taskRepository) RerollSubtaskVersion(ctx context.Context, taskId, subtaskId string, subtask model.Subtask) (model.Subtask, error) {
+	// Get the subtask document reference
+	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId).Collection(utils.EnvInstances.TASKS_SUBCOLLECTION).Doc(subtaskId)
+
+	// Get the document snapshot
+	_, err := docRef.Get(ctx)
+	if err != nil {
+		// Check if the subtask exists
+		if status.Code(err) == codes.NotFound {
+			return model.Subtask{}, fmt.Errorf("subtask with ID `%s` not found", subtaskId)
+		}
+		return model.Subtask{}, err
+	}
+
+	// Reroll the subtask version
+	_, err = docRef.Set(ctx, subtask)
+	if err != nil {
+		return model.Subtask{}, err
+	}
+
+	return subtask, err
+}
+
+// DeleteTaskById retrieves the data from the service layer and deletes the task
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task to delete
+//
+// Returns:
+//   - model.Task: The data of the deleted task
+//   - error: An error that occured during the process
+func (r *taskRepository) DeleteTaskById(ctx context.Context, taskId string) (model.Task, error) {
+	// Get the task document reference
+	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
+
+	// Get the document snapshot
+	docSnapshot, err := docRef.Get(ctx)
+
+	// Check if the document exists
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return model.Task{}, fmt.Errorf("task with ID %s not found", taskId)
+		}
+		return model.Task{}, err
+	}
+
+	// Add the snapshot data to the task object
+	var task model.Task
+	if err = docSnapshot.DataTo(&task); err != nil {
+		return model.Task{}, err
+	}
+
+	// Delete the task from the database
+	docRef.Delete(ctx)
+
+	return task, nil
+}
+
+// ClearTaskDependencies removes a deleted task from the dependencies of the tasks it was linked to
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - task: The data of the deleted task
+//
+// Returns:
+//   - error: An error that occured during the process
+func (r *taskRepository) ClearTaskDependencies(ctx context.Context, task model.Task) error {
+	if len(task.BlockedBy) == 0 && len(task.Blocks) == 0 {
+		return nil
+	}
+
+	// Generate a new bulk writer that batches the updates
+	bulkWriter := r.client.BulkWriter(ctx)
+
+	var writeJobs []*firestore.BulkWriterJob
+	addUpdate := func(taskId, path string) error {
+		docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
+		job, err := bulkWriter.Update(docRef, []firestore.Update{{Path: path, Value: firestore.ArrayRemove(task.ID)}})
+		if err != nil {
+			return err
+		}
+
+		writeJobs = append(writeJobs, job)
+		return nil
+	}
+
+	// The blockers no longer block the task and the blocked tasks are no longer blocked by it
+	for _, blockerId := range task.BlockedBy {
+		if err := addUpdate(blockerId, "blocks"); err != nil {
+			bulkWriter.End()
+			return err
+		}
+	}
+	for _, blockedId := range task.Blocks {
+		if err := addUpdate(blockedId, "blockedBy"); err != nil {
+			bulkWriter.End()
+			return err
+		}
+	}
+
+	// Commit the updates and wait for them to finish
+	bulkWriter.End()
+
+	// The linked tasks that were deleted as well are skipped
+	for _, job := range writeJobs {
+		if _, err := job.Results(); err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ClearTaskChildren detaches the children of a deleted task, so they become top level tasks
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - parentId: The ID of the deleted task
+//
+// Returns:
+//   - error: An error that occured during the process
+func (r *taskRepository) ClearTaskChildren(ctx context.Context, parentId string) error {
+	// Get the children of the task
+	docSnapshots, err := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Where("parentTaskId", "==", parentId).Documents(ctx).GetAll()
+	if err != nil {
+		return err
+	}
+
+	if len(docSnapshots) == 0 {
+		return nil
+	}
+
+	// Generate a new bulk writer that batches the updates
+	bulkWriter := r.client.BulkWriter(ctx)
+
+	var writeJobs []*firestore.BulkWriterJob
+	for _, doc := range docSnapshots {
+		job, err := bulkWriter.Update(doc.Ref, []firestore.Update{{Path: "parentTaskId", Value: firestore.Delete}})
+		if err != nil {
+			bulkWriter.End()
+			return err
+		}
+
+		writeJobs = append(writeJobs, job)
+	}
+
+	// Commit the updates and wait for them to finish
+	bulkWriter.End()
+
+	for _, job := range writeJobs {
+		if _, err := job.Results(); err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteSubtaskById retrieves the data from the service layer and deletes a subtask of a task
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task the subtask is part of
+//   - subtaskId: The ID of the subtask
+//
+// Returns:
+//   - model.Subtask: The data of the deleted subtask
+//   - error: An error that occured during the process
+func (r *taskRepository) DeleteSubtaskById(ctx context.Context, taskId string, subtaskId string) (model.Subtask, error) {
+	// Get the task reference
+	taskRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
+	// Get the subtask document reference
+	subtaskRef := taskRef.Collection(utils.EnvInstances.TASKS_SUBCOLLECTION).Doc(subtaskId)
+
+	var deletedSubtask model.Subtask
+
+	// Run a transaction to avoid errors while updating the parent task
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Get the subtask snapshot
+		subtaskSnapshot, err := tx.Get(subtaskRef)
+		if err != nil {
+			// Check if the subtask was not found
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("subtask with ID %s not found", subtaskId)
+			}
+
+			return err
+		}
+
+		// Add the subtask data to the variable in order to return it
+		if err := subtaskSnapshot.DataTo(&deletedSubtask); err != nil {
+			return err
+		}
+
+		// Delete the subtask
+		tx.Delete(subtaskRef)
+
+		// Decrement subtask counter
+		updates := []firestore.Update{
+			{Path: "subtaskCount", Value: firestore.Increment(-1)},
+		}
+
+		// If the subtask is complete, decrement the complete subtasks counter
+		if deletedSubtask.Done == true {
+			updates = append(updates, firestore.Update{
+				Path:  "completedSubtaskCount",
+				Value: firestore.Increment(-1),
+			})
+		}
+
+		// Apply the counter updates on the parent task
+		return tx.Update(taskRef, updates)
+	})
+	if err != nil {
+		return model.Subtask{}, err
+	}
+
+	return deletedSubtask, nil
+}
+
+// DeleteResopnseById retrieves the data from the service layer and deletes the task resposne
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task the response is part of
+//   - responseId: The ID of the task response
+//
+// Returns:
+//   - model.Response: The data of the deleted response
+//   - error: An error that occured during the process
+func (r *taskRepository) DeleteResponseById(ctx context.Context, taskId, responseId string) (model.Response, error) {
+	// Get the task reference
+	taskRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
+	// Get the response document reference
+	responseRef := taskRef.Collection(utils.EnvInstances.RESPONSES_COLLECTION).Doc(responseId)
+
+	var deletedResponse model.Response
+
+	// Run a transaction to avoid update errors in the parent task
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		reponseSnapshot, err := tx.Get(responseRef)
+		if err != nil {
+			// Check if the response was not found
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("response with ID %s not found", responseId)
+			}
+			return err
+		}
+
+		// Add the data of the resposne to the variable to return it
+		if err := reponseSnapshot.DataTo(&deletedResponse); err != nil {
+			return err
+		}
+
+		// Delte the response
+		tx.Delete(responseRef)
+
+		// Decremetn the task resposne counter
+		updates := []firestore.Update{
+			{Path: "responseCount", Value: firestore.Increment(-1)},
+		}
+
+		// Apply the counter updates to the parent task
+		return tx.Update(taskRef, updates)
+	})
+	if err != nil {
+		return model.Response{}, err
+	}
+
+	return deletedResponse, nil
+}
+
+// DeleteTaskSubcollectionBatch retrieves the data from the service layer and deletes one batch of documents
+// from the subtasks and responses subcollections of a deleted task and from its work logs and status history.
+// The subtasks are deleted first, then the responses, the work logs and the status transitions
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the deleted task
+//   - batchSize: The max number of documents to delete
+//
+// Returns:
+//   - int: The number of deleted documents, 0 when all the documents of the task are deleted
+//   - error: An error that occured during the process
+func (r *taskRepository) DeleteTaskSubcollectionBatch(ctx context.Context, taskId string, batchSize int) (int, error) {
+	// Get the task document reference
+	taskRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
+
+	queries := []firestore.Query{
+		taskRef.Collection(utils.EnvInstances.TASKS_SUBCOLLECTION).Query,
+		taskRef.Collection(utils.EnvInstances.RESPONSES_COLLECTION).Query,
+		r.client.Collection(utils.EnvInstances.WORKLOGS_COLLECTION).Where("taskId", "==", taskId),
+		r.client.Collection(utils.EnvInstances.TRANSITIONS_COLLECTION).Where("taskId", "==", taskId),
+	}
+
+	// Iterate over the collections and delete the first non empty batch
+	for _, query := range queries {
+		// Get the next batch of document snapshots
+		docSnapshots, err := query.Limit(batchSize).Documents(ctx).GetAll()
+		if err != nil {
+			return 0, err
+		}
+
+		// Check if the task has no documents left in the collection
+		if len(docSnapshots) == 0 {
+			continue
+		}
+
+		// Generate a new bulk writer that will delete the documents
+		bulkWriter := r.client.BulkWriter(ctx)
+
+		var deleteJobs []*firestore.BulkWriterJob
+		for _, doc := range docSnapshots {
+			job, err := bulkWriter.Delete(doc.Ref)
+			if err != nil {
+				bulkWriter.End()
+				return 0, err
+			}
+
+			deleteJobs = append(deleteJobs, job)
+		}
+
+		// Commit the deletions and wait for them to finish
+		bulkWriter.End()
+
+		// Check if any of the deletions failed
+		for _, job := range deleteJobs {
+			if _, err := job.Results(); err != nil {
+				return 0, err
+			}
+		}
+
+		return len(docSnapshots), nil
+	}
+
+	return 0, nil
+}
+
+// BulkUpdateTasks retrieves the data from the service layer and applies the same change to a list of tasks.
+// The writes are batched using a bulk writer and each task is updated independently,
+// so a task that fails does not stop the others. The status and handler changes are checked
+// against the work in progress limits, so each of them is written in its own transaction
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - assignerId: The ID of the user that assigns the added handlers
+//   - taskIds: The list of task IDs
+//   - operation: The change to apply to each task
+//
+// Returns:
+//   - []model.Task: The list of updated tasks
+//   - []model.BulkItemResult: The result of the operation for each task
+//   - error: An error that occured during the process
+func (r *taskRepository) BulkUpdateTasks(ctx context.Context, assignerId string, taskIds []string, operation model.BulkTaskOperation) ([]model.Task, []model.BulkItemResult, error) {
+	if operation.Operation == model.BulkSetStatus || operation.Operation == model.BulkAddHandlers {
+		updatedTasks := []model.Task{}
+		results := make([]model.BulkItemResult, len(taskIds))
+		for i, taskId := range taskIds {
+			results[i] = model.BulkItemResult{TaskID: taskId}
+
+			task, changed, err := r.bulkUpdateTask(ctx, assignerId, taskId, operation)
+			if err != nil {
+				results[i].Error = err.Error()
+				continue
+			}
+
+			// The tasks that already had the change are not returned as updated
+			results[i].Success = true
+			if changed {
+				updatedTasks = append(updatedTasks, task)
+			}
+		}
+
+		return updatedTasks, results, nil
+	}
+
+	// Generate the list of handler IDs as values accepted by the array transforms
+	handlerValues := make([]any, len(operation.HandlerIDs))
+	for i, handlerId := range operation.HandlerIDs {
+		handlerValues[i] = handlerId
+	}
+
+	// Generate the firestore updates of the operation
+	var updates []firestore.Update
+	switch operation.Operation {
+	case model.BulkRemoveHandlers:
+		updates = []firestore.Update{{Path: "handlerIds", Value: firestore.ArrayRemove(handlerValues...)}}
+
+		// The removed handlers no longer have to answer the assignment
+		for _, handlerId := range operation.HandlerIDs {
+			updates = append(updates, firestore.Update{FieldPath: firestore.FieldPath{"assignments", handlerId}, Value: firestore.Delete})
+		}
+	case model.BulkSetDeadline:
+		// A new deadline clears the overdue state of the previous one
+		updates = []firestore.Update{
+			{Path: "deadline", Value: operation.Deadline},
+			{Path: "overdueAt", Value: firestore.Delete},
+			{Path: "escalatedAt", Value: nil},
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported bulk operation `%s`", operation.Operation)
+	}
+
+	// Get the current data of the tasks
+	tasks, results, err := r.getBulkTasks(ctx, taskIds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Generate a new bulk writer that batches the updates
+	bulkWriter := r.client.BulkWriter(ctx)
+
+	// Add an update for each task that was found
+	writeJobs := make([]*firestore.BulkWriterJob, len(tasks))
+	for i, task := range tasks {
+		if task == nil {
+			continue
+		}
+
+		docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(task.ID)
+		job, err := bulkWriter.Update(docRef, updates)
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+
+		writeJobs[i] = job
+	}
+
+	// Commit the updates and wait for them to finish
+	bulkWriter.End()
+
+	// Check the result of each update and apply the change to the returned task data
+	updatedTasks := []model.Task{}
+	for i, job := range writeJobs {
+		if job == nil {
+			continue
+		}
+
+		if _, err := job.Results(); err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+
+		task := applyBulkOperation(*tasks[i], operation)
+		results[i].Success = true
+		updatedTasks = append(updatedTasks, task)
+	}
+
+	return updatedTasks, results, nil
+}
+
+// bulkUpdateTask is a private method that applies a status or a handler change of a bulk operation to a single task.
+// The work in progress limits are checked inside the same transaction as the update
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - assignerId: The ID of the user that assigns the added handlers
+//   - taskId: The ID of the task
+//   - operation: The bulk operation
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - bool: Whether the task was changed, false when it already had the change
+//   - error: An error that occured during the process
+func (r *taskRepository) bulkUpdateTask(ctx context.Context, assignerId, taskId string, operation model.BulkTaskOperation) (model.Task, bool, error) {
+	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
+
+	var task model.Task
+	var changed bool
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		changed = false
+
+		docSnapshot, err := tx.Get(docRef)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("task with ID %s not found", taskId)
+			}
+			return err
+		}
+
+		task = model.Task{}
+		if err := docSnapshot.DataTo(&task); err != nil {
+			return err
+		}
+
+		switch operation.Operation {
+		case model.BulkSetStatus:
+			if task.Status == operation.Status {
+				return nil
+			}
+
+			// The reviewed tasks enter and leave the review only through the review workflow
+			if err := checkReviewTransition(task, operation.Status); err != nil {
+				return err
+			}
+
+			// Check if the task and its handlers fit into the new status column
+			if _, err := checkWipLimits(r.client, tx, task, operation.Status, task.HandlerIDs, operation.Override); err != nil {
+				return err
+			}
+
+			// Record the status change in the task history
+			transition := r.newStatusTransition(task, operation.Status)
+			if err := tx.Create(r.client.Collection(utils.EnvInstances.TRANSITIONS_COLLECTION).Doc(transition.ID), transition); err != nil {
+				return err
+			}
+		case model.BulkAddHandlers:
+			// Check if the handlers that are not assigned yet can take the task
+			var newHandlerIds []string
+			for _, handlerId := range operation.HandlerIDs {
+				if !slices.Contains(task.HandlerIDs, handlerId) {
+					newHandlerIds = append(newHandlerIds, handlerId)
+				}
+			}
+
+			if len(newHandlerIds) == 0 {
+				return nil
+			}
+
+			if _, err := checkWipLimits(r.client, tx, task, task.Status, newHandlerIds, operation.Override); err != nil {
+				return err
+			}
+
+			// Each new handler has to accept the assignment
+			if task.Assignments == nil {
+				task.Assignments = map[string]model.HandlerAssignment{}
+			}
+
+			now := time.Now().UnixMilli()
+			for _, handlerId := range newHandlerIds {
+				task.Assignments[handlerId] = newHandlerAssignment(handlerId, assignerId, now)
+			}
+		}
+
+		task = applyBulkOperation(task, operation)
+		changed = true
+
+		return tx.Set(docRef, task)
+	})
+	if err != nil {
+		return model.Task{}, false, err
+	}
+
+	return task, changed, nil
+}
+
+// BulkDeleteTasks retrieves the data from the service layer and deletes a list of tasks using a bulk writer
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskIds: The list of task IDs
+//
+// Returns:
+//   - []model.Task: The list of deleted tasks
+//   - []model.BulkItemResult: The result of the deletion for each task
+//   - error: An error that occured during the process
+func (r *taskRepository) BulkDeleteTasks(ctx context.Context, taskIds []string) ([]model.Task, []model.BulkItemResult, error) {
+	// Get the current data of the tasks
+	tasks, results, err := r.getBulkTasks(ctx, taskIds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Generate a new bulk writer that batches the deletions
+	bulkWriter := r.client.BulkWriter(ctx)
+
+	// Add a deletion for each task that was found
+	writeJobs := make([]*firestore.BulkWriterJob, len(tasks))
+	for i, task := range tasks {
+		if task == nil {
+			continue
+		}
+
+		docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(task.ID)
+		job, err := bulkWriter.Delete(docRef, firestore.Exists)
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+
+		writeJobs[i] = job
+	}
+
+	// Commit the deletions and wait for them to finish
+	bulkWriter.End()
+
+	// Check the result of each deletion
+	deletedTasks := []model.Task{}
+	for i, job := range writeJobs {
+		if job == nil {
+			continue
+		}
+
+		if _, err := job.Results(); err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+
+		results[i].Success = true
+		deletedTasks = append(deletedTasks, *tasks[i])
+	}
+
+	return deletedTasks, results, nil
+}
+
+// getBulkTasks is a private method that retrieves the data of a list of tasks with a single request
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskIds: The list of task IDs
+//
+// Returns:
+//   - []*model.Task: The list of tasks, nil for the tasks that were not found
+//   - []model.BulkItemResult: The initial result of each task
+//   - error: An error that occured during the process
+func (r *taskRepository) getBulkTasks(ctx context.Context, taskIds []string) ([]*model.Task, []model.BulkItemResult, error) {
+	// Get the task document references
+	docRefs := make([]*firestore.DocumentRef, len(taskIds))
+	for i, taskId := range taskIds {
+		docRefs[i] = r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
+	}
+
+	// Get the document snapshots in the same order as the references
+	docSnapshots, err := r.client.GetAll(ctx, docRefs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tasks := make([]*model.Task, len(taskIds))
+	results := make([]model.BulkItemResult, len(taskIds))
+	for i, doc := range docSnapshots {
+		results[i] = model.BulkItemResult{TaskID: taskIds[i]}
+
+		// Check if the task exists
+		if !doc.Exists() {
+			results[i].Error = fmt.Sprintf("task with ID %s not found", taskIds[i])
+			continue
+		}
+
+		var task model.Task
+		if err := doc.DataTo(&task); err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+
+		tasks[i] = &task
+	}
+
+	return tasks, results, nil
+}
+
+// applyBulkOperation applies a bulk operation to the data of a task the same way the database update does
+//
+// Parameters:
+//   - task: The task data
+//   - operation: The bulk operation
+//
+// Returns:
+//   - model.Task: The updated task data
+func applyBulkOperation(task model.Task, operation model.BulkTaskOperation) model.Task {
+	switch operation.Operation {
+	case model.BulkSetStatus:
+		task.Status = operation.Status
+	case model.BulkAddHandlers:
+		for _, handlerId := range operation.HandlerIDs {
+			if !slices.Contains(task.HandlerIDs, handlerId) {
+				task.HandlerIDs = append(task.HandlerIDs, handlerId)
+			}
+		}
+	case model.BulkRemoveHandlers:
+		var filteredHandlerIds []string
+		for _, handlerId := range task.HandlerIDs {
+			if !slices.Contains(operation.HandlerIDs, handlerId) {
+				filteredHandlerIds = append(filteredHandlerIds, handlerId)
+			}
+		}
+		task.HandlerIDs = filteredHandlerIds
+
+		for _, handlerId := range operation.HandlerIDs {
+			delete(task.Assignments, handlerId)
+		}
+	case model.BulkSetDeadline:
+		task.Deadline = operation.Deadline
+		task.OverdueAt = nil
+		task.EscalatedAt = nil
+	}
+
+	return task
+}
+
+// getSortedTasks is a private method that returns a page of the tasks of a project sorted in memory.
+// The tasks created before a sort field use its default value: the tasks without a rank stay on top of the column
+// in their creation order, the same order the column keeps when it is rebalanced, the tasks without a priority
+// have the medium priority and the tasks without a deadline come after the tasks with a deadline
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskQuery: The filtering, ordering and pagination criteria
+//
+// Returns:
+//   - []model.Task: The list of retrieved tasks
+//   - string: The cursor of the next page, empty if there are no more tasks
+//   - error: An error that occured during the process
+func (r *taskRepository) getSortedTasks(ctx context.Context, taskQuery model.TaskQuery) ([]model.Task, string, error) {
+	query := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Where("projectId", "==", taskQuery.ProjectID)
+	if len(taskQuery.Statuses) > 0 {
+		query = query.Where("status", "in", taskQuery.Statuses)
+	}
+
+	docSnapshots, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, "", err
+	}
+
+	tasks := []model.Task{}
+	for _, doc := range docSnapshots {
+		var task model.Task
+		if err := doc.DataTo(&task); err != nil {
+			return nil, "", err
+		}
+
+		if matchesTaskQuery(task, taskQuery) {
+			tasks = append(tasks, task)
+		}
+	}
+
+	descending := cursorlib.QueryDirection(taskQuery.OrderDirection) == firestore.Desc
+	slices.SortFunc(tasks, func(a, b model.Task) int {
+		order := cmp.Or(compareTaskField(a, b, taskQuery.OrderBy), cmp.Compare(a.CreatedAt, b.CreatedAt), cmp.Compare(a.ID, b.ID))
+		if descending {
+			return -order
+		}
+		return order
+	})
+
+	// Move the page after the last task of the previous page
+	start := 0
+	if taskQuery.Cursor != "" {
+		cursor, err := utils.CursorSigner.Decode(taskQuery.Cursor, taskQuery.OrderBy)
+		if err != nil {
+			return nil, "", err
+		}
+
+		start = slices.IndexFunc(tasks, func(task model.Task) bool { return task.ID == cursor.ID }) + 1
+
+		// The last task no longer matches the query, the page starts after its sort value
+		if start == 0 {
+			cursorTask := sortValueTask(taskQuery.OrderBy, cursor.Value)
+			start = slices.IndexFunc(tasks, func(task model.Task) bool {
+				if descending {
+					return compareTaskField(task, cursorTask, taskQuery.OrderBy) < 0
+				}
+				return compareTaskField(task, cursorTask, taskQuery.OrderBy) > 0
+			})
+			if start == -1 {
+				start = len(tasks)
+			}
+		}
+	}
+
+	tasks = tasks[start:]
+	if len(tasks) <= taskQuery.Limit {
+		return tasks, "", nil
+	}
+
+	// Generate the cursor of the next page
+	lastTask := tasks[taskQuery.Limit-1]
+	nextCursor, err := utils.CursorSigner.Encode(taskQuery.OrderBy, storedSortValue(lastTask, taskQuery.OrderBy), lastTask.ID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return tasks[:taskQuery.Limit], nextCursor, nil
+}
+
+// taskSortValue is a private function that returns the value a task is sorted by in memory,
+// with the default value of the tasks created before the sort field
+//
+// Parameters:
+//   - task: The task data
+//   - field: The sort field
+//
+// Returns:
+//   - any: The sort value of the task
+func taskSortValue(task model.Task, field string) any {
+	switch field {
+	case "priority":
+		if task.PriorityRank == 0 {
+			return model.PriorityRanks[model.PriorityMedium]
+		}
+		return task.PriorityRank
+	case "deadline":
+		if task.Deadline == 0 {
+			return int64(math.MaxInt64)
+		}
+		return task.Deadline
+	default:
+		return task.Rank
+	}
+}
+
+// storedSortValue is a private function that returns the stored value of the field a task is sorted by in memory.
+// The cursors keep the stored value, the default values are applied only when the tasks are compared
+//
+// Parameters:
+//   - task: The task data
+//   - field: The sort field
+//
+// Returns:
+//   - any: The stored sort value of the task
+func storedSortValue(task model.Task, field string) any {
+	switch field {
+	case "priority":
+		return task.PriorityRank
+	case "deadline":
+		return task.Deadline
+	default:
+		return task.Rank
+	}
+}
+
+// sortValueTask is a private function that generates a task with the sort value stored in a cursor
+//
+// Parameters:
+//   - field: The sort field
+//   - value: The sort value decoded from the cursor
+//
+// Returns:
+//   - model.Task: The task with the sort value
+func sortValueTask(field string, value any) model.Task {
+	switch field {
+	case "priority":
+		number, _ := value.(float64)
+		return model.Task{PriorityRank: int(number)}
+	case "deadline":
+		number, _ := value.(float64)
+		return model.Task{Deadline: int64(number)}
+	default:
+		rank, _ := value.(string)
+		return model.Task{Rank: rank}
+	}
+}
+
+// compareTaskField is a private function that compares the sort values of two tasks
+//
+// Parameters:
+//   - a: The first task
+//   - b: The second task
+//   - field: The sort field
+//
+// Returns:
+//   - int: A negative number when the first task comes first, a positive number when it comes last, 0 otherwise
+func compareTaskField(a, b model.Task, field string) int {
+	switch field {
+	case "priority":
+		return cmp.Compare(taskSortValue(a, field).(int), taskSortValue(b, field).(int))
+	case "deadline":
+		return cmp.Compare(taskSortValue(a, field).(int64), taskSortValue(b, field).(int64))
+	default:
+		return cmp.Compare(a.Rank, b.Rank)
+	}
+}
+
+// rebalanceColumn is a private method that generates evenly spaced ranks for a board column with the moved task
+// placed right after its new upper neighbor. It only reads inside the transaction, the caller writes the ranks
+//
+// Parameters:
+//   - tx: The running transaction
+//   - task: The moved task
+//   - taskStatus: The status of the column
+//   - afterId: The ID of the task above the new position, empty for the top of the column
+//
+// Returns:
+//   - map[string]string: The new rank of each task of the column
+//   - error: An error that occured during the process
+func (r *taskRepository) rebalanceColumn(tx *firestore.Transaction, task model.Task, taskStatus, afterId string) (map[string]string, error) {
+	docSnapshots, err := tx.Documents(r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).
+		Where("projectId", "==", task.ProjectID).
+		Where("status", "==", taskStatus)).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(docSnapshots) > maxRebalanceTasks {
+		return nil, fmt.Errorf("the `%s` column has more than %d tasks and cannot be rebalanced", taskStatus, maxRebalanceTasks)
+	}
+
+	column := []model.Task{}
+	for _, doc := range docSnapshots {
+		var columnTask model.Task
+		if err := doc.DataTo(&columnTask); err != nil {
+			return nil, err
+		}
+
+		if columnTask.ID != task.ID {
+			column = append(column, columnTask)
+		}
+	}
+
+	// Keep the current order of the column, the unranked tasks stay on top in their creation order
+	slices.SortFunc(column, func(a, b model.Task) int {
+		return cmp.Or(cmp.Compare(a.Rank, b.Rank), cmp.Compare(a.CreatedAt, b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+
+	// Insert the moved task right after its upper neighbor
+	position := 0
+	if afterId != "" {
+		position = slices.IndexFunc(column, func(columnTask model.Task) bool { return columnTask.ID == afterId }) + 1
+	}
+	column = slices.Insert(column, position, task)
+
+	ranks := evenRanks(len(column))
+
+	rebalanced := make(map[string]string, len(column))
+	for i, columnTask := range column {
+		rebalanced[columnTask.ID] = ranks[i]
+	}
+
+	return rebalanced, nil
+}
+
+// checkWipLimits is a private function that checks if a task can join a status column without exceeding the
+// work in progress limits of its project. The column limit is checked only when the task changes its status and the
+// handler limits are checked for the given handlers. It only reads inside the transaction
+//
+// Parameters:
+//   - client: The firestore client
+//   - tx: The running transaction
+//   - task: The task data before the change
+//   - taskStatus: The status of the column
+//   - handlerIds: The IDs of the handlers that join the column with the task
+//   - override: Whether the exceeded limits are ignored
+//
+// Returns:
+//   - []string: The exceeded limits, only returned when they are overridden
+//   - error: An error that occured during the process
+func checkWipLimits(client *firestore.Client, tx *firestore.Transaction, task model.Task, taskStatus string, handlerIds []string, override bool) ([]string, error) {
+	// Get the work in progress limits of the project
+	docSnapshot, err := tx.Get(client.Collection(utils.EnvInstances.WIP_LIMITS_COLLECTION).Doc(task.ProjectID))
+	if err != nil {
+		// The projects without limits are not bounded
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var limits model.WipLimits
+	if err := docSnapshot.DataTo(&limits); err != nil {
+		return nil, err
+	}
+
+	// Only the document references are read, the limit bounds the number of read documents
+	columnQuery := client.Collection(utils.EnvInstances.TASKS_COLLECTION).
+		Where("projectId", "==", task.ProjectID).
+		Where("status", "==", taskStatus).
+		Select()
+
+	exceeded := []string{}
+	if limit := limits.StatusLimits[taskStatus]; limit > 0 && task.Status != taskStatus {
+		docSnapshots, err := tx.Documents(columnQuery.Limit(limit)).GetAll()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(docSnapshots) >= limit {
+			exceeded = append(exceeded, fmt.Sprintf("the `%s` column is limited to %d tasks", taskStatus, limit))
+		}
 	}
 
-	// update the response text message
-	response.Message = message
+	if limit := limits.HandlerLimits[taskStatus]; limit > 0 {
+		for _, handlerId := range handlerIds {
+			docSnapshots, err := tx.Documents(columnQuery.Where("handlerIds", "array-contains", handlerId).Limit(limit)).GetAll()
+			if err != nil {
+				return nil, err
+			}
 
-	// Update the reponse object into the database
-	_, err = docRef.Set(ctx, response)
-	if err != nil {
-		return model.Response{}, nil
+			if len(docSnapshots) >= limit {
+				exceeded = append(exceeded, fmt.Sprintf("the handler `%s` is limited to %d `%s` tasks", handlerId, limit, taskStatus))
+			}
+		}
 	}
 
-	return response, nil
+	if len(exceeded) > 0 && !override {
+		return nil, fmt.Errorf("%w: %s", utils.ErrWipLimitExceeded, strings.Join(exceeded, ", "))
+	}
 
+	return exceeded, nil
 }
 
-// RerollTaskVersion retrieves the data from the service layer and updates the data of a task to an older version
+// checkReviewTransition is a private function that checks if a status change skips the review workflow.
+// A task enters the review only when a review is requested and a task with reviewers is completed only by an approval
 //
 // Parameters:
-//   - ctx: Request-scoped context
-//   - taskId: The ID of the task
-//   - task: An old task version
+//   - task: The task data before the change
+//   - taskStatus: The new task status
 //
 // Returns:
-//   - model.Task: The updated task version
 //   - error: An error that occured during the process
-func (r *taskRepository) RerollTaskVersion(ctx context.Context, taskId string, task model.Task) (model.Task, error) {
-	// Get the task document reference
-	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
-
-	// Get the document snapshot
-	_, err := docRef.Get(ctx)
-	if err != nil {
-		// Check if the document exists
-		if status.Code(err) == codes.NotFound {
-			return model.Task{}, fmt.Errorf("task with ID `%s` not found", taskId)
-		}
-		return model.Task{}, err
+func checkReviewTransition(task model.Task, taskStatus string) error {
+	if taskStatus == model.TaskStatusInReview {
+		return fmt.Errorf("%w: request a review to move the task `%s` into review", utils.ErrInvalidReview, task.ID)
 	}
 
-	// Reroll the task version to a previous one
-	_, err = docRef.Set(ctx, task)
-	if err != nil {
-		return model.Task{}, err
+	if taskStatus == model.TaskStatusCompleted && len(task.ReviewerIDs) > 0 {
+		return fmt.Errorf("%w: the task `%s` has reviewers", utils.ErrReviewRequired, task.ID)
 	}
 
-	return task, nil
+	return nil
 }
 
-// RerollSubtaskVersion retrieves the data from the service layer and updates a subtask of a task to an older version
+// newHandlerAssignment is a private function that generates the assignment of a new task handler.
+// The users that assign themselves accept the assignment right away
 //
 // Parameters:
-//   - ctx: Reqeust-scoped context
-//   - taskId: The ID of the task the subtask is part of
-//   - subtaskId: The ID of the subtask
-//   - subtask: The old subtask version
+//   - handlerId: The ID of the handler
+//   - assignerId: The ID of the user that assigns the handler
+//   - assignedAt: The date of the assignment
 //
 // Returns:
-//   - model.Subtask: The updated subtask version
-//   - error: An error that occured during the process
-func (r *taskRepository) RerollSubtaskVersion(ctx context.Context, taskId, subtaskId string, subtask model.Subtask) (model.Subtask, error) {
-	// Get the subtask document reference
-	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId).Collection(utils.EnvInstances.TASKS_SUBCOLLECTION).Doc(subtaskId)
-
-	// Get the document snapshot
-	_, err := docRef.Get(ctx)
-	if err != nil {
-		// Check if the subtask exists
-		if status.Code(err) == codes.NotFound {
-			return model.Subtask{}, fmt.Errorf("subtask with ID `%s` not found", subtaskId)
-		}
-		return model.Subtask{}, err
+//   - model.HandlerAssignment: The handler assignment
+func newHandlerAssignment(handlerId, assignerId string, assignedAt int64) model.HandlerAssignment {
+	assignment := model.HandlerAssignment{
+		State:      model.AssignmentPending,
+		AssignedBy: assignerId,
+		AssignedAt: assignedAt,
 	}
 
-	// Reroll the subtask version
-	_, err = docRef.Set(ctx, subtask)
-	if err != nil {
-		return model.Subtask{}, err
+	if handlerId == assignerId {
+		assignment.State = model.AssignmentAccepted
+		assignment.AnsweredAt = &assignedAt
 	}
 
-	return subtask, err
+	return assignment
 }
 
-// DeleteTaskById retrieves the data from the service layer and deletes the task
+// moveTaskHistory is a private method that moves the work logs and the status transitions of a task to another project.
+// The documents are updated using a bulk writer, so the history of a task is not limited by the transaction size
 //
 // Parameters:
 //   - ctx: Request-scoped context
-//   - taskId: The ID of the task to delete
+//   - taskId: The ID of the moved task
+//   - projectId: The ID of the target project
 //
 // Returns:
-//   - model.Task: The data of the deleted task
 //   - error: An error that occured during the process
-func (r *taskRepository) DeleteTaskById(ctx context.Context, taskId string) (model.Task, error) {
-	// Get the task document reference
-	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
+func (r *taskRepository) moveTaskHistory(ctx context.Context, taskId, projectId string) error {
+	queries := []firestore.Query{
+		r.client.Collection(utils.EnvInstances.WORKLOGS_COLLECTION).Where("taskId", "==", taskId),
+		r.client.Collection(utils.EnvInstances.TRANSITIONS_COLLECTION).Where("taskId", "==", taskId),
+	}
 
-	// Get the document snapshot
-	docSnapshot, err := docRef.Get(ctx)
+	// Generate a new bulk writer that batches the updates
+	bulkWriter := r.client.BulkWriter(ctx)
 
-	// Check if the document exists
-	if err != nil {
-		if status.Code(err) == codes.NotFound {
-			return model.Task{}, fmt.Errorf("task with ID %s not found", taskId)
+	var updateJobs []*firestore.BulkWriterJob
+	for _, query := range queries {
+		docSnapshots, err := query.Documents(ctx).GetAll()
+		if err != nil {
+			bulkWriter.End()
+			return err
+		}
+
+		for _, doc := range docSnapshots {
+			job, err := bulkWriter.Update(doc.Ref, []firestore.Update{{Path: "projectId", Value: projectId}})
+			if err != nil {
+				bulkWriter.End()
+				return err
+			}
+
+			updateJobs = append(updateJobs, job)
 		}
-		return model.Task{}, err
 	}
 
-	// Add the snapshot data to the task object
-	var task model.Task
-	if err = docSnapshot.DataTo(&task); err != nil {
-		return model.Task{}, err
+	// Commit the updates and wait for them to finish
+	bulkWriter.End()
+
+	// Check if any of the updates failed
+	for _, job := range updateJobs {
+		if _, err := job.Results(); err != nil {
+			return err
+		}
 	}
 
-	// Delete the task from the database
-	docRef.Delete(ctx)
+	return nil
+}
 
-	return task, nil
+// newStatusTransition is a private method that generates the history entry of a task status change
+//
+// Parameters:
+//   - task: The task data before the change
+//   - taskStatus: The new task status
+//
+// Returns:
+//   - model.StatusTransition: The status transition
+func (r *taskRepository) newStatusTransition(task model.Task, taskStatus string) model.StatusTransition {
+	return model.StatusTransition{
+		ID:        r.client.Collection(utils.EnvInstances.TRANSITIONS_COLLECTION).NewDoc().ID,
+		TaskID:    task.ID,
+		ProjectID: task.ProjectID,
+		From:      task.Status,
+		To:        taskStatus,
+		ChangedAt: time.Now().UnixMilli(),
+	}
 }
 
-// DeleteSubtaskById retrieves the data from the service layer and deletes a subtask of a task
+// collectTasks is a private function that pages through a tasks query until
+// it finds the requested number of tasks that match all the filters
 //
 // Parameters:
 //   - ctx: Request-scoped context
-//   - taskId: The ID of the task the subtask is part of
-//   - subtaskId: The ID of the subtask
+//   - query: The ordered tasks query
+//   - taskQuery: The filtering criteria
 //
 // Returns:
-//   - model.Subtask: The data of the deleted subtask
+//   - []model.Task: The list of matching tasks
+//   - string: The cursor of the next page, empty if there are no more tasks
 //   - error: An error that occured during the process
-func (r *taskRepository) DeleteSubtaskById(ctx context.Context, taskId string, subtaskId string) (model.Subtask, error) {
-	// Get the task reference
-	taskRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
-	// Get the subtask document reference
-	subtaskRef := taskRef.Collection(utils.EnvInstances.TASKS_SUBCOLLECTION).Doc(subtaskId)
-
-	var deletedSubtask model.Subtask
+func collectTasks(ctx context.Context, query firestore.Query, taskQuery model.TaskQuery) ([]model.Task, string, error) {
+	tasks := []model.Task{}
+	var lastDoc *firestore.DocumentSnapshot
+	pageQuery := query.Limit(taskQuery.Limit + 1)
 
-	// Run a transaction to avoid errors while updating the parent task
-	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
-		// Get the subtask snapshot
-		subtaskSnapshot, err := tx.Get(subtaskRef)
+	for {
+		// Get the tasks snapshots of the current page
+		docs, err := pageQuery.Documents(ctx).GetAll()
 		if err != nil {
-			// Check if the subtask was not found
-			if status.Code(err) == codes.NotFound {
-				return fmt.Errorf("subtask with ID %s not found", subtaskId)
+			return nil, "", err
+		}
+
+		// Iterate over the snapshots
+		for _, doc := range docs {
+			var task model.Task
+
+			// Add the data of each snapshot to the tasks list
+			if err := doc.DataTo(&task); err != nil {
+				return nil, "", err
 			}
 
-			return err
-		}
+			if !matchesTaskQuery(task, taskQuery) {
+				continue
+			}
 
-		// Add the subtask data to the variable in order to return it
-		if err := subtaskSnapshot.DataTo(&deletedSubtask); err != nil {
-			return err
-		}
+			// Another matching task means there is a next page
+			// that starts after the last returned task
+			if len(tasks) == taskQuery.Limit {
+				nextCursor, err := utils.CursorSigner.NextPageCursor(lastDoc, sortableTaskFields[taskQuery.OrderBy])
+				if err != nil {
+					return nil, "", err
+				}
 
-		// Delete the subtask
-		tx.Delete(subtaskRef)
+				return tasks, nextCursor, nil
+			}
 
-		// Decrement subtask counter
-		updates := []firestore.Update{
-			{Path: "subtaskCount", Value: firestore.Increment(-1)},
+			tasks = append(tasks, task)
+			lastDoc = doc
 		}
 
-		// If the subtask is complete, decrement the complete subtasks counter
-		if deletedSubtask.Done == true {
-			updates = append(updates, firestore.Update{
-				Path:  "completedSubtaskCount",
-				Value: firestore.Increment(-1),
-			})
+		// Stop when there are no more tasks to read
+		if len(docs) < taskQuery.Limit+1 {
+			return tasks, "", nil
 		}
 
-		// Apply the counter updates on the parent task
-		return tx.Update(taskRef, updates)
-	})
-	if err != nil {
-		return model.Subtask{}, err
+		pageQuery = query.StartAfter(docs[len(docs)-1]).Limit(taskQuery.Limit + 1)
 	}
-
-	return deletedSubtask, nil
 }
 
-// DeleteResopnseById retrieves the data from the service layer and deletes the task resposne
+// matchesTaskQuery is a private function that checks if a task passes all the filters of a query
 //
 // Parameters:
-//   - ctx: Request-scoped context
-//   - taskId: The ID of the task the response is part of
-//   - responseId: The ID of the task response
+//   - task: The task data
+//   - taskQuery: The filtering criteria
 //
 // Returns:
-//   - model.Response: The data of the deleted response
-//   - error: An error that occured during the process
-func (r *taskRepository) DeleteResponseById(ctx context.Context, taskId, responseId string) (model.Response, error) {
-	// Get the task reference
-	taskRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
-	// Get the response document reference
-	responseRef := taskRef.Collection(utils.EnvInstances.RESPONSES_COLLECTION).Doc(responseId)
+//   - bool: True if the task matches the filters
+func matchesTaskQuery(task model.Task, taskQuery model.TaskQuery) bool {
+	if len(taskQuery.Statuses) > 0 && !slices.Contains(taskQuery.Statuses, task.Status) {
+		return false
+	}
 
-	var deletedResponse model.Response
+	if len(taskQuery.Priorities) > 0 && !slices.Contains(taskQuery.Priorities, task.Priority) {
+		return false
+	}
 
-	// Run a transaction to avoid update errors in the parent task
-	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
-		reponseSnapshot, err := tx.Get(responseRef)
-		if err != nil {
-			// Check if the response was not found
-			if status.Code(err) == codes.NotFound {
-				return fmt.Errorf("response with ID %s not found", responseId)
-			}
-			return err
-		}
+	if taskQuery.AuthorID != "" && task.AuthorID != taskQuery.AuthorID {
+		return false
+	}
 
-		// Add the data of the resposne to the variable to return it
-		if err := reponseSnapshot.DataTo(&deletedResponse); err != nil {
-			return err
-		}
+	if taskQuery.HandlerID != "" && !slices.Contains(task.HandlerIDs, taskQuery.HandlerID) {
+		return false
+	}
 
-		// Delte the response
-		tx.Delete(responseRef)
+	if len(taskQuery.Labels) > 0 && !slices.ContainsFunc(taskQuery.Labels, func(label string) bool { return slices.Contains(task.Labels, label) }) {
+		return false
+	}
 
-		// Decremetn the task resposne counter
-		updates := []firestore.Update{
-			{Path: "responseCount", Value: firestore.Increment(-1)},
+	if !inRange(task.Deadline, taskQuery.DeadlineFrom, taskQuery.DeadlineTo) {
+		return false
+	}
+
+	if !inRange(task.CreatedAt, taskQuery.CreatedFrom, taskQuery.CreatedTo) {
+		return false
+	}
+
+	if taskQuery.HasOpenSubtasks != nil {
+		hasOpenSubtasks := task.SubtaskCount > task.CompletedSubtaskCount
+		if hasOpenSubtasks != *taskQuery.HasOpenSubtasks {
+			return false
 		}
+	}
 
-		// Apply the counter updates to the parent task
-		return tx.Update(taskRef, updates)
-	})
-	if err != nil {
-		return model.Response{}, err
+	if taskQuery.PendingAcceptance != nil {
+		pendingAcceptance := slices.ContainsFunc(task.HandlerIDs, func(handlerId string) bool {
+			return task.Assignments[handlerId].State == model.AssignmentPending
+		})
+		if pendingAcceptance != *taskQuery.PendingAcceptance {
+			return false
+		}
 	}
 
-	return deletedResponse, nil
+	return true
 }
 
-// DeleteTaskSubcollections retrieves the data from the service layer
-// and deletes the responses and the subtasks of a deleted task
+// addRangeFilter is a private function that adds the optional bounds of a field to a query
 //
 // Parameters:
-//   - ctx: Request-scoped context
-//   - taskId: The ID of the deleted task
+//   - query: The query
+//   - field: The name of the field
+//   - from: The inclusive lower bound
+//   - to: The inclusive upper bound
 //
 // Returns:
-//   - string: The success message
-//   - error: An error that occured during the process
-func (r *taskRepository) DeleteTaskSubcollections(ctx context.Context, taskId string, batchSize int) (string, error) {
-	// Generate a new wait group for both subcollections to be deleted
-	var wg sync.WaitGroup
-
-	// Generate a new channel that recieves two error messages
-	errChan := make(chan error, 2)
+//   - firestore.Query: The filtered query
+func addRangeFilter(query firestore.Query, field string, from, to *int64) firestore.Query {
+	if from != nil {
+		query = query.Where(field, ">=", *from)
+	}
+	if to != nil {
+		query = query.Where(field, "<=", *to)
+	}
 
-	// Generate a new function in order to delete both subcollections using multi-threads
-	deleteCollection := func(colRef *firestore.CollectionRef) {
-		defer wg.Done()
+	return query
+}
 
-		// Generate a new bulk writer that will delete the documents
-		bulkWriter := r.client.BulkWriter(ctx)
+// inRange is a private function that checks if a value is between the optional bounds
+//
+// Parameters:
+//   - value: The value to check
+//   - from: The inclusive lower bound
+//   - to: The inclusive upper bound
+//
+// Returns:
+//   - bool: True if the value is inside the bounds
+func inRange(value int64, from, to *int64) bool {
+	if from != nil && value < *from {
+		return false
+	}
+	if to != nil && value > *to {
+		return false
+	}
 
-		// Iterate over each collection and retrieve a `limit` number of documents at a time
-		for {
-			iter := colRef.Limit(batchSize).Documents(ctx)
-			numDeleted := 0
+	return true
+}
 
-			// For each list of documentes iterate and perform the deletion operation
-			for {
-				// Get the next document
-				doc, err := iter.Next()
+// updateTaskLabels is a private method that applies an array transform to the labels of a task
+// and returns the updated task data
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - transform: The firestore array transform
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - error: An error that occured during the process
+func (r *taskRepository) updateTaskLabels(ctx context.Context, taskId string, transform any) (model.Task, error) {
+	// Get the task document reference
+	docRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId)
 
-				// Check if the iterator reach the last element
-				if err == iterator.Done {
-					break
-				}
+	// Update the labels of the task
+	if _, err := docRef.Update(ctx, []firestore.Update{{Path: "labels", Value: transform}}); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return model.Task{}, fmt.Errorf("task with ID %s not found", taskId)
+		}
+		return model.Task{}, err
+	}
 
-				// If an error occured, add it to the error channel
-				if err != nil {
-					errChan <- err
-					return
-				}
+	// Get the updated task data
+	docSnapshot, err := docRef.Get(ctx)
+	if err != nil {
+		return model.Task{}, err
+	}
 
-				// Delete the document reference and increment the number of documents deleted
-				bulkWriter.Delete(doc.Ref)
-				numDeleted++
-			}
+	var task model.Task
+	if err = docSnapshot.DataTo(&task); err != nil {
+		return model.Task{}, err
+	}
 
-			// If there were no documents deleted, end the process
-			if numDeleted == 0 {
-				bulkWriter.End()
-				break
-			}
+	return task, nil
+}
 
-			// Commit all the processes
-			bulkWriter.Flush()
-		}
+// labelValues is a private function that converts a list of labels to values accepted by the array transforms
+//
+// Parameters:
+//   - labels: The list of label names
+//
+// Returns:
+//   - []any: The list of values
+func labelValues(labels []string) []any {
+	values := make([]any, len(labels))
+	for i, label := range labels {
+		values[i] = label
 	}
 
-	// Get the subtasks collection reference
-	subtasksRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId).Collection(utils.EnvInstances.TASKS_SUBCOLLECTION)
-	responsesRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(taskId).Collection(utils.EnvInstances.RESPONSES_COLLECTION)
+	return values
+}
 
-	// Add each process
-	wg.Add(2)
-	go deleteCollection(subtasksRef)
-	go deleteCollection(responsesRef)
+// hasDependencyPath is a private function that checks if a task blocks another task,
+// directly or through other tasks, using a depth-first search over the dependency graph
+//
+// Parameters:
+//   - graph: The list of blocked task IDs of each task
+//   - from: The ID of the blocking task
+//   - to: The ID of the blocked task
+//
+// Returns:
+//   - bool: True if there is a path between the tasks and false otherwise
+func hasDependencyPath(graph map[string][]string, from, to string) bool {
+	visited := map[string]bool{}
+	stack := []string{from}
 
-	// Wait for each process to finish the execution
-	wg.Wait()
+	for len(stack) > 0 {
+		taskId := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
 
-	// Close the error channel
-	close(errChan)
+		if taskId == to {
+			return true
+		}
 
-	// Iterate over the error channel and check if there is an error
-	for err := range errChan {
-		if err != nil {
-			return "", err
+		if visited[taskId] {
+			continue
 		}
+		visited[taskId] = true
+
+		stack = append(stack, graph[taskId]...)
 	}
 
-	return "OK", nil
+	return false
 }
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	firestore "cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+type templateRepository struct {
+	client *firestore.Client
+}
+
+func NewTemplateRepository(client *firestore.Client) interfaces.TemplateRepository {
+	return &templateRepository{client: client}
+}
+
+// CreateTemplate retrieves the data from the service layer and adds a new task template into the database
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - template: The template object
+//
+// Returns:
+//   - model.TaskTemplate: The created template data
+//   - error: An error that occured during the process
+func (r *templateRepository) CreateTemplate(ctx context.Context, template model.TaskTemplate) (model.TaskTemplate, error) {
+	// Add the new template object into the templates collection using the ID of the template object
+	_, err := r.client.Collection(utils.EnvInstances.TEMPLATES_COLLECTION).Doc(template.ID).Create(ctx, template)
+	if err != nil {
+		return model.TaskTemplate{}, err
+	}
+
+	return template, nil
+}
+
+// GetProjectTemplates retrieves the task templates of a project ordered by their creation date
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - projectId: The ID of the project
+//
+// Returns:
+//   - []model.TaskTemplate: The list of project templates
+//   - error: An error that occured during the process
+func (r *templateRepository) GetProjectTemplates(ctx context.Context, projectId string) ([]model.TaskTemplate, error) {
+	// Get the template documents of the project
+	docSnapshots, err := r.client.Collection(utils.EnvInstances.TEMPLATES_COLLECTION).
+		Where("projectId", "==", projectId).
+		OrderBy("createdAt", firestore.Asc).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Iterate over the document snapshots
+	templates := []model.TaskTemplate{}
+	for _, doc := range docSnapshots {
+		var template model.TaskTemplate
+
+		// Add the snapshot data of each document to the template object
+		if err := doc.DataTo(&template); err != nil {
+			return nil, err
+		}
+
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
+
+// GetTemplateById retrieves the data from the service layer and returns the data of the task template
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - templateId: The ID of the template
+//
+// Returns:
+//   - model.TaskTemplate: The data of the template
+//   - error: An error that occured during the process
+func (r *templateRepository) GetTemplateById(ctx context.Context, templateId string) (model.TaskTemplate, error) {
+	// Get the template document snapshot
+	docSnapshot, err := r.client.Collection(utils.EnvInstances.TEMPLATES_COLLECTION).Doc(templateId).Get(ctx)
+	if err != nil {
+		// Check if the template exists
+		if status.Code(err) == codes.NotFound {
+			return model.TaskTemplate{}, fmt.Errorf("template with ID %s not found", templateId)
+		}
+		return model.TaskTemplate{}, err
+	}
+
+	// Add the snapshot data to the template object
+	var template model.TaskTemplate
+	if err = docSnapshot.DataTo(&template); err != nil {
+		return model.TaskTemplate{}, err
+	}
+
+	return template, nil
+}
+
+// DeleteTemplate retrieves the data from the service layer and removes the task template from the database
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - templateId: The ID of the template
+//
+// Returns:
+//   - error: An error that occured during the process
+func (r *templateRepository) DeleteTemplate(ctx context.Context, templateId string) error {
+	_, err := r.client.Collection(utils.EnvInstances.TEMPLATES_COLLECTION).Doc(templateId).Delete(ctx)
+	return err
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+
+	firestore "cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+type wipRepository struct {
+	client *firestore.Client
+}
+
+func NewWipRepository(client *firestore.Client) interfaces.WipRepository {
+	return &wipRepository{client: client}
+}
+
+// GetWipLimits retrieves the data from the service layer and returns the work in progress limits of a project
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - projectId: The ID of the project
+//
+// Returns:
+//   - model.WipLimits: The work in progress limits, empty when the project has no limits
+//   - error: An error that occured during the process
+func (r *wipRepository) GetWipLimits(ctx context.Context, projectId string) (model.WipLimits, error) {
+	// The limits document has the ID of its project
+	docSnapshot, err := r.client.Collection(utils.EnvInstances.WIP_LIMITS_COLLECTION).Doc(projectId).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return model.WipLimits{ProjectID: projectId, StatusLimits: map[string]int{}, HandlerLimits: map[string]int{}}, nil
+		}
+		return model.WipLimits{}, err
+	}
+
+	// Add the snapshot data to the limits object
+	var limits model.WipLimits
+	if err = docSnapshot.DataTo(&limits); err != nil {
+		return model.WipLimits{}, err
+	}
+
+	return limits, nil
+}
+
+// SetWipLimits retrieves the data from the service layer and replaces the work in progress limits of a project
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - limits: The new work in progress limits
+//
+// Returns:
+//   - model.WipLimits: The stored work in progress limits
+//   - error: An error that occured during the process
+func (r *wipRepository) SetWipLimits(ctx context.Context, limits model.WipLimits) (model.WipLimits, error) {
+	if _, err := r.client.Collection(utils.EnvInstances.WIP_LIMITS_COLLECTION).Doc(limits.ProjectID).Set(ctx, limits); err != nil {
+		return model.WipLimits{}, err
+	}
+
+	return limits, nil
+}
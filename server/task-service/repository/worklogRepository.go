@@ -0,0 +1,354 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	firestore "cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+type worklogRepository struct {
+	client *firestore.Client
+}
+
+func NewWorkLogRepository(client *firestore.Client) interfaces.WorkLogRepository {
+	return &worklogRepository{client: client}
+}
+
+// CreateWorkLog retrieves the data from the service layer and adds a new work log into the database.
+// The logged time of the task and of the subtask is increased inside the same transaction
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - worklog: The work log object
+//
+// Returns:
+//   - model.WorkLog: The created work log
+//   - error: An error that occured during the process
+func (r *worklogRepository) CreateWorkLog(ctx context.Context, worklog model.WorkLog) (model.WorkLog, error) {
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		return r.writeWorkLog(tx, worklog)
+	})
+	if err != nil {
+		return model.WorkLog{}, err
+	}
+
+	return worklog, nil
+}
+
+// GetWorkLogById retrieves the data from the service layer and returns the data of the work log
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - worklogId: The ID of the work log
+//
+// Returns:
+//   - model.WorkLog: The data of the work log
+//   - error: An error that occured during the process
+func (r *worklogRepository) GetWorkLogById(ctx context.Context, worklogId string) (model.WorkLog, error) {
+	// Get the work log document snapshot
+	docSnapshot, err := r.client.Collection(utils.EnvInstances.WORKLOGS_COLLECTION).Doc(worklogId).Get(ctx)
+	if err != nil {
+		// Check if the work log exists
+		if status.Code(err) == codes.NotFound {
+			return model.WorkLog{}, fmt.Errorf("work log with ID %s not found", worklogId)
+		}
+		return model.WorkLog{}, err
+	}
+
+	// Add the snapshot data to the work log object
+	var worklog model.WorkLog
+	if err = docSnapshot.DataTo(&worklog); err != nil {
+		return model.WorkLog{}, err
+	}
+
+	return worklog, nil
+}
+
+// GetTaskWorkLogs retrieves the work logs of a task and of its subtasks ordered by their start date
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//
+// Returns:
+//   - []model.WorkLog: The list of task work logs
+//   - error: An error that occured during the process
+func (r *worklogRepository) GetTaskWorkLogs(ctx context.Context, taskId string) ([]model.WorkLog, error) {
+	query := r.client.Collection(utils.EnvInstances.WORKLOGS_COLLECTION).
+		Where("taskId", "==", taskId).
+		OrderBy("startedAt", firestore.Asc)
+
+	return collectWorkLogs(ctx, query)
+}
+
+// GetWorkLogs retrieves the work logs of a project and/or of a user started inside a date range
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - worklogQuery: The project, the user and the date range of the work logs
+//
+// Returns:
+//   - []model.WorkLog: The list of work logs ordered by their start date
+//   - error: An error that occured during the process
+func (r *worklogRepository) GetWorkLogs(ctx context.Context, worklogQuery model.WorkLogQuery) ([]model.WorkLog, error) {
+	query := r.client.Collection(utils.EnvInstances.WORKLOGS_COLLECTION).Query
+
+	if worklogQuery.ProjectID != "" {
+		query = query.Where("projectId", "==", worklogQuery.ProjectID)
+	}
+
+	if worklogQuery.UserID != "" {
+		query = query.Where("userId", "==", worklogQuery.UserID)
+	}
+
+	query = query.Where("startedAt", ">=", worklogQuery.From).
+		Where("startedAt", "<", worklogQuery.To).
+		OrderBy("startedAt", firestore.Asc)
+
+	return collectWorkLogs(ctx, query)
+}
+
+// DeleteWorkLog removes a work log from the database and decreases the logged time of its task and subtask
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - worklogId: The ID of the work log
+//
+// Returns:
+//   - model.WorkLog: The deleted work log
+//   - error: An error that occured during the process
+func (r *worklogRepository) DeleteWorkLog(ctx context.Context, worklogId string) (model.WorkLog, error) {
+	worklogRef := r.client.Collection(utils.EnvInstances.WORKLOGS_COLLECTION).Doc(worklogId)
+
+	var worklog model.WorkLog
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Get the work log data
+		worklogSnapshot, err := tx.Get(worklogRef)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("work log with ID %s not found", worklogId)
+			}
+			return err
+		}
+
+		if err := worklogSnapshot.DataTo(&worklog); err != nil {
+			return err
+		}
+
+		// The task or the subtask may have been deleted since the time was logged
+		refs := r.loggedTimeRefs(worklog)
+		existing := []*firestore.DocumentRef{}
+		for _, ref := range refs {
+			snapshot, err := tx.Get(ref)
+			if err != nil {
+				if status.Code(err) == codes.NotFound {
+					continue
+				}
+				return err
+			}
+
+			existing = append(existing, snapshot.Ref)
+		}
+
+		if err := tx.Delete(worklogRef); err != nil {
+			return err
+		}
+
+		for _, ref := range existing {
+			if err := tx.Update(ref, []firestore.Update{{Path: "loggedTime", Value: firestore.Increment(-worklog.Duration)}}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return model.WorkLog{}, err
+	}
+
+	return worklog, nil
+}
+
+// StartTimer adds the running timer of a user into the database
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - timer: The timer object
+//
+// Returns:
+//   - model.Timer: The started timer
+//   - error: An error that occured during the process
+func (r *worklogRepository) StartTimer(ctx context.Context, timer model.Timer) (model.Timer, error) {
+	// The timer document is keyed by the user, so a second timer cannot be created
+	_, err := r.client.Collection(utils.EnvInstances.TIMERS_COLLECTION).Doc(timer.UserID).Create(ctx, timer)
+	if err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			return model.Timer{}, utils.ErrTimerRunning
+		}
+		return model.Timer{}, err
+	}
+
+	return timer, nil
+}
+
+// GetTimer retrieves the running timer of a user
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user
+//
+// Returns:
+//   - model.Timer: The running timer
+//   - error: An error that occured during the process
+func (r *worklogRepository) GetTimer(ctx context.Context, userId string) (model.Timer, error) {
+	docSnapshot, err := r.client.Collection(utils.EnvInstances.TIMERS_COLLECTION).Doc(userId).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return model.Timer{}, utils.ErrNoTimer
+		}
+		return model.Timer{}, err
+	}
+
+	var timer model.Timer
+	if err = docSnapshot.DataTo(&timer); err != nil {
+		return model.Timer{}, err
+	}
+
+	return timer, nil
+}
+
+// StopTimer removes the running timer of a user and turns it into a work log inside a transaction
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user
+//   - stop: The function that builds the work log from the running timer
+//
+// Returns:
+//   - model.WorkLog: The created work log
+//   - error: An error that occured during the process
+func (r *worklogRepository) StopTimer(ctx context.Context, userId string, stop func(timer model.Timer) (model.WorkLog, error)) (model.WorkLog, error) {
+	timerRef := r.client.Collection(utils.EnvInstances.TIMERS_COLLECTION).Doc(userId)
+
+	var worklog model.WorkLog
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Get the timer data
+		timerSnapshot, err := tx.Get(timerRef)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return utils.ErrNoTimer
+			}
+			return err
+		}
+
+		var timer model.Timer
+		if err := timerSnapshot.DataTo(&timer); err != nil {
+			return err
+		}
+
+		worklog, err = stop(timer)
+		if err != nil {
+			return err
+		}
+
+		if err := r.writeWorkLog(tx, worklog); err != nil {
+			return err
+		}
+
+		return tx.Delete(timerRef)
+	})
+	if err != nil {
+		return model.WorkLog{}, err
+	}
+
+	return worklog, nil
+}
+
+// writeWorkLog is a private method that adds a work log inside a transaction and increases
+// the logged time of its task and subtask. All the reads are done before the writes
+//
+// Parameters:
+//   - tx: The running transaction
+//   - worklog: The work log object
+//
+// Returns:
+//   - error: An error that occured during the process
+func (r *worklogRepository) writeWorkLog(tx *firestore.Transaction, worklog model.WorkLog) error {
+	refs := r.loggedTimeRefs(worklog)
+
+	// Check that the task and the subtask still exist
+	for _, ref := range refs {
+		if _, err := tx.Get(ref); err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("document with ID %s not found", ref.ID)
+			}
+			return err
+		}
+	}
+
+	if err := tx.Create(r.client.Collection(utils.EnvInstances.WORKLOGS_COLLECTION).Doc(worklog.ID), worklog); err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		if err := tx.Update(ref, []firestore.Update{{Path: "loggedTime", Value: firestore.Increment(worklog.Duration)}}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loggedTimeRefs is a private method that returns the documents whose logged time includes the work log
+//
+// Parameters:
+//   - worklog: The work log object
+//
+// Returns:
+//   - []*firestore.DocumentRef: The task reference followed by the subtask reference
+func (r *worklogRepository) loggedTimeRefs(worklog model.WorkLog) []*firestore.DocumentRef {
+	taskRef := r.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Doc(worklog.TaskID)
+	refs := []*firestore.DocumentRef{taskRef}
+
+	if worklog.SubtaskID != "" {
+		refs = append(refs, taskRef.Collection(utils.EnvInstances.TASKS_SUBCOLLECTION).Doc(worklog.SubtaskID))
+	}
+
+	return refs
+}
+
+// collectWorkLogs is a private function that runs a query and converts the snapshots into work logs
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - query: The work log query
+//
+// Returns:
+//   - []model.WorkLog: The list of work logs
+//   - error: An error that occured during the process
+func collectWorkLogs(ctx context.Context, query firestore.Query) ([]model.WorkLog, error) {
+	docSnapshots, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Iterate over the document snapshots
+	worklogs := []model.WorkLog{}
+	for _, doc := range docSnapshots {
+		var worklog model.WorkLog
+		if err := doc.DataTo(&worklog); err != nil {
+			return nil, err
+		}
+
+		worklogs = append(worklogs, worklog)
+	}
+
+	return worklogs, nil
+}
@@ -3,16 +3,20 @@ package router
 import (
 	"context"
 	"net/http"
+	"time"
 
-	"firebase.google.com/go/v4/auth"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
 	"github.com/horatiucrisan/task-service/controller"
 	"github.com/horatiucrisan/task-service/firebase"
 	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/jobs"
 	"github.com/horatiucrisan/task-service/middleware"
+	"github.com/horatiucrisan/task-service/model"
 	"github.com/horatiucrisan/task-service/rabbitmq"
 	"github.com/horatiucrisan/task-service/repository"
+	"github.com/horatiucrisan/task-service/scheduler"
+	"github.com/horatiucrisan/task-service/search"
 	"github.com/horatiucrisan/task-service/service"
 	"github.com/horatiucrisan/task-service/utils"
 )
@@ -51,58 +55,303 @@ func NewRouter(userProducer *rabbitmq.UserProducer, loggerProducer, notification
 
 	// Initialize the repository layer
 	taskRepo := repository.NewTaskRepository(firebaseClient)
+	projectRepo := repository.NewProjectRepository(firebaseClient)
+	jobRepo := repository.NewJobRepository(firebaseClient)
+	templateRepo := repository.NewTemplateRepository(firebaseClient)
+	recurrenceRepo := repository.NewRecurrenceRepository(firebaseClient)
+	deadlineRepo := repository.NewDeadlineRepository(firebaseClient)
+	worklogRepo := repository.NewWorkLogRepository(firebaseClient)
+	sprintRepo := repository.NewSprintRepository(firebaseClient)
+	analyticsRepo := repository.NewAnalyticsRepository(firebaseClient)
+	wipRepo := repository.NewWipRepository(firebaseClient)
+	availabilityRepo := repository.NewAvailabilityRepository(firebaseClient)
 
 	// Initialize the service layer
-	taskService := service.NewTaskService(taskRepo)
+	jobService := service.NewJobService(jobRepo)
+	taskService := service.NewTaskService(taskRepo, projectRepo, worklogRepo, availabilityRepo, jobService)
+	templateService := service.NewTemplateService(templateRepo, taskRepo, projectRepo)
+	recurrenceService := service.NewRecurrenceService(recurrenceRepo, taskRepo, projectRepo)
+	worklogService := service.NewWorkLogService(worklogRepo, taskRepo, projectRepo)
+	sprintService := service.NewSprintService(sprintRepo, taskRepo, projectRepo)
+	wipService := service.NewWipService(wipRepo, projectRepo)
+	availabilityService := service.NewAvailabilityService(availabilityRepo, taskRepo, projectRepo)
+
+	// Get the deadline reminder offsets and the escalation grace period
+	reminderOffsets, err := utils.ParseDurations(utils.EnvInstances.REMINDER_OFFSETS)
+	if err != nil {
+		return nil, err
+	}
+
+	var escalationGrace time.Duration
+	if utils.EnvInstances.ESCALATION_GRACE != "" {
+		if escalationGrace, err = time.ParseDuration(utils.EnvInstances.ESCALATION_GRACE); err != nil {
+			return nil, err
+		}
+	}
+
+	deadlineService := service.NewDeadlineService(deadlineRepo, projectRepo, reminderOffsets, escalationGrace)
+
+	// Get the time the project analytics are cached for
+	var analyticsCacheTTL time.Duration
+	if utils.EnvInstances.ANALYTICS_CACHE_TTL != "" {
+		if analyticsCacheTTL, err = time.ParseDuration(utils.EnvInstances.ANALYTICS_CACHE_TTL); err != nil {
+			return nil, err
+		}
+	}
+
+	analyticsService := service.NewAnalyticsService(analyticsRepo, taskRepo, projectRepo, analyticsCacheTTL)
+
+	// Initialize the search index and keep it in sync with the database
+	searchIndex := search.NewIndex()
+	search.NewWatcher(firebaseClient, searchIndex).Start(ctx)
+	searchService := service.NewSearchService(searchIndex, projectRepo)
+
+	// Initialize the job worker pool and register the job handlers
+	workerPool := jobs.NewWorkerPool(jobRepo, jobs.DefaultWorkers)
+	workerPool.RegisterHandler(model.JobTypeDeleteTaskSubcollections, taskService.DeleteTaskSubcollections)
+	workerPool.Start(ctx)
 
 	// Initialize the controller layer
-	taskController := controller.NewTaskController(taskService, userProducer, loggerProducer, notificationProducer, versionProducer)
+	taskController := controller.NewTaskController(taskService, recurrenceService, userProducer, loggerProducer, notificationProducer, versionProducer)
+	jobController := controller.NewJobController(jobService, loggerProducer)
+	searchController := controller.NewSearchController(searchService, loggerProducer)
+	templateController := controller.NewTemplateController(templateService, userProducer, loggerProducer, notificationProducer, versionProducer)
+	recurrenceController := controller.NewRecurrenceController(recurrenceService, userProducer, loggerProducer, notificationProducer, versionProducer)
+	deadlineController := controller.NewDeadlineController(deadlineService, userProducer, notificationProducer, versionProducer)
+	worklogController := controller.NewWorkLogController(worklogService, loggerProducer)
+	sprintController := controller.NewSprintController(sprintService, loggerProducer, versionProducer)
+	analyticsController := controller.NewAnalyticsController(analyticsService, loggerProducer)
+	wipController := controller.NewWipController(wipService, loggerProducer)
+	availabilityController := controller.NewAvailabilityController(availabilityService, loggerProducer)
+
+	// Initialize the scheduler that runs the periodic routines
+	taskScheduler := scheduler.NewScheduler()
+	taskScheduler.Register("recurring-tasks", scheduler.DefaultInterval, recurrenceController.CreateDueOccurrences)
+	taskScheduler.Register("deadline-notifications", scheduler.DefaultInterval, deadlineController.SendDeadlineNotifications)
+	taskScheduler.Start(ctx)
 
 	// Initialize the routes
-	taskRoutes(r, authClient, taskController)
+	r.Route(utils.EnvInstances.ROUTE, func(r chi.Router) {
+		// Use the user token validation method
+		r.Use(middleware.AuthMiddleware(authClient))
+
+		jobRoutes(r, jobController)
+		searchRoutes(r, searchController)
+		templateRoutes(r, templateController)
+		recurrenceRoutes(r, recurrenceController)
+		worklogRoutes(r, worklogController)
+		sprintRoutes(r, sprintController)
+		analyticsRoutes(r, analyticsController)
+		wipRoutes(r, wipController)
+		availabilityRoutes(r, availabilityController)
+		taskRoutes(r, taskController)
+	})
 
 	return r, nil
 }
 
+// jobRoutes initializes the background job routes
+//
+// Parameters:
+//   - r: The go chi router
+//   - jobController: The job controller layer object
+func jobRoutes(r chi.Router, jobController interfaces.JobController) {
+	// GET routes
+	r.Get("/jobs/{jobId}", jobController.GetJobById)
+
+	// DELETE routes
+	r.Delete("/jobs/{jobId}", jobController.CancelJob)
+}
+
+// searchRoutes initializes the search routes
+//
+// Parameters:
+//   - r: The go chi router
+//   - searchController: The search controller layer object
+func searchRoutes(r chi.Router, searchController interfaces.SearchController) {
+	// GET routes
+	r.Get("/search", searchController.Search)
+}
+
+// templateRoutes initializes the task template routes
+//
+// Parameters:
+//   - r: The go chi router
+//   - templateController: The template controller layer object
+func templateRoutes(r chi.Router, templateController interfaces.TemplateController) {
+	// POST routes
+	r.Post("/templates", templateController.CreateTemplate)
+	r.Post("/templates/tasks/{taskId}", templateController.CreateTemplateFromTask)
+	r.Post("/templates/{templateId}/instantiate", templateController.InstantiateTemplate)
+
+	// GET routes
+	r.Get("/templates", templateController.GetProjectTemplates)
+	r.Get("/templates/{templateId}", templateController.GetTemplateById)
+
+	// DELETE routes
+	r.Delete("/templates/{templateId}", templateController.DeleteTemplate)
+}
+
+// recurrenceRoutes initializes the recurring task series routes
+//
+// Parameters:
+//   - r: The go chi router
+//   - recurrenceController: The recurrence controller layer object
+func recurrenceRoutes(r chi.Router, recurrenceController interfaces.RecurrenceController) {
+	// POST routes
+	r.Post("/series/tasks/{taskId}", recurrenceController.CreateSeries)
+
+	// GET routes
+	r.Get("/series", recurrenceController.GetProjectSeries)
+	r.Get("/series/{seriesId}", recurrenceController.GetSeriesById)
+
+	// PUT routes
+	r.Put("/series/{seriesId}", recurrenceController.UpdateSeries)
+	r.Put("/series/{seriesId}/stop", recurrenceController.StopSeries)
+}
+
+// worklogRoutes initializes the time tracking routes
+//
+// Parameters:
+//   - r: The go chi router
+//   - worklogController: The work log controller layer object
+func worklogRoutes(r chi.Router, worklogController interfaces.WorkLogController) {
+	// POST routes
+	r.Post("/timers/start", worklogController.StartTimer)
+	r.Post("/timers/stop", worklogController.StopTimer)
+	r.Post("/{taskId}/worklogs", worklogController.CreateWorkLog)
+
+	// GET routes
+	r.Get("/timers", worklogController.GetTimer)
+	r.Get("/reports/time", worklogController.GetTimeReport)
+	r.Get("/{taskId}/worklogs", worklogController.GetTaskWorkLogs)
+
+	// DELETE routes
+	r.Delete("/{taskId}/worklogs/{worklogId}", worklogController.DeleteWorkLog)
+}
+
+// sprintRoutes initializes the sprint planning routes
+//
+// Parameters:
+//   - r: The go chi router
+//   - sprintController: The sprint controller layer object
+func sprintRoutes(r chi.Router, sprintController interfaces.SprintController) {
+	// POST routes
+	r.Post("/sprints", sprintController.CreateSprint)
+
+	// GET routes
+	r.Get("/sprints", sprintController.GetProjectSprints)
+	r.Get("/sprints/velocity", sprintController.GetProjectVelocity)
+	r.Get("/sprints/{sprintId}", sprintController.GetSprintById)
+	r.Get("/sprints/{sprintId}/burndown", sprintController.GetSprintBurndown)
+
+	// PUT routes
+	r.Put("/sprints/{sprintId}", sprintController.UpdateSprint)
+	r.Put("/{taskId}/sprint", sprintController.AssignTaskSprint)
+	r.Put("/{taskId}/estimate", sprintController.UpdateTaskEstimate)
+
+	// DELETE routes
+	r.Delete("/sprints/{sprintId}", sprintController.DeleteSprint)
+}
+
+// analyticsRoutes initializes the project analytics routes
+//
+// Parameters:
+//   - r: The go chi router
+//   - analyticsController: The analytics controller layer object
+func analyticsRoutes(r chi.Router, analyticsController interfaces.AnalyticsController) {
+	// GET routes
+	r.Get("/analytics", analyticsController.GetProjectAnalytics)
+	r.Get("/analytics/flow", analyticsController.GetCumulativeFlow)
+	r.Get("/analytics/cycle-time", analyticsController.GetCycleTimeMetrics)
+	r.Get("/{taskId}/time-in-status", analyticsController.GetTaskTimeInStatus)
+}
+
+// wipRoutes initializes the work in progress limit routes
+//
+// Parameters:
+//   - r: The go chi router
+//   - wipController: The work in progress limit controller layer object
+func wipRoutes(r chi.Router, wipController interfaces.WipController) {
+	// GET routes
+	r.Get("/wip-limits", wipController.GetWipLimits)
+
+	// PUT routes
+	r.Put("/wip-limits", wipController.SetWipLimits)
+}
+
+// availabilityRoutes initializes the member availability routes
+//
+// Parameters:
+//   - r: The go chi router
+//   - availabilityController: The availability controller layer object
+func availabilityRoutes(r chi.Router, availabilityController interfaces.AvailabilityController) {
+	// POST routes
+	r.Post("/absences", availabilityController.CreateAbsence)
+
+	// GET routes
+	r.Get("/absences", availabilityController.GetAbsences)
+	r.Get("/absences/at-risk", availabilityController.GetTasksAtRisk)
+
+	// DELETE routes
+	r.Delete("/absences/{absenceId}", availabilityController.DeleteAbsence)
+}
+
 // taskRoutes initializes the request routes available
 //
 // Parameters:
 //   - r: The go chi router
-//   - authClient: The firestore authentication client
 //   - taskController: The controller layer object
-func taskRoutes(r chi.Router, authClient *auth.Client, taskController interfaces.TaskController) {
-	r.Route(utils.EnvInstances.ROUTE, func(r chi.Router) {
-		// Use the user token validation method
-		r.Use(middleware.AuthMiddleware(authClient))
+func taskRoutes(r chi.Router, taskController interfaces.TaskController) {
+	// POST routes
+	r.Post("/", taskController.CreateTask)
+	r.Post("/bulk", taskController.BulkUpdateTasks)
+	r.Post("/{taskId}", taskController.CreateSubtask)
+	r.Post("/{taskId}/response", taskController.CreateTaskResponse)
+	r.Post("/{taskId}/duplicate", taskController.DuplicateTask)
+	r.Post("/{taskId}/promote/{subtaskId}", taskController.PromoteSubtask)
+	r.Post("/{taskId}/review", taskController.RequestTaskReview)
+	r.Post("/{taskId}/review/approve", taskController.ApproveTaskReview)
+	r.Post("/{taskId}/review/reject", taskController.RejectTaskReview)
+	r.Post("/{taskId}/assignment/accept", taskController.AcceptTaskAssignment)
+	r.Post("/{taskId}/assignment/decline", taskController.DeclineTaskAssignment)
 
-		// POST routes
-		r.Post("/", taskController.CreateTask)
-		r.Post("/{taskId}", taskController.CreateSubtask)
-		r.Post("/{taskId}/response", taskController.CreateTaskResponse)
-
-		// GET routes
-		r.Get("/{projectId}", taskController.GetTasks)
-		r.Get("/{projectId}/{taskId}", taskController.GetTaskById)
-		r.Get("/{taskId}/subtasks", taskController.GetSubtasks)
-		r.Get("/{taskId}/responses", taskController.GetResponses)
-		r.Get("/{taskId}/subtasks/{subtaskId}", taskController.GetSubtaskById)
-		r.Get("/{taskId}/responses/{responseId}", taskController.GetResponseById)
-
-		// PUT routes
-		r.Put("/{taskId}/description", taskController.UpdateTaskDescription)
-		r.Put("/{taskId}/status", taskController.UpdateTaskStatus)
-		r.Put("/{taskId}/addHandlers", taskController.AddTaskHandlers)
-		r.Put("/{taskId}/removeHandlers", taskController.RemoveTaskHandlers)
-		r.Put("/{taskId}/description/{subtaskId}", taskController.UpdateSubtaskDescription)
-		r.Put("/{taskId}/{subtaskId}/handler", taskController.UpdateSubtaskHandler)
-		r.Put("/{taskId}/status/{subtaskId}", taskController.UpdateSubtaskStatus)
-		r.Put("/{taskId}/responses/{responseId}", taskController.UpdateResponseMessage)
-		r.Put("/{taskId}/rollback/", taskController.RerollTaskVersion)
-		r.Put("/{taskId}/rollback/{subtaskId}", taskController.RerollSubtaskVersion)
-
-		// DELETE routes
-		r.Delete("/{taskId}", taskController.DeleteTaskById)
-		r.Delete("/{taskId}/subtasks/{subtaskId}", taskController.DeleteSubtaskById)
-		r.Delete("/{taskId}/responses/{responseId}", taskController.DeleteResponseById)
-	})
+	// GET routes
+	r.Get("/my-work", taskController.GetUserWork)
+	r.Get("/{projectId}", taskController.GetTasks)
+	r.Get("/{projectId}/dependencies", taskController.GetDependencyGraph)
+	r.Get("/{projectId}/suggestions", taskController.GetHandlerSuggestions)
+	r.Get("/{projectId}/{taskId}", taskController.GetTaskById)
+	r.Get("/{taskId}/subtasks", taskController.GetSubtasks)
+	r.Get("/{taskId}/responses", taskController.GetResponses)
+	r.Get("/{taskId}/tree", taskController.GetTaskTree)
+	r.Get("/{taskId}/subtasks/{subtaskId}", taskController.GetSubtaskById)
+	r.Get("/{taskId}/responses/{responseId}", taskController.GetResponseById)
+
+	// PUT routes
+	r.Put("/{taskId}/description", taskController.UpdateTaskDescription)
+	r.Put("/{taskId}/status", taskController.UpdateTaskStatus)
+	r.Put("/{taskId}/rank", taskController.UpdateTaskRank)
+	r.Put("/{taskId}/reviewers", taskController.SetTaskReviewers)
+	r.Put("/{taskId}/priority", taskController.UpdateTaskPriority)
+	r.Put("/{taskId}/addHandlers", taskController.AddTaskHandlers)
+	r.Put("/{taskId}/removeHandlers", taskController.RemoveTaskHandlers)
+	r.Put("/{taskId}/addLabels", taskController.AddTaskLabels)
+	r.Put("/{taskId}/removeLabels", taskController.RemoveTaskLabels)
+	r.Put("/{taskId}/addBlocker", taskController.AddTaskDependency)
+	r.Put("/{taskId}/removeBlocker", taskController.RemoveTaskDependency)
+	r.Put("/{taskId}/addChildren", taskController.AddTaskChildren)
+	r.Put("/{taskId}/removeChildren", taskController.RemoveTaskChildren)
+	r.Put("/{taskId}/move", taskController.MoveTask)
+	r.Put("/{taskId}/description/{subtaskId}", taskController.UpdateSubtaskDescription)
+	r.Put("/{taskId}/{subtaskId}/handler", taskController.UpdateSubtaskHandler)
+	r.Put("/{taskId}/status/{subtaskId}", taskController.UpdateSubtaskStatus)
+	r.Put("/{taskId}/responses/{responseId}", taskController.UpdateResponseMessage)
+	r.Put("/{taskId}/rollback/", taskController.RerollTaskVersion)
+	r.Put("/{taskId}/rollback/{subtaskId}", taskController.RerollSubtaskVersion)
+
+	// DELETE routes
+	r.Delete("/{taskId}", taskController.DeleteTaskById)
+	r.Delete("/{taskId}/subtasks/{subtaskId}", taskController.DeleteSubtaskById)
+	r.Delete("/{taskId}/responses/{responseId}", taskController.DeleteResponseById)
 }
@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// DefaultInterval is the time between two runs of a scheduled routine
+const DefaultInterval = time.Minute
+
+// Routine is the work executed periodically by the scheduler
+type Routine func(ctx context.Context) error
+
+type scheduledRoutine struct {
+	name     string
+	interval time.Duration
+	run      Routine
+}
+
+type Scheduler struct {
+	routines []scheduledRoutine
+	mu       sync.Mutex
+}
+
+// NewScheduler generates a new scheduler that runs the registered routines in the background
+//
+// Returns:
+//   - *Scheduler: The new scheduler
+func NewScheduler() *Scheduler {
+	return &Scheduler{routines: []scheduledRoutine{}}
+}
+
+// Register adds a routine that runs periodically once the scheduler is started
+//
+// Parameters:
+//   - name: The name of the routine used in the logs
+//   - interval: The time between two runs of the routine
+//   - run: The routine
+func (s *Scheduler) Register(name string, interval time.Duration, run Routine) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	s.routines = append(s.routines, scheduledRoutine{name: name, interval: interval, run: run})
+}
+
+// Start runs each registered routine in its own goroutine until the context is done
+//
+// Parameters:
+//   - ctx: The context that stops the scheduler when cancelled
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, routine := range s.routines {
+		go func(routine scheduledRoutine) {
+			ticker := time.NewTicker(routine.interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := routine.run(ctx); err != nil {
+						log.Printf("Scheduled routine %s failed: %v", routine.name, err)
+					}
+				}
+			}
+		}(routine)
+	}
+}
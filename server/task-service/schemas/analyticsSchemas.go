@@ -0,0 +1,15 @@
+package schemas
+
+// GET schemas
+
+type GetProjectAnalyticsSchema struct {
+	UserID    string `validate:"required"`
+	ProjectID string `validate:"required"`
+	From      int64  `validate:"min=0"`
+	To        int64  `validate:"required,gtfield=From"`
+}
+
+type GetTaskTimeInStatusSchema struct {
+	UserID string `validate:"required"`
+	TaskID string `validate:"required"`
+}
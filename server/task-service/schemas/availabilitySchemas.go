@@ -0,0 +1,35 @@
+package schemas
+
+// POST schemas
+
+type CreateAbsenceSchema struct {
+	UserID    string `validate:"required"`
+	ProjectID string `validate:"required"`
+	MemberID  string `validate:"omitempty"`
+	StartAt   int64  `validate:"required,min=0"`
+	EndAt     int64  `validate:"required,gtfield=StartAt"`
+	Reason    string `validate:"omitempty,max=500"`
+}
+
+// GET schemas
+
+type GetAbsencesSchema struct {
+	UserID    string `validate:"required"`
+	ProjectID string `validate:"required"`
+	From      int64  `validate:"min=0"`
+	To        int64  `validate:"required,gtfield=From"`
+}
+
+type GetTasksAtRiskSchema struct {
+	UserID    string `validate:"required"`
+	ProjectID string `validate:"required"`
+	Days      int    `validate:"min=1,max=90"`
+}
+
+// DELETE schemas
+
+type DeleteAbsenceSchema struct {
+	UserID    string `validate:"required"`
+	ProjectID string `validate:"required"`
+	AbsenceID string `validate:"required"`
+}
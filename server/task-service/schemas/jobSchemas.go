@@ -0,0 +1,11 @@
+package schemas
+
+type GetJobByIdSchema struct {
+	UserID string `validate:"required"`
+	JobID  string `validate:"required"`
+}
+
+type CancelJobSchema struct {
+	UserID string `validate:"required"`
+	JobID  string `validate:"required"`
+}
@@ -0,0 +1,43 @@
+package schemas
+
+type RecurrenceRuleSchema struct {
+	Frequency string `validate:"required,oneof=daily weekly monthly"`
+	Interval  int    `validate:"required,min=1,max=365"`
+	Until     *int64 `validate:"omitempty,min=0,excluded_with=Count"`
+	Count     int    `validate:"omitempty,min=2,max=1000"`
+}
+
+type CreateSeriesSchema struct {
+	UserID string `validate:"required"`
+	TaskID string `validate:"required"`
+	RecurrenceRuleSchema
+}
+
+// GET schemas
+
+type GetProjectSeriesSchema struct {
+	UserID    string `validate:"required"`
+	ProjectID string `validate:"required"`
+}
+
+type GetSeriesByIdSchema struct {
+	UserID   string `validate:"required"`
+	SeriesID string `validate:"required"`
+}
+
+// PUT schemas
+
+type UpdateSeriesSchema struct {
+	UserID      string                `validate:"required"`
+	SeriesID    string                `validate:"required"`
+	Rule        *RecurrenceRuleSchema `validate:"omitempty"`
+	Description string                `validate:"omitempty,min=1"`
+	HandlerIDs  []string              `validate:"omitempty,max=50,dive,required"`
+	Priority    string                `validate:"omitempty,oneof=critical high medium low"`
+	Severity    string                `validate:"omitempty,oneof=blocker major minor trivial"`
+}
+
+type StopSeriesSchema struct {
+	UserID   string `validate:"required"`
+	SeriesID string `validate:"required"`
+}
@@ -0,0 +1,8 @@
+package schemas
+
+type SearchSchema struct {
+	UserID    string `validate:"required"`
+	Query     string `validate:"required,min=1,max=256"`
+	ProjectID string `validate:"omitempty"`
+	Limit     int    `validate:"required,min=1,max=100"`
+}
@@ -0,0 +1,61 @@
+package schemas
+
+// POST schemas
+
+type CreateSprintSchema struct {
+	UserID    string `validate:"required"`
+	ProjectID string `validate:"required"`
+	Name      string `validate:"required,min=1,max=100"`
+	Goal      string `validate:"omitempty,max=1000"`
+	StartAt   int64  `validate:"required,min=0"`
+	EndAt     int64  `validate:"required,gtfield=StartAt"`
+}
+
+// GET schemas
+
+type GetProjectSprintsSchema struct {
+	UserID    string `validate:"required"`
+	ProjectID string `validate:"required"`
+}
+
+type GetSprintByIdSchema struct {
+	UserID   string `validate:"required"`
+	SprintID string `validate:"required"`
+}
+
+type GetProjectVelocitySchema struct {
+	UserID    string `validate:"required"`
+	ProjectID string `validate:"required"`
+	Limit     int    `validate:"required,min=1,max=20"`
+}
+
+// PUT schemas
+
+type UpdateSprintSchema struct {
+	UserID   string  `validate:"required"`
+	SprintID string  `validate:"required"`
+	Name     string  `validate:"omitempty,min=1,max=100"`
+	Goal     *string `validate:"omitempty,max=1000"`
+	StartAt  *int64  `validate:"omitempty,min=0"`
+	EndAt    *int64  `validate:"omitempty,min=0"`
+}
+
+type AssignTaskSprintSchema struct {
+	UserID   string `validate:"required"`
+	TaskID   string `validate:"required"`
+	SprintID string `validate:"omitempty"`
+}
+
+type UpdateTaskEstimateSchema struct {
+	UserID        string   `validate:"required"`
+	TaskID        string   `validate:"required"`
+	StoryPoints   *float64 `validate:"omitempty,min=0,max=1000"`
+	EstimatedTime *int64   `validate:"omitempty,min=0"`
+}
+
+// DELETE schemas
+
+type DeleteSprintSchema struct {
+	UserID   string `validate:"required"`
+	SprintID string `validate:"required"`
+}
@@ -5,9 +5,12 @@ import "github.com/horatiucrisan/task-service/model"
 type CreateTaskSchema struct {
 	AuthorID    string   `validate:"required"`
 	ProjectID   string   `validate:"required"`
-	HandlerIDs  []string `validate:"required,min=1,max=50,dive,required"`
+	HandlerIDs  []string `validate:"required_without=AutoAssign,excluded_with=AutoAssign,max=50,dive,required"`
 	Description string   `validate:"required,min=1"`
 	Deadline    int64    `validate:"required"`
+	Priority    string   `validate:"omitempty,oneof=critical high medium low"`
+	Severity    string   `validate:"omitempty,oneof=blocker major minor trivial"`
+	AutoAssign  string   `validate:"omitempty,oneof=round-robin least-loaded"`
 }
 
 type CreateSubtaskSchema struct {
@@ -23,20 +26,64 @@ type CreateTaskResponseSchema struct {
 	Message  string `validate:"required"`
 }
 
+type DuplicateTaskSchema struct {
+	UserID           string `validate:"required"`
+	TaskID           string `validate:"required"`
+	IncludeResponses bool   `validate:"omitempty"`
+	Deadline         int64  `validate:"omitempty,min=0"`
+}
+
 // GET schemas
 
 type GetTasksSchema struct {
-	UserID         string `validate:"required"`
-	ProjectID      string `validate:"required"`
-	Limit          int    `validate:"required,min=1"`
-	OrderBy        string `validate:"required"`
-	OrderDirection string `validate:"required"`
-	StartAfter     string `validate:"omitempty"`
+	UserID            string   `validate:"required"`
+	ProjectID         string   `validate:"required"`
+	Limit             int      `validate:"required,min=1"`
+	OrderBy           string   `validate:"required,oneof=createdAt deadline status priority rank"`
+	OrderDirection    string   `validate:"required,oneof=asc desc"`
+	Cursor            string   `validate:"omitempty"`
+	Statuses          []string `validate:"omitempty,max=10,dive,required"`
+	Priorities        []string `validate:"omitempty,max=4,dive,oneof=critical high medium low"`
+	Labels            []string `validate:"omitempty,max=10,dive,required"`
+	HandlerID         string   `validate:"omitempty"`
+	AuthorID          string   `validate:"omitempty"`
+	DeadlineFrom      *int64   `validate:"omitempty,min=0"`
+	DeadlineTo        *int64   `validate:"omitempty,min=0"`
+	CreatedFrom       *int64   `validate:"omitempty,min=0"`
+	CreatedTo         *int64   `validate:"omitempty,min=0"`
+	HasOpenSubtasks   *bool    `validate:"omitempty"`
+	PendingAcceptance *bool    `validate:"omitempty"`
+}
+
+type GetDependencyGraphSchema struct {
+	UserID    string `validate:"required"`
+	ProjectID string `validate:"required"`
+}
+
+type GetHandlerSuggestionsSchema struct {
+	UserID    string `validate:"required"`
+	ProjectID string `validate:"required"`
+	Deadline  int64  `validate:"omitempty,min=0"`
+}
+
+type GetTaskTreeSchema struct {
+	UserID string `validate:"required"`
+	TaskID string `validate:"required"`
+	Depth  int    `validate:"required,min=1,max=5"`
+}
+
+type GetUserWorkSchema struct {
+	UserID       string   `validate:"required"`
+	Statuses     []string `validate:"omitempty,max=10,dive,required"`
+	DeadlineFrom *int64   `validate:"omitempty,min=0"`
+	DeadlineTo   *int64   `validate:"omitempty,min=0"`
 }
 
 type GetSubtasksSchema struct {
 	UserID string `validate:"required"`
 	TaskID string `validate:"required"`
+	Limit  int    `validate:"omitempty,min=1"`
+	Cursor string `validate:"omitempty"`
 }
 
 type GetSubtaskByIdSchema struct {
@@ -48,6 +95,8 @@ type GetSubtaskByIdSchema struct {
 type GetResponsesSchema struct {
 	UserID string `validate:"required"`
 	TaskID string `validate:"required"`
+	Limit  int    `validate:"omitempty,min=1"`
+	Cursor string `validate:"omitempty"`
 }
 
 type GetResponseByIdSchema struct {
@@ -71,6 +120,18 @@ type AddTaskHandlersSchema struct {
 	UserID     string   `validate:"required"`
 	TaskID     string   `validate:"required"`
 	HandlerIDs []string `validate:"required,min=1,max=5,dive,required"`
+	Override   bool
+}
+
+type AcceptTaskAssignmentSchema struct {
+	UserID string `validate:"required"`
+	TaskID string `validate:"required"`
+}
+
+type DeclineTaskAssignmentSchema struct {
+	UserID string `validate:"required"`
+	TaskID string `validate:"required"`
+	Reason string `validate:"required,min=1,max=1000"`
 }
 
 type RemoveTaskHandlersSchema struct {
@@ -79,10 +140,103 @@ type RemoveTaskHandlersSchema struct {
 	HandlerIDs []string `validate:"required,dive,required"`
 }
 
+type AddTaskLabelsSchema struct {
+	UserID string   `validate:"required"`
+	TaskID string   `validate:"required"`
+	Labels []string `validate:"required,min=1,max=10,dive,required,max=30"`
+}
+
+type RemoveTaskLabelsSchema struct {
+	UserID string   `validate:"required"`
+	TaskID string   `validate:"required"`
+	Labels []string `validate:"required,min=1,dive,required"`
+}
+
+type AddTaskDependencySchema struct {
+	UserID    string `validate:"required"`
+	TaskID    string `validate:"required"`
+	BlockerID string `validate:"required"`
+}
+
+type RemoveTaskDependencySchema struct {
+	UserID    string `validate:"required"`
+	TaskID    string `validate:"required"`
+	BlockerID string `validate:"required"`
+}
+
+type AddTaskChildrenSchema struct {
+	UserID   string   `validate:"required"`
+	TaskID   string   `validate:"required"`
+	ChildIDs []string `validate:"required,min=1,max=50,dive,required"`
+}
+
+type RemoveTaskChildrenSchema struct {
+	UserID   string   `validate:"required"`
+	TaskID   string   `validate:"required"`
+	ChildIDs []string `validate:"required,min=1,max=50,dive,required"`
+}
+
+type MoveTaskSchema struct {
+	UserID     string `validate:"required"`
+	TaskID     string `validate:"required"`
+	ProjectID  string `validate:"required"`
+	NonMembers string `validate:"omitempty,oneof=reject drop keep"`
+}
+
+type PromoteSubtaskSchema struct {
+	UserID      string `validate:"required"`
+	TaskID      string `validate:"required"`
+	SubtaskID   string `validate:"required"`
+	Deadline    int64  `validate:"omitempty,min=0"`
+	Priority    string `validate:"omitempty,oneof=critical high medium low"`
+	KeepSubtask bool   `validate:"omitempty"`
+}
+
 type UpdateTaskStatusSchema struct {
-	UserID string `validate:"required"`
-	TaskID string `validate:"required"`
-	Status string `validate:"required"`
+	UserID   string `validate:"required"`
+	TaskID   string `validate:"required"`
+	Status   string `validate:"required"`
+	Override bool
+}
+
+type UpdateTaskRankSchema struct {
+	UserID   string `validate:"required"`
+	TaskID   string `validate:"required"`
+	Status   string `validate:"required,oneof=new development on-hold in-review completed"`
+	AfterID  string `validate:"omitempty,nefield=BeforeID"`
+	BeforeID string `validate:"omitempty"`
+	Override bool
+}
+
+type SetTaskReviewersSchema struct {
+	UserID      string   `validate:"required"`
+	TaskID      string   `validate:"required"`
+	ReviewerIDs []string `validate:"omitempty,max=5,dive,required"`
+}
+
+type RequestTaskReviewSchema struct {
+	UserID   string `validate:"required"`
+	TaskID   string `validate:"required"`
+	Override bool
+}
+
+type ApproveTaskReviewSchema struct {
+	UserID  string `validate:"required"`
+	TaskID  string `validate:"required"`
+	Comment string `validate:"omitempty,max=5000"`
+}
+
+type RejectTaskReviewSchema struct {
+	UserID  string `validate:"required"`
+	TaskID  string `validate:"required"`
+	Comment string `validate:"required,min=1,max=5000"`
+}
+
+type UpdateTaskPrioritySchema struct {
+	UserID   string `validate:"required"`
+	TaskID   string `validate:"required"`
+	Priority string `validate:"required,oneof=critical high medium low"`
+	Severity string `validate:"omitempty,oneof=blocker major minor trivial"`
 }
 
 type UpdateSubtaskDescriptionSchema struct {
@@ -144,3 +298,13 @@ type DeleteResponseById struct {
 	TaskID     string `validate:"required"`
 	ResponseID string `validate:"required"`
 }
+
+type BulkTaskOperationSchema struct {
+	UserID     string   `validate:"required"`
+	TaskIDs    []string `json:"taskIds" validate:"required,min=1,max=100,dive,required"`
+	Operation  string   `json:"operation" validate:"required,oneof=set-status add-handlers remove-handlers set-deadline delete"`
+	Status     string   `json:"status" validate:"required_if=Operation set-status,omitempty,oneof=new development on-hold in-review completed"`
+	HandlerIDs []string `json:"handlerIds" validate:"required_if=Operation add-handlers,required_if=Operation remove-handlers,omitempty,max=50,dive,required"`
+	Deadline   int64    `json:"deadline" validate:"required_if=Operation set-deadline"`
+	Override   bool     `json:"override"`
+}
@@ -0,0 +1,48 @@
+package schemas
+
+type TemplateSubtaskSchema struct {
+	Description string `validate:"required,min=10,max=255"`
+}
+
+type CreateTemplateSchema struct {
+	UserID      string                  `validate:"required"`
+	ProjectID   string                  `validate:"required"`
+	Name        string                  `validate:"required,min=1,max=100"`
+	Description string                  `validate:"required,min=1"`
+	Priority    string                  `validate:"omitempty,oneof=critical high medium low"`
+	Severity    string                  `validate:"omitempty,oneof=blocker major minor trivial"`
+	Labels      []string                `validate:"omitempty,max=10,dive,required"`
+	Subtasks    []TemplateSubtaskSchema `validate:"omitempty,max=50,dive"`
+}
+
+type CreateTemplateFromTaskSchema struct {
+	UserID string `validate:"required"`
+	TaskID string `validate:"required"`
+	Name   string `validate:"required,min=1,max=100"`
+}
+
+type InstantiateTemplateSchema struct {
+	UserID            string            `validate:"required"`
+	TemplateID        string            `validate:"required"`
+	Variables         map[string]string `validate:"omitempty"`
+	Deadline          int64             `validate:"required"`
+	HandlerIDs        []string          `validate:"required,min=1,max=50,dive,required"`
+	SubtaskHandlerIDs []string          `validate:"omitempty,max=50"`
+}
+
+// GET schemas
+
+type GetProjectTemplatesSchema struct {
+	UserID    string `validate:"required"`
+	ProjectID string `validate:"required"`
+}
+
+type GetTemplateByIdSchema struct {
+	UserID     string `validate:"required"`
+	TemplateID string `validate:"required"`
+}
+
+type DeleteTemplateSchema struct {
+	UserID     string `validate:"required"`
+	TemplateID string `validate:"required"`
+}
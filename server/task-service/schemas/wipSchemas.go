@@ -0,0 +1,17 @@
+package schemas
+
+// GET schemas
+
+type GetWipLimitsSchema struct {
+	UserID    string `validate:"required"`
+	ProjectID string `validate:"required"`
+}
+
+// PUT schemas
+
+type SetWipLimitsSchema struct {
+	UserID        string         `validate:"required"`
+	ProjectID     string         `validate:"required"`
+	StatusLimits  map[string]int `validate:"omitempty,dive,keys,oneof=new development on-hold in-review,endkeys,min=1,max=1000"`
+	HandlerLimits map[string]int `validate:"omitempty,dive,keys,oneof=new development on-hold in-review,endkeys,min=1,max=1000"`
+}
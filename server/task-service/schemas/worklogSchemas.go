@@ -0,0 +1,53 @@
+package schemas
+
+// POST schemas
+
+type CreateWorkLogSchema struct {
+	UserID    string `validate:"required"`
+	TaskID    string `validate:"required"`
+	SubtaskID string `validate:"omitempty"`
+	StartedAt int64  `validate:"omitempty,min=0"`
+	EndedAt   int64  `validate:"omitempty,min=0,required_with=StartedAt"`
+	Duration  int64  `validate:"omitempty,min=1"`
+	Note      string `validate:"omitempty,max=1000"`
+}
+
+type StartTimerSchema struct {
+	UserID    string `validate:"required"`
+	TaskID    string `validate:"required"`
+	SubtaskID string `validate:"omitempty"`
+	Note      string `validate:"omitempty,max=1000"`
+}
+
+type StopTimerSchema struct {
+	UserID string `validate:"required"`
+	Note   string `validate:"omitempty,max=1000"`
+}
+
+// GET schemas
+
+type GetTaskWorkLogsSchema struct {
+	UserID string `validate:"required"`
+	TaskID string `validate:"required"`
+}
+
+type GetTimerSchema struct {
+	UserID string `validate:"required"`
+}
+
+type GetTimeReportSchema struct {
+	UserID       string `validate:"required"`
+	ProjectID    string `validate:"omitempty"`
+	ReportUserID string `validate:"omitempty"`
+	From         int64  `validate:"min=0"`
+	To           int64  `validate:"required,gtfield=From"`
+	Format       string `validate:"omitempty,oneof=json csv"`
+}
+
+// DELETE schemas
+
+type DeleteWorkLogSchema struct {
+	UserID    string `validate:"required"`
+	TaskID    string `validate:"required"`
+	WorkLogID string `validate:"required"`
+}
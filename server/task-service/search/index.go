@@ -0,0 +1,424 @@
+package search
+
+import (
+	"html"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/horatiucrisan/task-service/model"
+)
+
+const (
+	// k1 and b are the BM25 ranking parameters
+	k1 = 1.2
+	b  = 0.75
+
+	// highlightSize is the max number of bytes of a highlighted fragment
+	highlightSize = 200
+
+	// highlightContext is the number of words shown before the first match
+	highlightContext = 5
+)
+
+// Document is a searchable text of a task, subtask or response
+type Document struct {
+	ID        string
+	Type      string
+	TaskID    string
+	ProjectID string
+	Text      string
+}
+
+type indexedDocument struct {
+	Document
+	tokens []token
+}
+
+// clauseMatch holds the occurrences of a clause inside a document
+type clauseMatch struct {
+	frequency int
+	positions []int
+}
+
+// Index is an in-memory inverted index of the task texts
+type Index struct {
+	mu          sync.RWMutex
+	documents   map[string]*indexedDocument
+	postings    map[string]map[string][]int
+	totalLength int
+}
+
+// NewIndex generates a new empty search index
+//
+// Returns:
+//   - *Index: The search index
+func NewIndex() *Index {
+	return &Index{
+		documents: map[string]*indexedDocument{},
+		postings:  map[string]map[string][]int{},
+	}
+}
+
+// Add indexes a document, replacing the previous version of it
+//
+// Parameters:
+//   - document: The document to index
+func (i *Index) Add(document Document) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.add(document)
+}
+
+// Remove deletes a document from the index
+//
+// Parameters:
+//   - docType: The type of the document
+//   - id: The ID of the document
+func (i *Index) Remove(docType, id string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(documentKey(docType, id))
+}
+
+// Replace removes all the documents of a type and indexes the new list of documents
+//
+// Parameters:
+//   - docType: The type of the documents
+//   - documents: The new list of documents
+func (i *Index) Replace(docType string, documents []Document) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for key, document := range i.documents {
+		if document.Type == docType {
+			i.remove(key)
+		}
+	}
+
+	for _, document := range documents {
+		i.add(document)
+	}
+}
+
+// Search returns the documents that match every clause of the query, ranked by relevance.
+// Only the documents of the given projects are returned
+//
+// Parameters:
+//   - query: The search query
+//   - projectIds: The list of projects the results can be part of
+//   - limit: The max number of results
+//
+// Returns:
+//   - []model.SearchHit: The list of ranked results
+//   - error: An error that occured during the process
+func (i *Index) Search(query string, projectIds []string, limit int) ([]model.SearchHit, error) {
+	// Parse the query into clauses
+	clauses, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	// Find the documents that match all the clauses
+	var candidates map[string][]clauseMatch
+	idfs := make([]float64, len(clauses))
+	for c, clause := range clauses {
+		matches, idf := i.matchClause(clause)
+		idfs[c] = idf
+
+		if candidates == nil {
+			candidates = make(map[string][]clauseMatch, len(matches))
+			for key, match := range matches {
+				candidates[key] = []clauseMatch{match}
+			}
+			continue
+		}
+
+		for key := range candidates {
+			match, ok := matches[key]
+			if !ok {
+				delete(candidates, key)
+				continue
+			}
+			candidates[key] = append(candidates[key], match)
+		}
+	}
+
+	// Score the documents the user can see
+	averageLength := 1.0
+	if len(i.documents) > 0 {
+		averageLength = math.Max(float64(i.totalLength)/float64(len(i.documents)), 1)
+	}
+
+	hits := []model.SearchHit{}
+	for key, matches := range candidates {
+		document := i.documents[key]
+		projectId := i.projectOf(document)
+		if !slices.Contains(projectIds, projectId) {
+			continue
+		}
+
+		// Rank the document using BM25
+		score := 0.0
+		positions := []int{}
+		length := float64(len(document.tokens))
+		for c, match := range matches {
+			frequency := float64(match.frequency)
+			score += idfs[c] * frequency * (k1 + 1) / (frequency + k1*(1-b+b*length/averageLength))
+			positions = append(positions, match.positions...)
+		}
+
+		hits = append(hits, model.SearchHit{
+			ID:        document.ID,
+			Type:      document.Type,
+			TaskID:    document.TaskID,
+			ProjectID: projectId,
+			Score:     score,
+			Highlight: highlight(document, positions),
+		})
+	}
+
+	// Sort the results by relevance
+	sort.Slice(hits, func(a, b int) bool {
+		if hits[a].Score != hits[b].Score {
+			return hits[a].Score > hits[b].Score
+		}
+		return documentKey(hits[a].Type, hits[a].ID) < documentKey(hits[b].Type, hits[b].ID)
+	})
+
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	return hits, nil
+}
+
+// add is a private method that indexes a document. The caller must hold the write lock
+//
+// Parameters:
+//   - document: The document to index
+func (i *Index) add(document Document) {
+	key := documentKey(document.Type, document.ID)
+	i.remove(key)
+
+	tokens := tokenize(document.Text)
+	i.documents[key] = &indexedDocument{Document: document, tokens: tokens}
+	i.totalLength += len(tokens)
+
+	for _, token := range tokens {
+		if i.postings[token.term] == nil {
+			i.postings[token.term] = map[string][]int{}
+		}
+		i.postings[token.term][key] = append(i.postings[token.term][key], token.position)
+	}
+}
+
+// remove is a private method that deletes a document from the index. The caller must hold the write lock
+//
+// Parameters:
+//   - key: The key of the document
+func (i *Index) remove(key string) {
+	document, ok := i.documents[key]
+	if !ok {
+		return
+	}
+
+	for _, token := range document.tokens {
+		delete(i.postings[token.term], key)
+		if len(i.postings[token.term]) == 0 {
+			delete(i.postings, token.term)
+		}
+	}
+
+	i.totalLength -= len(document.tokens)
+	delete(i.documents, key)
+}
+
+// matchClause is a private method that finds the documents that match a clause
+//
+// Parameters:
+//   - clause: The query clause
+//
+// Returns:
+//   - map[string]clauseMatch: The matches of each document
+//   - float64: The inverse document frequency of the clause
+func (i *Index) matchClause(clause clause) (map[string]clauseMatch, float64) {
+	matches := map[string]clauseMatch{}
+
+	switch {
+	case clause.prefix:
+		// Merge the postings of every term that starts with the prefix
+		for term, postings := range i.postings {
+			if !strings.HasPrefix(term, clause.terms[0]) {
+				continue
+			}
+			for key, positions := range postings {
+				match := matches[key]
+				match.frequency += len(positions)
+				match.positions = append(match.positions, positions...)
+				matches[key] = match
+			}
+		}
+		return matches, i.idf(len(matches))
+
+	case clause.phrase:
+		idf := 0.0
+		for _, term := range clause.terms {
+			idf += i.idf(len(i.postings[term]))
+		}
+
+		// Check every occurrence of the first term for the rest of the phrase
+		for key, firstPositions := range i.postings[clause.terms[0]] {
+			match := clauseMatch{}
+			for _, start := range firstPositions {
+				if i.phraseAt(key, clause.terms, start) {
+					match.frequency++
+					for offset := range clause.terms {
+						match.positions = append(match.positions, start+offset)
+					}
+				}
+			}
+			if match.frequency > 0 {
+				matches[key] = match
+			}
+		}
+		return matches, idf
+
+	default:
+		for key, positions := range i.postings[clause.terms[0]] {
+			matches[key] = clauseMatch{frequency: len(positions), positions: positions}
+		}
+		return matches, i.idf(len(matches))
+	}
+}
+
+// phraseAt is a private method that checks if a document contains the terms of a phrase starting at a position
+//
+// Parameters:
+//   - key: The key of the document
+//   - terms: The terms of the phrase
+//   - start: The position of the first term
+//
+// Returns:
+//   - bool: True if the phrase starts at the position
+func (i *Index) phraseAt(key string, terms []string, start int) bool {
+	tokens := i.documents[key].tokens
+	if start+len(terms) > len(tokens) {
+		return false
+	}
+
+	for offset, term := range terms {
+		if tokens[start+offset].term != term {
+			return false
+		}
+	}
+
+	return true
+}
+
+// idf is a private method that computes the inverse document frequency of a term
+//
+// Parameters:
+//   - documentFrequency: The number of documents that contain the term
+//
+// Returns:
+//   - float64: The inverse document frequency
+func (i *Index) idf(documentFrequency int) float64 {
+	total := float64(len(i.documents))
+	frequency := float64(documentFrequency)
+	return math.Log(1 + (total-frequency+0.5)/(frequency+0.5))
+}
+
+// projectOf is a private method that returns the project of a document.
+// Subtasks and responses are part of the project of their task
+//
+// Parameters:
+//   - document: The indexed document
+//
+// Returns:
+//   - string: The ID of the project
+func (i *Index) projectOf(document *indexedDocument) string {
+	if document.Type == model.SearchTypeTask {
+		return document.ProjectID
+	}
+
+	task, ok := i.documents[documentKey(model.SearchTypeTask, document.TaskID)]
+	if !ok {
+		return ""
+	}
+
+	return task.ProjectID
+}
+
+// documentKey generates the key of a document inside the index
+//
+// Parameters:
+//   - docType: The type of the document
+//   - id: The ID of the document
+//
+// Returns:
+//   - string: The key of the document
+func documentKey(docType, id string) string {
+	return docType + "/" + id
+}
+
+// highlight generates a fragment of the document text with the matched words wrapped in `<mark>` tags
+//
+// Parameters:
+//   - document: The indexed document
+//   - positions: The positions of the matched words
+//
+// Returns:
+//   - string: The HTML escaped fragment
+func highlight(document *indexedDocument, positions []int) string {
+	if len(document.tokens) == 0 {
+		return ""
+	}
+
+	slices.Sort(positions)
+	positions = slices.Compact(positions)
+
+	// Start the fragment a few words before the first match
+	first := 0
+	if len(positions) > 0 {
+		first = max(positions[0]-highlightContext, 0)
+	}
+	start := document.tokens[first].start
+	if first == 0 {
+		start = 0
+	}
+	end := start + len(truncate(document.Text[start:], highlightSize))
+
+	var builder strings.Builder
+	if start > 0 {
+		builder.WriteString("…")
+	}
+
+	cursor := start
+	for _, position := range positions {
+		token := document.tokens[position]
+		if token.start < cursor || token.end > end {
+			continue
+		}
+
+		builder.WriteString(html.EscapeString(document.Text[cursor:token.start]))
+		builder.WriteString("<mark>")
+		builder.WriteString(html.EscapeString(document.Text[token.start:token.end]))
+		builder.WriteString("</mark>")
+		cursor = token.end
+	}
+	builder.WriteString(html.EscapeString(document.Text[cursor:end]))
+
+	if end < len(document.Text) {
+		builder.WriteString("…")
+	}
+
+	return builder.String()
+}
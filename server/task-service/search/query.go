@@ -0,0 +1,80 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+// clause is a part of a search query that every result has to match
+type clause struct {
+	terms  []string
+	phrase bool
+	prefix bool
+}
+
+// parseQuery converts a search query into clauses.
+// Quoted text is matched as a phrase and a word ending with `*` is matched as a prefix
+//
+// Parameters:
+//   - query: The search query
+//
+// Returns:
+//   - []clause: The list of clauses
+//   - error: An error that occured during the process
+func parseQuery(query string) ([]clause, error) {
+	clauses := []clause{}
+
+	// Split the query into quoted and unquoted parts
+	parts := strings.Split(query, `"`)
+	for i, part := range parts {
+		isPhrase := i%2 == 1
+
+		if isPhrase {
+			terms := termsOf(part)
+			if len(terms) > 0 {
+				clauses = append(clauses, clause{terms: terms, phrase: len(terms) > 1})
+			}
+			continue
+		}
+
+		for _, word := range strings.Fields(part) {
+			isPrefix := strings.HasSuffix(word, "*")
+
+			// A word like `e-mail` is split into multiple terms that must follow each other
+			terms := termsOf(word)
+			if len(terms) == 0 {
+				continue
+			}
+
+			clauses = append(clauses, clause{
+				terms:  terms,
+				phrase: len(terms) > 1,
+				prefix: isPrefix && len(terms) == 1,
+			})
+		}
+	}
+
+	if len(clauses) == 0 {
+		return nil, utils.ErrInvalidSearchQuery
+	}
+
+	return clauses, nil
+}
+
+// termsOf returns the lowercase terms of a text
+//
+// Parameters:
+//   - text: The text
+//
+// Returns:
+//   - []string: The list of terms
+func termsOf(text string) []string {
+	tokens := tokenize(text)
+	terms := make([]string, len(tokens))
+	for i, token := range tokens {
+		terms[i] = token.term
+	}
+
+	return terms
+}
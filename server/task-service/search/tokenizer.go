@@ -0,0 +1,90 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// token is a single word of an indexed text
+type token struct {
+	term     string
+	position int
+	start    int
+	end      int
+}
+
+// tokenize splits a text into lowercase words and keeps the byte offsets of each word
+// so the matches can be highlighted in the original text
+//
+// Parameters:
+//   - text: The text to split
+//
+// Returns:
+//   - []token: The list of words in the order they appear
+func tokenize(text string) []token {
+	tokens := []token{}
+	start := -1
+
+	for i, char := range text {
+		isWordChar := unicode.IsLetter(char) || unicode.IsDigit(char)
+
+		// Start a new word
+		if isWordChar && start == -1 {
+			start = i
+			continue
+		}
+
+		// End the current word
+		if !isWordChar && start != -1 {
+			tokens = append(tokens, newToken(text, start, i, len(tokens)))
+			start = -1
+		}
+	}
+
+	// Add the last word of the text
+	if start != -1 {
+		tokens = append(tokens, newToken(text, start, len(text), len(tokens)))
+	}
+
+	return tokens
+}
+
+// newToken generates a token from the bounds of a word
+//
+// Parameters:
+//   - text: The original text
+//   - start: The byte offset where the word starts
+//   - end: The byte offset where the word ends
+//   - position: The index of the word in the text
+//
+// Returns:
+//   - token: The generated token
+func newToken(text string, start, end, position int) token {
+	return token{
+		term:     strings.ToLower(text[start:end]),
+		position: position,
+		start:    start,
+		end:      end,
+	}
+}
+
+// truncate shortens a text to a number of bytes without splitting a character
+//
+// Parameters:
+//   - text: The text to shorten
+//   - size: The max number of bytes
+//
+// Returns:
+//   - string: The shortened text
+func truncate(text string, size int) string {
+	if len(text) <= size {
+		return text
+	}
+
+	for size > 0 && !utf8.RuneStart(text[size]) {
+		size--
+	}
+
+	return text[:size]
+}
@@ -0,0 +1,151 @@
+package search
+
+import (
+	"context"
+	"log"
+	"time"
+
+	firestore "cloud.google.com/go/firestore"
+
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+// reconnectDelay is the time to wait before listening again after a listener failed
+const reconnectDelay = 5 * time.Second
+
+// Watcher keeps the search index in sync with the tasks, subtasks and responses collections.
+// The snapshot listeners receive every write, including the ones made by other replicas and by the background jobs
+type Watcher struct {
+	client *firestore.Client
+	index  *Index
+}
+
+// NewWatcher generates a new watcher for the search index
+//
+// Parameters:
+//   - client: The firestore client
+//   - index: The search index to keep in sync
+//
+// Returns:
+//   - *Watcher: The new watcher
+func NewWatcher(client *firestore.Client, index *Index) *Watcher {
+	return &Watcher{client: client, index: index}
+}
+
+// Start listens to the collections in the background until the context is done
+//
+// Parameters:
+//   - ctx: The context that stops the listeners when cancelled
+func (w *Watcher) Start(ctx context.Context) {
+	go w.watch(ctx, w.client.Collection(utils.EnvInstances.TASKS_COLLECTION).Query, model.SearchTypeTask)
+	go w.watch(ctx, w.client.CollectionGroup(utils.EnvInstances.TASKS_SUBCOLLECTION).Query, model.SearchTypeSubtask)
+	go w.watch(ctx, w.client.CollectionGroup(utils.EnvInstances.RESPONSES_COLLECTION).Query, model.SearchTypeResponse)
+}
+
+// watch is a private method that listens to a query and starts a new listener when the current one fails
+//
+// Parameters:
+//   - ctx: The context that stops the listener when cancelled
+//   - query: The query to listen to
+//   - docType: The type of the documents returned by the query
+func (w *Watcher) watch(ctx context.Context, query firestore.Query, docType string) {
+	for {
+		iterator := query.Snapshots(ctx)
+		err := w.consume(iterator, docType)
+		iterator.Stop()
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Printf("Search listener for %s documents stopped: %v", docType, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// consume is a private method that applies the snapshots of a listener to the index.
+// The first snapshot holds all the documents, so it replaces the documents of the type
+// to drop the ones deleted while the listener was not running
+//
+// Parameters:
+//   - iterator: The snapshot iterator of the listener
+//   - docType: The type of the documents
+//
+// Returns:
+//   - error: The error that stopped the listener
+func (w *Watcher) consume(iterator *firestore.QuerySnapshotIterator, docType string) error {
+	isFirst := true
+
+	for {
+		snapshot, err := iterator.Next()
+		if err != nil {
+			return err
+		}
+
+		if isFirst {
+			documents := []Document{}
+			for _, change := range snapshot.Changes {
+				if document, ok := toDocument(docType, change.Doc); ok {
+					documents = append(documents, document)
+				}
+			}
+
+			w.index.Replace(docType, documents)
+			isFirst = false
+			continue
+		}
+
+		for _, change := range snapshot.Changes {
+			if change.Kind == firestore.DocumentRemoved {
+				w.index.Remove(docType, change.Doc.Ref.ID)
+				continue
+			}
+
+			if document, ok := toDocument(docType, change.Doc); ok {
+				w.index.Add(document)
+			}
+		}
+	}
+}
+
+// toDocument converts a document snapshot into a searchable document
+//
+// Parameters:
+//   - docType: The type of the document
+//   - doc: The document snapshot
+//
+// Returns:
+//   - Document: The searchable document
+//   - bool: False if the snapshot could not be converted
+func toDocument(docType string, doc *firestore.DocumentSnapshot) (Document, bool) {
+	switch docType {
+	case model.SearchTypeTask:
+		var task model.Task
+		if err := doc.DataTo(&task); err != nil {
+			return Document{}, false
+		}
+		return Document{ID: doc.Ref.ID, Type: docType, TaskID: doc.Ref.ID, ProjectID: task.ProjectID, Text: task.Description}, true
+
+	case model.SearchTypeSubtask:
+		var subtask model.Subtask
+		if err := doc.DataTo(&subtask); err != nil {
+			return Document{}, false
+		}
+		return Document{ID: doc.Ref.ID, Type: docType, TaskID: subtask.TaskID, Text: subtask.Description}, true
+
+	case model.SearchTypeResponse:
+		var response model.Response
+		if err := doc.DataTo(&response); err != nil {
+			return Document{}, false
+		}
+		return Document{ID: doc.Ref.ID, Type: docType, TaskID: response.TaskID, Text: response.Message}, true
+	}
+
+	return Document{}, false
+}
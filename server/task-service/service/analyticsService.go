@@ -0,0 +1,548 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/horatiucrisan/task-service/cache"
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+// The max time window of the analytics requests, it bounds the number of computed days
+const maxAnalyticsWindow = 366 * 24 * time.Hour
+
+// analyticsKey identifies the cached analytics of a project time window
+type analyticsKey struct {
+	projectId string
+	from      int64
+	to        int64
+}
+
+type analyticsService struct {
+	analyticsRepository interfaces.AnalyticsRepository
+	taskRepository      interfaces.TaskRepository
+	projectRepository   interfaces.ProjectRepository
+	cache               *cache.Cache[analyticsKey, model.ProjectAnalytics]
+	flowCache           *cache.Cache[analyticsKey, model.CumulativeFlow]
+	cycleTimeCache      *cache.Cache[analyticsKey, model.CycleTimeMetrics]
+}
+
+func NewAnalyticsService(analyticsRepository interfaces.AnalyticsRepository, taskRepository interfaces.TaskRepository, projectRepository interfaces.ProjectRepository, cacheTTL time.Duration) interfaces.AnalyticsService {
+	return &analyticsService{
+		analyticsRepository: analyticsRepository,
+		taskRepository:      taskRepository,
+		projectRepository:   projectRepository,
+		cache:               cache.New[analyticsKey, model.ProjectAnalytics](cacheTTL),
+		flowCache:           cache.New[analyticsKey, model.CumulativeFlow](cacheTTL),
+		cycleTimeCache:      cache.New[analyticsKey, model.CycleTimeMetrics](cacheTTL),
+	}
+}
+
+// GetProjectAnalytics retrieves the data from the controller layer and computes the progress of a project inside a time window.
+// The aggregates are cached per project and window, so repeated requests do not read the whole project again
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - projectId: The ID of the project
+//   - from: The inclusive start of the window
+//   - to: The exclusive end of the window
+//
+// Returns:
+//   - model.ProjectAnalytics: The analytics of the project
+//   - error: An error that occured during the process
+func (s *analyticsService) GetProjectAnalytics(ctx context.Context, userId, projectId string, from, to int64) (model.ProjectAnalytics, error) {
+	if to-from > maxAnalyticsWindow.Milliseconds() {
+		return model.ProjectAnalytics{}, fmt.Errorf("%w: the window cannot be longer than %s", utils.ErrInvalidTimeWindow, maxAnalyticsWindow)
+	}
+
+	// Check if the user is part of the project before reading the cache
+	if err := s.checkProjectMember(ctx, userId, projectId); err != nil {
+		return model.ProjectAnalytics{}, err
+	}
+
+	key := analyticsKey{projectId: projectId, from: from, to: to}
+	if analytics, ok := s.cache.Get(key); ok {
+		return analytics, nil
+	}
+
+	tasks, history, err := s.getProjectHistory(ctx, projectId, to)
+	if err != nil {
+		return model.ProjectAnalytics{}, err
+	}
+
+	analytics := projectAnalytics(projectId, tasks, history, from, to, time.Now().UnixMilli())
+	s.cache.Set(key, analytics)
+
+	return analytics, nil
+}
+
+// GetCumulativeFlow retrieves the data from the controller layer and computes the cumulative flow diagram series of a project.
+// The number of tasks in each status at the end of every day is rebuilt from the status history
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - projectId: The ID of the project
+//   - from: The inclusive start of the window
+//   - to: The exclusive end of the window
+//
+// Returns:
+//   - model.CumulativeFlow: The daily series of the project, up to the current day
+//   - error: An error that occured during the process
+func (s *analyticsService) GetCumulativeFlow(ctx context.Context, userId, projectId string, from, to int64) (model.CumulativeFlow, error) {
+	if to-from > maxAnalyticsWindow.Milliseconds() {
+		return model.CumulativeFlow{}, fmt.Errorf("%w: the window cannot be longer than %s", utils.ErrInvalidTimeWindow, maxAnalyticsWindow)
+	}
+
+	if err := s.checkProjectMember(ctx, userId, projectId); err != nil {
+		return model.CumulativeFlow{}, err
+	}
+
+	key := analyticsKey{projectId: projectId, from: from, to: to}
+	if flow, ok := s.flowCache.Get(key); ok {
+		return flow, nil
+	}
+
+	tasks, history, err := s.getProjectHistory(ctx, projectId, to)
+	if err != nil {
+		return model.CumulativeFlow{}, err
+	}
+
+	flow := model.CumulativeFlow{ProjectID: projectId, From: from, To: to, Statuses: model.TaskStatuses, Days: []model.FlowPoint{}}
+
+	now := time.Now().UnixMilli()
+	day := (24 * time.Hour).Milliseconds()
+
+	// Walk over the days of the window, starting from the UTC day the window starts on
+	for dayStart := time.UnixMilli(from).UTC().Truncate(24 * time.Hour).UnixMilli(); dayStart < to && dayStart <= now; dayStart += day {
+		at := min(dayStart+day, to, now)
+
+		point := model.FlowPoint{Date: dayStart, Statuses: map[string]int{}}
+		for _, taskStatus := range model.TaskStatuses {
+			point.Statuses[taskStatus] = 0
+		}
+
+		for _, task := range tasks {
+			if taskStatus := taskStatusAt(task, history[task.ID], at); taskStatus != "" {
+				point.Statuses[taskStatus]++
+			}
+		}
+
+		flow.Days = append(flow.Days, point)
+	}
+
+	s.flowCache.Set(key, flow)
+
+	return flow, nil
+}
+
+// GetCycleTimeMetrics retrieves the data from the controller layer and computes the lead time, the cycle time and the time
+// spent in each status by the tasks of a project completed inside a time window
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - projectId: The ID of the project
+//   - from: The inclusive start of the window
+//   - to: The exclusive end of the window
+//
+// Returns:
+//   - model.CycleTimeMetrics: The percentiles of the completed tasks
+//   - error: An error that occured during the process
+func (s *analyticsService) GetCycleTimeMetrics(ctx context.Context, userId, projectId string, from, to int64) (model.CycleTimeMetrics, error) {
+	if to-from > maxAnalyticsWindow.Milliseconds() {
+		return model.CycleTimeMetrics{}, fmt.Errorf("%w: the window cannot be longer than %s", utils.ErrInvalidTimeWindow, maxAnalyticsWindow)
+	}
+
+	if err := s.checkProjectMember(ctx, userId, projectId); err != nil {
+		return model.CycleTimeMetrics{}, err
+	}
+
+	key := analyticsKey{projectId: projectId, from: from, to: to}
+	if metrics, ok := s.cycleTimeCache.Get(key); ok {
+		return metrics, nil
+	}
+
+	tasks, history, err := s.getProjectHistory(ctx, projectId, to)
+	if err != nil {
+		return model.CycleTimeMetrics{}, err
+	}
+
+	var leadTimes, cycleTimes []int64
+	statusTimes := map[string][]int64{}
+
+	for _, task := range tasks {
+		completedAt, ok := taskCompletedAt(task, history[task.ID], to)
+		if !ok || completedAt < from {
+			continue
+		}
+
+		leadTimes = append(leadTimes, completedAt-task.CreatedAt)
+		if cycleTime, ok := taskCycleTime(history[task.ID], completedAt); ok {
+			cycleTimes = append(cycleTimes, cycleTime)
+		}
+
+		// Sum the time the task spent in each open status before it was completed
+		totals := map[string]int64{}
+		for _, period := range taskStatusPeriods(task, history[task.ID], completedAt) {
+			if period.Status != model.TaskStatusCompleted {
+				totals[period.Status] += period.Duration
+			}
+		}
+
+		for taskStatus, duration := range totals {
+			statusTimes[taskStatus] = append(statusTimes[taskStatus], duration)
+		}
+	}
+
+	metrics := model.CycleTimeMetrics{
+		ProjectID:      projectId,
+		From:           from,
+		To:             to,
+		CompletedTasks: len(leadTimes),
+		LeadTime:       durationPercentiles(leadTimes),
+		CycleTime:      durationPercentiles(cycleTimes),
+		TimeInStatus:   map[string]model.Percentiles{},
+	}
+
+	for taskStatus, durations := range statusTimes {
+		metrics.TimeInStatus[taskStatus] = durationPercentiles(durations)
+	}
+
+	s.cycleTimeCache.Set(key, metrics)
+
+	return metrics, nil
+}
+
+// GetTaskTimeInStatus retrieves the data from the controller layer and returns the time a task spent in each status
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - taskId: The ID of the task
+//
+// Returns:
+//   - model.TimeInStatus: The status periods of the task and their totals
+//   - error: An error that occured during the process
+func (s *analyticsService) GetTaskTimeInStatus(ctx context.Context, userId, taskId string) (model.TimeInStatus, error) {
+	task, err := s.taskRepository.GetTaskById(ctx, taskId)
+	if err != nil {
+		return model.TimeInStatus{}, err
+	}
+
+	if err := s.checkProjectMember(ctx, userId, task.ProjectID); err != nil {
+		return model.TimeInStatus{}, err
+	}
+
+	transitions, err := s.analyticsRepository.GetTaskTransitions(ctx, taskId)
+	if err != nil {
+		return model.TimeInStatus{}, err
+	}
+
+	timeInStatus := model.TimeInStatus{
+		TaskID:  task.ID,
+		Status:  task.Status,
+		Periods: taskStatusPeriods(task, transitions, time.Now().UnixMilli()),
+		Totals:  map[string]int64{},
+	}
+
+	for _, period := range timeInStatus.Periods {
+		timeInStatus.Totals[period.Status] += period.Duration
+	}
+
+	return timeInStatus, nil
+}
+
+// checkProjectMember is a private method that checks if the user is part of a project
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user
+//   - projectId: The ID of the project
+//
+// Returns:
+//   - error: An error that occured during the process
+func (s *analyticsService) checkProjectMember(ctx context.Context, userId, projectId string) error {
+	project, err := s.projectRepository.GetProjectById(ctx, projectId)
+	if err != nil {
+		return err
+	}
+
+	if !isProjectMember(project, userId) {
+		return utils.ErrForbidden
+	}
+
+	return nil
+}
+
+// getProjectHistory is a private method that returns the tasks of a project and their status history
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - projectId: The ID of the project
+//   - to: The exclusive end of the history
+//
+// Returns:
+//   - []model.Task: The list of project tasks
+//   - map[string][]model.StatusTransition: The status transitions of each task sorted by their date
+//   - error: An error that occured during the process
+func (s *analyticsService) getProjectHistory(ctx context.Context, projectId string, to int64) ([]model.Task, map[string][]model.StatusTransition, error) {
+	tasks, err := s.taskRepository.GetProjectTasks(ctx, projectId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transitions, err := s.analyticsRepository.GetProjectTransitions(ctx, projectId, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Group the status history by task, the transitions are already sorted by their date
+	history := map[string][]model.StatusTransition{}
+	for _, transition := range transitions {
+		history[transition.TaskID] = append(history[transition.TaskID], transition)
+	}
+
+	return tasks, history, nil
+}
+
+// projectAnalytics is a private function that aggregates the tasks of a project and their status history
+//
+// Parameters:
+//   - projectId: The ID of the project
+//   - tasks: The list of project tasks
+//   - history: The status transitions of each task sorted by their date
+//   - from: The inclusive start of the window
+//   - to: The exclusive end of the window
+//   - now: The current timestamp
+//
+// Returns:
+//   - model.ProjectAnalytics: The analytics of the project
+func projectAnalytics(projectId string, tasks []model.Task, history map[string][]model.StatusTransition, from, to, now int64) model.ProjectAnalytics {
+	analytics := model.ProjectAnalytics{
+		ProjectID:       projectId,
+		From:            from,
+		To:              to,
+		GeneratedAt:     now,
+		TasksByStatus:   map[string]int{},
+		HandlerWorkload: []model.HandlerWorkload{},
+	}
+
+	var leadTime, cycleTime int64
+	var leadCount, cycleCount int64
+	var taskCompletion float64
+	var tasksWithSubtasks int
+	workload := map[string]*model.HandlerWorkload{}
+
+	for _, task := range tasks {
+		transitions := history[task.ID]
+
+		// Count the tasks created inside the window by the status they had at its end
+		if task.CreatedAt >= from && task.CreatedAt < to {
+			taskStatus := taskStatusAt(task, transitions, to-1)
+
+			analytics.TotalTasks++
+			analytics.TasksByStatus[taskStatus]++
+
+			if taskStatus == model.TaskStatusCompleted {
+				analytics.CompletedTasks++
+			} else if task.Deadline > 0 && task.Deadline < min(to, now) {
+				analytics.OverdueTasks++
+			}
+
+			analytics.Subtasks.Total += task.SubtaskCount
+			analytics.Subtasks.Completed += task.CompletedSubtaskCount
+			if task.SubtaskCount > 0 {
+				taskCompletion += float64(task.CompletedSubtaskCount) / float64(task.SubtaskCount)
+				tasksWithSubtasks++
+			}
+		}
+
+		// Measure the lead and cycle times of the tasks completed inside the window
+		if completedAt, ok := taskCompletedAt(task, transitions, to); ok && completedAt >= from {
+			leadTime += completedAt - task.CreatedAt
+			leadCount++
+
+			if taskCycle, ok := taskCycleTime(transitions, completedAt); ok {
+				cycleTime += taskCycle
+				cycleCount++
+			}
+		}
+
+		// Sum the open work of every handler
+		if !slices.Contains(model.OpenTaskStatuses, task.Status) {
+			continue
+		}
+
+		points, _ := taskEstimate(task)
+		for _, handlerId := range task.HandlerIDs {
+			handlerWorkload, ok := workload[handlerId]
+			if !ok {
+				handlerWorkload = &model.HandlerWorkload{HandlerID: handlerId}
+				workload[handlerId] = handlerWorkload
+			}
+
+			handlerWorkload.OpenTasks++
+			handlerWorkload.OpenPoints += points
+			if task.Deadline > 0 && task.Deadline < now {
+				handlerWorkload.OverdueTasks++
+			}
+		}
+	}
+
+	if analytics.TotalTasks > 0 {
+		analytics.CompletionRate = float64(analytics.CompletedTasks) / float64(analytics.TotalTasks)
+	}
+
+	if leadCount > 0 {
+		analytics.AverageLeadTime = leadTime / leadCount
+	}
+
+	if cycleCount > 0 {
+		analytics.AverageCycleTime = cycleTime / cycleCount
+	}
+
+	if analytics.Subtasks.Total > 0 {
+		analytics.Subtasks.CompletionRate = float64(analytics.Subtasks.Completed) / float64(analytics.Subtasks.Total)
+	}
+
+	if tasksWithSubtasks > 0 {
+		analytics.Subtasks.AverageTaskCompletion = taskCompletion / float64(tasksWithSubtasks)
+	}
+
+	// Order the handlers by their open work, the busiest first
+	for _, handlerWorkload := range workload {
+		analytics.HandlerWorkload = append(analytics.HandlerWorkload, *handlerWorkload)
+	}
+
+	slices.SortFunc(analytics.HandlerWorkload, func(a, b model.HandlerWorkload) int {
+		return cmp.Or(cmp.Compare(b.OpenTasks, a.OpenTasks), cmp.Compare(a.HandlerID, b.HandlerID))
+	})
+
+	return analytics
+}
+
+// taskCompletedAt is a private function that returns the date a task was completed before a given date.
+// A task that was reopened afterwards is not considered completed
+//
+// Parameters:
+//   - task: The task data
+//   - transitions: The status transitions of the task sorted by their date
+//   - to: The exclusive end of the history
+//
+// Returns:
+//   - int64: The date of the last completion
+//   - bool: True if the task was completed at the given date
+func taskCompletedAt(task model.Task, transitions []model.StatusTransition, to int64) (int64, bool) {
+	if taskStatusAt(task, transitions, to-1) != model.TaskStatusCompleted {
+		return 0, false
+	}
+
+	for _, transition := range slices.Backward(transitions) {
+		if transition.ChangedAt < to && transition.To == model.TaskStatusCompleted {
+			return transition.ChangedAt, true
+		}
+	}
+
+	// The task was completed before its history was recorded
+	return 0, false
+}
+
+// taskCycleTime is a private function that returns the time between the start of the development of a task and its completion
+//
+// Parameters:
+//   - transitions: The status transitions of the task sorted by their date
+//   - completedAt: The date the task was completed
+//
+// Returns:
+//   - int64: The cycle time of the task
+//   - bool: False if the development of the task never started
+func taskCycleTime(transitions []model.StatusTransition, completedAt int64) (int64, bool) {
+	for _, transition := range transitions {
+		if transition.ChangedAt > completedAt {
+			break
+		}
+
+		if transition.To == model.TaskStatusDevelopment {
+			return completedAt - transition.ChangedAt, true
+		}
+	}
+
+	return 0, false
+}
+
+// taskStatusPeriods is a private function that splits the life of a task into the periods it spent in each status
+//
+// Parameters:
+//   - task: The task data
+//   - transitions: The status transitions of the task sorted by their date
+//   - until: The date the last period ends at
+//
+// Returns:
+//   - []model.StatusPeriod: The status periods ordered by their start date
+func taskStatusPeriods(task model.Task, transitions []model.StatusTransition, until int64) []model.StatusPeriod {
+	periods := []model.StatusPeriod{}
+
+	current := model.StatusPeriod{Status: task.Status, StartedAt: task.CreatedAt}
+	if len(transitions) > 0 {
+		current.Status = transitions[0].From
+	}
+
+	for _, transition := range transitions {
+		if transition.ChangedAt > until {
+			break
+		}
+
+		current.EndedAt = transition.ChangedAt
+		current.Duration = max(current.EndedAt-current.StartedAt, 0)
+		periods = append(periods, current)
+
+		current = model.StatusPeriod{Status: transition.To, StartedAt: transition.ChangedAt}
+	}
+
+	current.EndedAt = until
+	current.Duration = max(current.EndedAt-current.StartedAt, 0)
+
+	return append(periods, current)
+}
+
+// durationPercentiles is a private function that computes the distribution of a list of durations using the nearest-rank method
+//
+// Parameters:
+//   - durations: The list of durations
+//
+// Returns:
+//   - model.Percentiles: The count, the average and the percentiles of the durations
+func durationPercentiles(durations []int64) model.Percentiles {
+	if len(durations) == 0 {
+		return model.Percentiles{}
+	}
+
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+
+	var total int64
+	for _, duration := range sorted {
+		total += duration
+	}
+
+	percentile := func(p int) int64 {
+		rank := (p*len(sorted) + 99) / 100
+		return sorted[max(rank-1, 0)]
+	}
+
+	return model.Percentiles{
+		Count:   len(sorted),
+		Average: total / int64(len(sorted)),
+		P50:     percentile(50),
+		P75:     percentile(75),
+		P85:     percentile(85),
+		P95:     percentile(95),
+	}
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+type availabilityService struct {
+	availabilityRepository interfaces.AvailabilityRepository
+	taskRepository         interfaces.TaskRepository
+	projectRepository      interfaces.ProjectRepository
+}
+
+func NewAvailabilityService(availabilityRepository interfaces.AvailabilityRepository, taskRepository interfaces.TaskRepository, projectRepository interfaces.ProjectRepository) interfaces.AvailabilityService {
+	return &availabilityService{availabilityRepository: availabilityRepository, taskRepository: taskRepository, projectRepository: projectRepository}
+}
+
+// CreateAbsence retrieves the data from the controller layer and creates a new absence of a project member.
+// The members can add their own absences and the project manager can add the absences of any member
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - projectId: The ID of the project
+//   - memberId: The ID of the absent member
+//   - startAt: The start of the absence
+//   - endAt: The end of the absence
+//   - reason: The optional reason of the absence
+//
+// Returns:
+//   - model.Absence: The created absence
+//   - error: An error that occured during the process
+func (s *availabilityService) CreateAbsence(ctx context.Context, userId, projectId, memberId string, startAt, endAt int64, reason string) (model.Absence, error) {
+	project, err := s.projectRepository.GetProjectById(ctx, projectId)
+	if err != nil {
+		return model.Absence{}, err
+	}
+
+	if memberId != userId && project.ProjectManagerID != userId {
+		return model.Absence{}, utils.ErrForbidden
+	}
+
+	if !isProjectMember(project, memberId) {
+		return model.Absence{}, fmt.Errorf("%w: user `%s` is not a member of the project", utils.ErrInvalidAbsence, memberId)
+	}
+
+	if endAt <= startAt {
+		return model.Absence{}, fmt.Errorf("%w: the absence must end after it starts", utils.ErrInvalidAbsence)
+	}
+
+	absence := model.Absence{
+		ID:        uuid.NewString(),
+		ProjectID: projectId,
+		UserID:    memberId,
+		StartAt:   startAt,
+		EndAt:     endAt,
+		Reason:    reason,
+		CreatedBy: userId,
+		CreatedAt: time.Now().UnixMilli(),
+	}
+
+	// Send the data to the repository layer to store the absence
+	return s.availabilityRepository.CreateAbsence(ctx, absence)
+}
+
+// GetAbsences retrieves the data from the controller layer and returns the absences of a project that overlap a date range
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - projectId: The ID of the project
+//   - from: The start of the date range
+//   - to: The end of the date range
+//
+// Returns:
+//   - []model.Absence: The list of absences
+//   - error: An error that occured during the process
+func (s *availabilityService) GetAbsences(ctx context.Context, userId, projectId string, from, to int64) ([]model.Absence, error) {
+	project, err := s.projectRepository.GetProjectById(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isProjectMember(project, userId) {
+		return nil, utils.ErrForbidden
+	}
+
+	return s.availabilityRepository.GetAbsences(ctx, projectId, from, to)
+}
+
+// GetTasksAtRisk retrieves the data from the controller layer and returns the open tasks of a project
+// whose deadline falls inside an absence of one of their handlers that is in progress or starts in the next days
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - projectId: The ID of the project
+//   - days: The number of days the upcoming absences are looked up for
+//
+// Returns:
+//   - []model.TaskAtRisk: The tasks at risk ordered by their deadline
+//   - error: An error that occured during the process
+func (s *availabilityService) GetTasksAtRisk(ctx context.Context, userId, projectId string, days int) ([]model.TaskAtRisk, error) {
+	project, err := s.projectRepository.GetProjectById(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isProjectMember(project, userId) {
+		return nil, utils.ErrForbidden
+	}
+
+	now := time.Now()
+	absences, err := s.availabilityRepository.GetAbsences(ctx, projectId, now.UnixMilli(), now.AddDate(0, 0, days).UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+
+	tasksAtRisk := []model.TaskAtRisk{}
+	if len(absences) == 0 {
+		return tasksAtRisk, nil
+	}
+
+	tasks, err := s.taskRepository.GetProjectTasks(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, task := range tasks {
+		if !slices.Contains(model.OpenTaskStatuses, task.Status) {
+			continue
+		}
+
+		if warnings := absenceWarnings(absences, task.HandlerIDs, task.Deadline); len(warnings) > 0 {
+			tasksAtRisk = append(tasksAtRisk, model.TaskAtRisk{Task: task, Warnings: warnings})
+		}
+	}
+
+	slices.SortStableFunc(tasksAtRisk, func(a, b model.TaskAtRisk) int {
+		return cmp.Compare(a.Task.Deadline, b.Task.Deadline)
+	})
+
+	return tasksAtRisk, nil
+}
+
+// DeleteAbsence retrieves the data from the controller layer and deletes an absence of a project member.
+// The members can delete their own absences and the project manager can delete the absences of any member
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - projectId: The ID of the project
+//   - absenceId: The ID of the absence
+//
+// Returns:
+//   - model.Absence: The deleted absence
+//   - error: An error that occured during the process
+func (s *availabilityService) DeleteAbsence(ctx context.Context, userId, projectId, absenceId string) (model.Absence, error) {
+	project, err := s.projectRepository.GetProjectById(ctx, projectId)
+	if err != nil {
+		return model.Absence{}, err
+	}
+
+	absence, err := s.availabilityRepository.GetAbsenceById(ctx, projectId, absenceId)
+	if err != nil {
+		return model.Absence{}, err
+	}
+
+	if absence.UserID != userId && project.ProjectManagerID != userId {
+		return model.Absence{}, utils.ErrForbidden
+	}
+
+	// Send the data to the repository layer to delete the absence
+	if err = s.availabilityRepository.DeleteAbsence(ctx, projectId, absenceId); err != nil {
+		return model.Absence{}, err
+	}
+
+	return absence, nil
+}
+
+// absenceWarnings is a private function that returns the absences of the task handlers that cover the task deadline
+//
+// Parameters:
+//   - absences: The absences of the project members
+//   - handlerIds: The IDs of the task handlers
+//   - deadline: The deadline of the task
+//
+// Returns:
+//   - []model.AbsenceWarning: The absences that cover the deadline
+func absenceWarnings(absences []model.Absence, handlerIds []string, deadline int64) []model.AbsenceWarning {
+	warnings := []model.AbsenceWarning{}
+	for _, absence := range absences {
+		if absence.StartAt > deadline || absence.EndAt < deadline || !slices.Contains(handlerIds, absence.UserID) {
+			continue
+		}
+
+		warnings = append(warnings, model.AbsenceWarning{
+			HandlerID: absence.UserID,
+			AbsenceID: absence.ID,
+			StartAt:   absence.StartAt,
+			EndAt:     absence.EndAt,
+		})
+	}
+
+	return warnings
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+)
+
+// The reminder offsets used when none are configured
+var defaultReminderOffsets = []time.Duration{24 * time.Hour, time.Hour}
+
+// The time an overdue task can stay open before it is escalated when no grace period is configured
+const defaultEscalationGrace = 24 * time.Hour
+
+type deadlineService struct {
+	deadlineRepository interfaces.DeadlineRepository
+	projectRepository  interfaces.ProjectRepository
+	reminderOffsets    []time.Duration
+	escalationGrace    time.Duration
+	backfilled         bool
+}
+
+// NewDeadlineService generates the service that checks the deadlines of the open tasks
+//
+// Parameters:
+//   - deadlineRepository: The deadline repository layer
+//   - projectRepository: The project repository layer
+//   - reminderOffsets: The time before the deadline at which the reminders are sent, the defaults are used when empty
+//   - escalationGrace: The time after the deadline at which an open task is escalated, the default is used when 0
+//
+// Returns:
+//   - interfaces.DeadlineService: The deadline service
+func NewDeadlineService(deadlineRepository interfaces.DeadlineRepository, projectRepository interfaces.ProjectRepository, reminderOffsets []time.Duration, escalationGrace time.Duration) interfaces.DeadlineService {
+	if len(reminderOffsets) == 0 {
+		reminderOffsets = defaultReminderOffsets
+	}
+
+	if escalationGrace <= 0 {
+		escalationGrace = defaultEscalationGrace
+	}
+
+	// Sort the offsets so the closest reminder is checked first
+	reminderOffsets = slices.Clone(reminderOffsets)
+	slices.Sort(reminderOffsets)
+
+	return &deadlineService{
+		deadlineRepository: deadlineRepository,
+		projectRepository:  projectRepository,
+		reminderOffsets:    reminderOffsets,
+		escalationGrace:    escalationGrace,
+	}
+}
+
+// CheckDeadlines claims the reminders, overdue notices and escalations that are due for the open tasks.
+// It is run periodically by the scheduler and every returned notice was claimed by this call only
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//
+// Returns:
+//   - []model.DeadlineNotice: The notices that have to be sent
+//   - error: The errors that occured while checking the tasks
+func (s *deadlineService) CheckDeadlines(ctx context.Context) ([]model.DeadlineNotice, error) {
+	// The open tasks created before the escalations are prepared for the overdue scan on the first check
+	if !s.backfilled {
+		if _, err := s.deadlineRepository.BackfillEscalatedAt(ctx); err != nil {
+			return nil, err
+		}
+
+		s.backfilled = true
+	}
+
+	now := time.Now().UnixMilli()
+	notices := []model.DeadlineNotice{}
+	var errs []error
+
+	// Get the tasks whose deadline is inside the largest reminder window
+	maxOffset := s.reminderOffsets[len(s.reminderOffsets)-1]
+	upcomingTasks, err := s.deadlineRepository.GetOpenTasksByDeadline(ctx, now, now+maxOffset.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, task := range upcomingTasks {
+		// Only the closest reminder window that was reached is sent, the wider ones are skipped
+		offset, ok := reminderOffset(s.reminderOffsets, task.Deadline, now)
+		if !ok {
+			continue
+		}
+
+		claimedTask, claimed, err := s.deadlineRepository.ClaimTaskReminder(ctx, task.ID, task.Deadline, reminderKey(task.Deadline, offset))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("task %s: %w", task.ID, err))
+			continue
+		}
+
+		if claimed {
+			notices = append(notices, model.DeadlineNotice{Type: model.NoticeReminder, Task: claimedTask, Offset: offset.Milliseconds()})
+		}
+	}
+
+	// Get the open tasks that passed their deadline and were not escalated yet,
+	// no matter how long ago the deadline passed
+	overdueTasks, err := s.deadlineRepository.GetOverdueTasks(ctx, now)
+	if err != nil {
+		return notices, errors.Join(append(errs, err)...)
+	}
+
+	projectManagers := map[string]string{}
+	for _, task := range overdueTasks {
+		if task.OverdueAt == nil {
+			claimedTask, claimed, err := s.deadlineRepository.MarkTaskOverdue(ctx, task.ID, task.Deadline)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("task %s: %w", task.ID, err))
+				continue
+			}
+
+			if !claimed {
+				continue
+			}
+
+			task = claimedTask
+			notices = append(notices, model.DeadlineNotice{Type: model.NoticeOverdue, Task: task})
+		}
+
+		// Escalate the task once the grace period passed
+		if task.EscalatedAt != nil || task.Deadline+s.escalationGrace.Milliseconds() > now {
+			continue
+		}
+
+		projectManagerId, ok := projectManagers[task.ProjectID]
+		if !ok {
+			project, err := s.projectRepository.GetProjectById(ctx, task.ProjectID)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("task %s: %w", task.ID, err))
+				continue
+			}
+
+			projectManagerId = project.ProjectManagerID
+			projectManagers[task.ProjectID] = projectManagerId
+		}
+
+		claimedTask, claimed, err := s.deadlineRepository.EscalateOverdueTask(ctx, task.ID, task.Deadline)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("task %s: %w", task.ID, err))
+			continue
+		}
+
+		if claimed {
+			notices = append(notices, model.DeadlineNotice{
+				Type:             model.NoticeEscalation,
+				Task:             claimedTask,
+				Offset:           s.escalationGrace.Milliseconds(),
+				ProjectManagerID: projectManagerId,
+			})
+		}
+	}
+
+	return notices, errors.Join(errs...)
+}
+
+// ReleaseDeadlineNotice removes the claim of a notice that could not be sent, so the next check claims it again
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - notice: The notice that was not sent
+//
+// Returns:
+//   - error: An error that occured during the process
+func (s *deadlineService) ReleaseDeadlineNotice(ctx context.Context, notice model.DeadlineNotice) error {
+	switch notice.Type {
+	case model.NoticeReminder:
+		offset := time.Duration(notice.Offset) * time.Millisecond
+		return s.deadlineRepository.ReleaseTaskReminder(ctx, notice.Task.ID, reminderKey(notice.Task.Deadline, offset))
+	case model.NoticeOverdue:
+		return s.deadlineRepository.ReleaseTaskOverdue(ctx, notice.Task.ID)
+	case model.NoticeEscalation:
+		return s.deadlineRepository.ReleaseTaskEscalation(ctx, notice.Task.ID)
+	default:
+		return fmt.Errorf("unsupported deadline notice `%s`", notice.Type)
+	}
+}
+
+// reminderKey is a private function that generates the key a reminder is claimed with
+//
+// Parameters:
+//   - deadline: The deadline of the task
+//   - offset: The offset of the reminder
+//
+// Returns:
+//   - string: The reminder key
+func reminderKey(deadline int64, offset time.Duration) string {
+	return fmt.Sprintf("%d/%s", deadline, offset)
+}
+
+// reminderOffset is a private function that returns the closest reminder window a deadline entered
+//
+// Parameters:
+//   - offsets: The reminder offsets sorted in ascending order
+//   - deadline: The deadline of the task
+//   - now: The current timestamp
+//
+// Returns:
+//   - time.Duration: The offset of the reminder
+//   - bool: True if a reminder window was reached and false otherwise
+func reminderOffset(offsets []time.Duration, deadline, now int64) (time.Duration, bool) {
+	for _, offset := range offsets {
+		if deadline-offset.Milliseconds() <= now {
+			return offset, true
+		}
+	}
+
+	return 0, false
+}
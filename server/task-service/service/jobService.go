@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+)
+
+// The number of times a job is attempted before it is marked as failed
+const defaultJobAttempts = 3
+
+type jobService struct {
+	jobRepository interfaces.JobRepository
+}
+
+func NewJobService(jobRepository interfaces.JobRepository) interfaces.JobService {
+	return &jobService{jobRepository: jobRepository}
+}
+
+// EnqueueJob creates a new pending job that is picked up by the worker pool
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - jobType: The type of the job
+//   - createdBy: The ID of the user that started the job
+//   - payload: The data the job handler needs
+//   - total: The estimated number of items the job processes
+//
+// Returns:
+//   - model.Job: The created job data
+//   - error: An error that occured during the process
+func (s *jobService) EnqueueJob(ctx context.Context, jobType string, createdBy string, payload map[string]any, total int64) (model.Job, error) {
+	// Get the current timestamp
+	now := time.Now().UnixMilli()
+
+	// Generate the job object
+	jobData := model.Job{
+		ID:          uuid.NewString(),
+		Type:        jobType,
+		Status:      model.JobStatusPending,
+		Payload:     payload,
+		CreatedBy:   createdBy,
+		Processed:   0,
+		Total:       total,
+		Attempts:    0,
+		MaxAttempts: defaultJobAttempts,
+		RunAt:       now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	// Send the data to the repository layer to create the job
+	job, err := s.jobRepository.CreateJob(ctx, jobData)
+	if err != nil {
+		return model.Job{}, err
+	}
+
+	return job, nil
+}
+
+// GetJobById retrieves the data from the controller layer and returns the data of the job
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - jobId: The ID of the job
+//
+// Returns:
+//   - model.Job: The data of the job
+//   - error: An error that occured during the process
+func (s *jobService) GetJobById(ctx context.Context, jobId string) (model.Job, error) {
+	// Send the data to the repository layer to retrieve the job
+	return s.jobRepository.GetJobById(ctx, jobId)
+}
+
+// CancelJob retrieves the data from the controller layer and sends it to the repository layer to cancel the job
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - jobId: The ID of the job
+//
+// Returns:
+//   - model.Job: The updated job data
+//   - error: An error that occured during the process
+func (s *jobService) CancelJob(ctx context.Context, jobId string) (model.Job, error) {
+	// Send the data to the repository layer to cancel the job
+	job, err := s.jobRepository.CancelJob(ctx, jobId)
+	if err != nil {
+		return model.Job{}, err
+	}
+
+	return job, nil
+}
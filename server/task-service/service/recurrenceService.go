@@ -0,0 +1,451 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+// The max number of due series advanced by a single scheduler run
+const dueSeriesBatchSize = 50
+
+type recurrenceService struct {
+	recurrenceRepository interfaces.RecurrenceRepository
+	taskRepository       interfaces.TaskRepository
+	projectRepository    interfaces.ProjectRepository
+}
+
+func NewRecurrenceService(recurrenceRepository interfaces.RecurrenceRepository, taskRepository interfaces.TaskRepository, projectRepository interfaces.ProjectRepository) interfaces.RecurrenceService {
+	return &recurrenceService{recurrenceRepository: recurrenceRepository, taskRepository: taskRepository, projectRepository: projectRepository}
+}
+
+// CreateSeries retrieves the data from the controller layer and turns a task into the first occurrence of a new series.
+// The deadline of the task is the anchor the deadlines of the next occurrences are computed from
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - taskId: The ID of the task
+//   - rule: The recurrence rule of the series
+//
+// Returns:
+//   - model.SeriesOccurrence: The created series and the updated task
+//   - error: An error that occured during the process
+func (s *recurrenceService) CreateSeries(ctx context.Context, userId, taskId string, rule model.RecurrenceRule) (model.SeriesOccurrence, error) {
+	// Get the task and check if the user is part of its project
+	task, err := s.taskRepository.GetTaskById(ctx, taskId)
+	if err != nil {
+		return model.SeriesOccurrence{}, err
+	}
+
+	project, err := s.projectRepository.GetProjectById(ctx, task.ProjectID)
+	if err != nil {
+		return model.SeriesOccurrence{}, err
+	}
+
+	if !isProjectMember(project, userId) {
+		return model.SeriesOccurrence{}, utils.ErrForbidden
+	}
+
+	// Get the subtasks that are recreated with every occurrence
+	subtasks, _, err := s.taskRepository.GetSubtasks(ctx, taskId, 0, "")
+	if err != nil {
+		return model.SeriesOccurrence{}, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	series := model.TaskSeries{
+		ID:          uuid.NewString(),
+		ProjectID:   task.ProjectID,
+		AuthorID:    userId,
+		Rule:        rule,
+		Status:      model.SeriesStatusActive,
+		Description: task.Description,
+		HandlerIDs:  task.HandlerIDs,
+		Priority:    task.Priority,
+		Severity:    task.Severity,
+		Labels:      task.Labels,
+		Subtasks:    []model.TemplateSubtask{},
+		AnchorAt:    task.Deadline,
+		AnchorIndex: 0,
+		Occurrences: 1,
+		LastTaskID:  task.ID,
+		NextRunAt:   task.Deadline,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	for _, subtask := range subtasks {
+		series.Subtasks = append(series.Subtasks, model.TemplateSubtask{Description: subtask.Description})
+	}
+
+	// The rule has to produce at least one more occurrence
+	if !allowsOccurrence(rule, 1, occurrenceDeadline(series, 1)) {
+		return model.SeriesOccurrence{}, fmt.Errorf("%w: the rule does not produce any occurrence after the task", utils.ErrInvalidRecurrence)
+	}
+
+	// Send the data to the repository layer to create the series
+	return s.recurrenceRepository.CreateSeries(ctx, series)
+}
+
+// GetProjectSeries retrieves the data from the controller layer and returns the task series of a project
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - projectId: The ID of the project
+//
+// Returns:
+//   - []model.TaskSeries: The list of project series
+//   - error: An error that occured during the process
+func (s *recurrenceService) GetProjectSeries(ctx context.Context, userId, projectId string) ([]model.TaskSeries, error) {
+	// Check if the user is part of the project
+	project, err := s.projectRepository.GetProjectById(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isProjectMember(project, userId) {
+		return nil, utils.ErrForbidden
+	}
+
+	return s.recurrenceRepository.GetProjectSeries(ctx, projectId)
+}
+
+// GetSeriesById retrieves the data from the controller layer and returns the data of a task series
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - seriesId: The ID of the series
+//
+// Returns:
+//   - model.TaskSeries: The data of the series
+//   - error: An error that occured during the process
+func (s *recurrenceService) GetSeriesById(ctx context.Context, userId, seriesId string) (model.TaskSeries, error) {
+	series, _, err := s.getSeriesProject(ctx, userId, seriesId)
+	return series, err
+}
+
+// UpdateSeries retrieves the data from the controller layer and edits the rule and the blueprint of a task series.
+// The changes apply to the occurrences created from now on, the existing occurrences are kept unchanged
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - seriesId: The ID of the series
+//   - changes: The edited fields of the series
+//
+// Returns:
+//   - model.TaskSeries: The updated series
+//   - error: An error that occured during the process
+func (s *recurrenceService) UpdateSeries(ctx context.Context, userId, seriesId string, changes model.SeriesChanges) (model.TaskSeries, error) {
+	_, project, err := s.getSeriesProject(ctx, userId, seriesId)
+	if err != nil {
+		return model.TaskSeries{}, err
+	}
+
+	// Check if the new handlers are part of the project
+	nonMembers := []string{}
+	for _, handlerId := range changes.HandlerIDs {
+		if !isProjectMember(project, handlerId) {
+			nonMembers = append(nonMembers, handlerId)
+		}
+	}
+
+	if len(nonMembers) > 0 {
+		return model.TaskSeries{}, fmt.Errorf("%w: %v", utils.ErrHandlersNotMembers, nonMembers)
+	}
+
+	// Send the changes to the repository layer to update the series
+	return s.recurrenceRepository.UpdateSeries(ctx, seriesId, func(series *model.TaskSeries) error {
+		if series.Status == model.SeriesStatusStopped {
+			return utils.ErrSeriesStopped
+		}
+
+		if changes.Description != "" {
+			series.Description = changes.Description
+		}
+
+		if changes.HandlerIDs != nil {
+			series.HandlerIDs = changes.HandlerIDs
+		}
+
+		if changes.Priority != "" {
+			series.Priority = changes.Priority
+		}
+
+		if changes.Severity != "" {
+			series.Severity = changes.Severity
+		}
+
+		if changes.Rule != nil {
+			// Anchor the new rule to the last occurrence so the past deadlines are not moved
+			series.AnchorAt = occurrenceDeadline(*series, series.Occurrences-1)
+			series.AnchorIndex = series.Occurrences - 1
+			series.Rule = *changes.Rule
+
+			// Resume or finish the series depending on the new rule
+			if allowsOccurrence(series.Rule, series.Occurrences, occurrenceDeadline(*series, series.Occurrences)) {
+				series.Status = model.SeriesStatusActive
+				series.NextRunAt = series.AnchorAt
+			} else {
+				series.Status = model.SeriesStatusFinished
+				series.NextRunAt = 0
+			}
+		}
+
+		series.UpdatedAt = time.Now().UnixMilli()
+		return nil
+	})
+}
+
+// StopSeries retrieves the data from the controller layer and stops a task series.
+// The existing occurrences are kept and no new occurrence is created
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - seriesId: The ID of the series
+//
+// Returns:
+//   - model.TaskSeries: The stopped series
+//   - error: An error that occured during the process
+func (s *recurrenceService) StopSeries(ctx context.Context, userId, seriesId string) (model.TaskSeries, error) {
+	if _, _, err := s.getSeriesProject(ctx, userId, seriesId); err != nil {
+		return model.TaskSeries{}, err
+	}
+
+	return s.recurrenceRepository.UpdateSeries(ctx, seriesId, func(series *model.TaskSeries) error {
+		if series.Status == model.SeriesStatusStopped {
+			return utils.ErrSeriesStopped
+		}
+
+		series.Status = model.SeriesStatusStopped
+		series.NextRunAt = 0
+		series.UpdatedAt = time.Now().UnixMilli()
+		return nil
+	})
+}
+
+// ContinueSeries creates the next occurrence of a series when its last occurrence was completed
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - task: The data of the completed task
+//
+// Returns:
+//   - *model.SeriesOccurrence: The created occurrence, nil when the task is not the last occurrence of an active series
+//   - error: An error that occured during the process
+func (s *recurrenceService) ContinueSeries(ctx context.Context, task model.Task) (*model.SeriesOccurrence, error) {
+	if task.SeriesID == "" || task.Status != model.TaskStatusCompleted {
+		return nil, nil
+	}
+
+	return s.recurrenceRepository.AdvanceSeries(ctx, task.SeriesID, func(series *model.TaskSeries) (*model.TaskDetails, error) {
+		if series.Status != model.SeriesStatusActive || series.LastTaskID != task.ID {
+			return nil, nil
+		}
+
+		return nextOccurrence(series, time.Now().UnixMilli()), nil
+	})
+}
+
+// CreateDueOccurrences creates the next occurrence of the active series whose last occurrence reached its deadline.
+// It is run periodically by the scheduler
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//
+// Returns:
+//   - []model.SeriesOccurrence: The created occurrences
+//   - error: The errors that occured while advancing the series
+func (s *recurrenceService) CreateDueOccurrences(ctx context.Context) ([]model.SeriesOccurrence, error) {
+	now := time.Now().UnixMilli()
+
+	dueSeries, err := s.recurrenceRepository.GetDueSeries(ctx, now, dueSeriesBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Advance each series on its own so a failing series does not stop the others
+	occurrences := []model.SeriesOccurrence{}
+	var errs []error
+	for _, due := range dueSeries {
+		occurrence, err := s.recurrenceRepository.AdvanceSeries(ctx, due.ID, func(series *model.TaskSeries) (*model.TaskDetails, error) {
+			if series.Status != model.SeriesStatusActive || series.NextRunAt > now {
+				return nil, nil
+			}
+
+			return nextOccurrence(series, now), nil
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("series %s: %w", due.ID, err))
+			continue
+		}
+
+		if occurrence != nil {
+			occurrences = append(occurrences, *occurrence)
+		}
+	}
+
+	return occurrences, errors.Join(errs...)
+}
+
+// getSeriesProject is a private method that returns a task series and its project
+// after checking that the user is part of the project
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - seriesId: The ID of the series
+//
+// Returns:
+//   - model.TaskSeries: The data of the series
+//   - model.Project: The data of the series project
+//   - error: An error that occured during the process
+func (s *recurrenceService) getSeriesProject(ctx context.Context, userId, seriesId string) (model.TaskSeries, model.Project, error) {
+	series, err := s.recurrenceRepository.GetSeriesById(ctx, seriesId)
+	if err != nil {
+		return model.TaskSeries{}, model.Project{}, err
+	}
+
+	project, err := s.projectRepository.GetProjectById(ctx, series.ProjectID)
+	if err != nil {
+		return model.TaskSeries{}, model.Project{}, err
+	}
+
+	if !isProjectMember(project, userId) {
+		return model.TaskSeries{}, model.Project{}, utils.ErrForbidden
+	}
+
+	return series, project, nil
+}
+
+// nextOccurrence is a private function that generates the next occurrence of a series and updates the series.
+// The occurrences whose deadline already passed are skipped. The series is marked as finished when the rule
+// does not allow any other occurrence
+//
+// Parameters:
+//   - series: The series data, updated in place
+//   - now: The current timestamp
+//
+// Returns:
+//   - *model.TaskDetails: The next occurrence and its subtasks, nil when the series is finished
+func nextOccurrence(series *model.TaskSeries, now int64) *model.TaskDetails {
+	series.UpdatedAt = now
+
+	// Skip the occurrences that are already overdue
+	index := series.Occurrences
+	deadline := occurrenceDeadline(*series, index)
+	for deadline <= now && allowsOccurrence(series.Rule, index, deadline) {
+		index++
+		deadline = occurrenceDeadline(*series, index)
+	}
+
+	if !allowsOccurrence(series.Rule, index, deadline) {
+		series.Status = model.SeriesStatusFinished
+		series.NextRunAt = 0
+		return nil
+	}
+
+	details := &model.TaskDetails{
+		Task: model.Task{
+			ID:           uuid.NewString(),
+			AuthorID:     series.AuthorID,
+			ProjectID:    series.ProjectID,
+			HandlerIDs:   slices.Clone(series.HandlerIDs),
+			Description:  series.Description,
+			Status:       model.TaskStatusNew,
+			Deadline:     deadline,
+			CreatedAt:    now,
+			SubtaskCount: int64(len(series.Subtasks)),
+			Priority:     series.Priority,
+			PriorityRank: model.PriorityRanks[series.Priority],
+			Severity:     series.Severity,
+			Labels:       slices.Clone(series.Labels),
+			SeriesID:     series.ID,
+			Occurrence:   index + 1,
+			Assignments:  handlerAssignments(series.HandlerIDs, series.AuthorID, now),
+		},
+		Subtasks:  []model.Subtask{},
+		Responses: []model.Response{},
+	}
+
+	for subtaskIndex, subtask := range series.Subtasks {
+		details.Subtasks = append(details.Subtasks, model.Subtask{
+			ID:          uuid.NewString(),
+			TaskID:      details.Task.ID,
+			AuthorID:    series.AuthorID,
+			Description: subtask.Description,
+			CreatedAt:   now + int64(subtaskIndex),
+			Done:        false,
+		})
+	}
+
+	// The next occurrence is created when this one reaches its deadline or is completed
+	series.Occurrences = index + 1
+	series.LastTaskID = details.Task.ID
+	series.NextRunAt = deadline
+
+	if !allowsOccurrence(series.Rule, series.Occurrences, occurrenceDeadline(*series, series.Occurrences)) {
+		series.Status = model.SeriesStatusFinished
+		series.NextRunAt = 0
+	}
+
+	return details
+}
+
+// occurrenceDeadline is a private function that computes the deadline of an occurrence of a series.
+// The monthly occurrences fall on the last day of the month when the month is shorter than the anchor day
+//
+// Parameters:
+//   - series: The series data
+//   - index: The zero based index of the occurrence
+//
+// Returns:
+//   - int64: The deadline of the occurrence
+func occurrenceDeadline(series model.TaskSeries, index int) int64 {
+	anchor := time.UnixMilli(series.AnchorAt).UTC()
+	steps := (index - series.AnchorIndex) * series.Rule.Interval
+
+	switch series.Rule.Frequency {
+	case model.FrequencyDaily:
+		return anchor.AddDate(0, 0, steps).UnixMilli()
+	case model.FrequencyWeekly:
+		return anchor.AddDate(0, 0, 7*steps).UnixMilli()
+	default:
+		firstDay := time.Date(anchor.Year(), anchor.Month()+time.Month(steps), 1, anchor.Hour(), anchor.Minute(), anchor.Second(), anchor.Nanosecond(), time.UTC)
+		lastDay := firstDay.AddDate(0, 1, -1).Day()
+		return firstDay.AddDate(0, 0, min(anchor.Day(), lastDay)-1).UnixMilli()
+	}
+}
+
+// allowsOccurrence is a private function that checks if a recurrence rule allows an occurrence
+//
+// Parameters:
+//   - rule: The recurrence rule
+//   - index: The zero based index of the occurrence
+//   - deadline: The deadline of the occurrence
+//
+// Returns:
+//   - bool: True if the occurrence is part of the series and false otherwise
+func allowsOccurrence(rule model.RecurrenceRule, index int, deadline int64) bool {
+	if rule.Count > 0 && index >= rule.Count {
+		return false
+	}
+
+	if rule.Until != nil && deadline > *rule.Until {
+		return false
+	}
+
+	return true
+}
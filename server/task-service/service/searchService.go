@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/search"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+type searchService struct {
+	index             *search.Index
+	projectRepository interfaces.ProjectRepository
+}
+
+func NewSearchService(index *search.Index, projectRepository interfaces.ProjectRepository) interfaces.SearchService {
+	return &searchService{index: index, projectRepository: projectRepository}
+}
+
+// Search retrieves the data from the controller layer and returns the tasks, subtasks and responses
+// that match the query from the projects the user is part of
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - query: The search query
+//   - projectId: The ID of the project to search in, empty to search in all the user projects
+//   - limit: The max number of results
+//
+// Returns:
+//   - []model.SearchHit: The list of ranked results
+//   - error: An error that occured during the process
+func (s *searchService) Search(ctx context.Context, userId string, query string, projectId string, limit int) ([]model.SearchHit, error) {
+	// Get the projects the user can see
+	projects, err := s.projectRepository.GetUserProjects(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	projectIds := make([]string, 0, len(projects))
+	for _, project := range projects {
+		projectIds = append(projectIds, project.ID)
+	}
+
+	// Check if the user is part of the requested project
+	if projectId != "" {
+		if !slices.Contains(projectIds, projectId) {
+			return nil, utils.ErrForbidden
+		}
+
+		projectIds = []string{projectId}
+	}
+
+	// Search the index
+	return s.index.Search(query, projectIds, limit)
+}
@@ -0,0 +1,502 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+// The max length of a sprint, it bounds the number of burndown days
+const maxSprintLength = 90 * 24 * time.Hour
+
+type sprintService struct {
+	sprintRepository  interfaces.SprintRepository
+	taskRepository    interfaces.TaskRepository
+	projectRepository interfaces.ProjectRepository
+}
+
+func NewSprintService(sprintRepository interfaces.SprintRepository, taskRepository interfaces.TaskRepository, projectRepository interfaces.ProjectRepository) interfaces.SprintService {
+	return &sprintService{sprintRepository: sprintRepository, taskRepository: taskRepository, projectRepository: projectRepository}
+}
+
+// CreateSprint retrieves the data from the controller layer and adds a new sprint to a project.
+// Only the project manager can plan the sprints of the project
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - projectId: The ID of the project
+//   - name: The name of the sprint
+//   - goal: The goal of the sprint
+//   - startAt: The start date of the sprint
+//   - endAt: The end date of the sprint
+//
+// Returns:
+//   - model.Sprint: The created sprint
+//   - error: An error that occured during the process
+func (s *sprintService) CreateSprint(ctx context.Context, userId, projectId, name, goal string, startAt, endAt int64) (model.Sprint, error) {
+	project, err := s.projectRepository.GetProjectById(ctx, projectId)
+	if err != nil {
+		return model.Sprint{}, err
+	}
+
+	if project.ProjectManagerID != userId {
+		return model.Sprint{}, utils.ErrForbidden
+	}
+
+	if err := validateSprintDates(startAt, endAt); err != nil {
+		return model.Sprint{}, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	sprint := model.Sprint{
+		ID:        uuid.NewString(),
+		ProjectID: projectId,
+		AuthorID:  userId,
+		Name:      name,
+		Goal:      goal,
+		StartAt:   startAt,
+		EndAt:     endAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// Send the data to the repository layer to create the sprint
+	return s.sprintRepository.CreateSprint(ctx, sprint)
+}
+
+// GetProjectSprints retrieves the data from the controller layer and returns the sprints of a project
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - projectId: The ID of the project
+//
+// Returns:
+//   - []model.Sprint: The list of project sprints
+//   - error: An error that occured during the process
+func (s *sprintService) GetProjectSprints(ctx context.Context, userId, projectId string) ([]model.Sprint, error) {
+	project, err := s.projectRepository.GetProjectById(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isProjectMember(project, userId) {
+		return nil, utils.ErrForbidden
+	}
+
+	return s.sprintRepository.GetProjectSprints(ctx, projectId)
+}
+
+// GetSprintById retrieves the data from the controller layer and returns the data of a sprint
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - sprintId: The ID of the sprint
+//
+// Returns:
+//   - model.Sprint: The data of the sprint
+//   - error: An error that occured during the process
+func (s *sprintService) GetSprintById(ctx context.Context, userId, sprintId string) (model.Sprint, error) {
+	sprint, _, err := s.getSprintProject(ctx, userId, sprintId)
+	return sprint, err
+}
+
+// UpdateSprint retrieves the data from the controller layer and edits the name, the goal and the dates of a sprint
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - sprintId: The ID of the sprint
+//   - changes: The fields to change, the empty fields are kept
+//
+// Returns:
+//   - model.Sprint: The updated sprint
+//   - error: An error that occured during the process
+func (s *sprintService) UpdateSprint(ctx context.Context, userId, sprintId string, changes model.SprintChanges) (model.Sprint, error) {
+	_, project, err := s.getSprintProject(ctx, userId, sprintId)
+	if err != nil {
+		return model.Sprint{}, err
+	}
+
+	if project.ProjectManagerID != userId {
+		return model.Sprint{}, utils.ErrForbidden
+	}
+
+	return s.sprintRepository.UpdateSprint(ctx, sprintId, func(sprint *model.Sprint) error {
+		if changes.Name != "" {
+			sprint.Name = changes.Name
+		}
+
+		if changes.Goal != nil {
+			sprint.Goal = *changes.Goal
+		}
+
+		if changes.StartAt != nil {
+			sprint.StartAt = *changes.StartAt
+		}
+
+		if changes.EndAt != nil {
+			sprint.EndAt = *changes.EndAt
+		}
+
+		sprint.UpdatedAt = time.Now().UnixMilli()
+		return validateSprintDates(sprint.StartAt, sprint.EndAt)
+	})
+}
+
+// DeleteSprint retrieves the data from the controller layer and removes a sprint, its tasks are moved back to the backlog
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - sprintId: The ID of the sprint
+//
+// Returns:
+//   - []model.Task: The list of tasks removed from the sprint
+//   - error: An error that occured during the process
+func (s *sprintService) DeleteSprint(ctx context.Context, userId, sprintId string) ([]model.Task, error) {
+	_, project, err := s.getSprintProject(ctx, userId, sprintId)
+	if err != nil {
+		return nil, err
+	}
+
+	if project.ProjectManagerID != userId {
+		return nil, utils.ErrForbidden
+	}
+
+	return s.sprintRepository.DeleteSprint(ctx, sprintId)
+}
+
+// AssignTaskSprint retrieves the data from the controller layer and plans a task into a sprint.
+// An empty sprint ID moves the task back to the backlog
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - taskId: The ID of the task
+//   - sprintId: The ID of the sprint
+//
+// Returns:
+//   - model.Task: The updated task
+//   - error: An error that occured during the process
+func (s *sprintService) AssignTaskSprint(ctx context.Context, userId, taskId, sprintId string) (model.Task, error) {
+	if err := s.checkTaskMember(ctx, userId, taskId); err != nil {
+		return model.Task{}, err
+	}
+
+	return s.sprintRepository.AssignTaskSprint(ctx, taskId, sprintId)
+}
+
+// UpdateTaskEstimate retrieves the data from the controller layer and sets the estimate of a task
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - taskId: The ID of the task
+//   - estimate: The story points and the estimated time of the task
+//
+// Returns:
+//   - model.Task: The updated task
+//   - error: An error that occured during the process
+func (s *sprintService) UpdateTaskEstimate(ctx context.Context, userId, taskId string, estimate model.TaskEstimate) (model.Task, error) {
+	if err := s.checkTaskMember(ctx, userId, taskId); err != nil {
+		return model.Task{}, err
+	}
+
+	return s.sprintRepository.UpdateTaskEstimate(ctx, taskId, estimate)
+}
+
+// GetSprintBurndown retrieves the data from the controller layer and computes the work left in a sprint at the end of every day.
+// The state of the tasks at each date is rebuilt from their status history
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - sprintId: The ID of the sprint
+//
+// Returns:
+//   - model.Burndown: The burndown series of the sprint, up to the current day
+//   - error: An error that occured during the process
+func (s *sprintService) GetSprintBurndown(ctx context.Context, userId, sprintId string) (model.Burndown, error) {
+	sprint, _, err := s.getSprintProject(ctx, userId, sprintId)
+	if err != nil {
+		return model.Burndown{}, err
+	}
+
+	tasks, history, err := s.getSprintHistory(ctx, sprintId)
+	if err != nil {
+		return model.Burndown{}, err
+	}
+
+	burndown := model.Burndown{Sprint: sprint, TotalTasks: len(tasks), Days: []model.BurndownPoint{}}
+	for _, task := range tasks {
+		points, estimatedTime := taskEstimate(task)
+		burndown.TotalPoints += points
+		burndown.TotalTime += estimatedTime
+	}
+
+	now := time.Now().UnixMilli()
+	day := (24 * time.Hour).Milliseconds()
+
+	// Walk over the days of the sprint, starting from the UTC day the sprint starts on
+	for dayStart := time.UnixMilli(sprint.StartAt).UTC().Truncate(24 * time.Hour).UnixMilli(); dayStart < sprint.EndAt && dayStart <= now; dayStart += day {
+		at := min(dayStart+day, sprint.EndAt, now)
+
+		point := model.BurndownPoint{
+			Date:        dayStart,
+			IdealPoints: burndown.TotalPoints * float64(sprint.EndAt-max(at, sprint.StartAt)) / float64(sprint.EndAt-sprint.StartAt),
+		}
+
+		for _, task := range tasks {
+			taskStatus := taskStatusAt(task, history[task.ID], at)
+			if taskStatus == "" || taskStatus == model.TaskStatusCompleted {
+				continue
+			}
+
+			points, estimatedTime := taskEstimate(task)
+			point.RemainingPoints += points
+			point.RemainingTime += estimatedTime
+			point.RemainingTasks++
+		}
+
+		burndown.Days = append(burndown.Days, point)
+	}
+
+	return burndown, nil
+}
+
+// GetProjectVelocity retrieves the data from the controller layer and computes the work completed in the last finished sprints of a project
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - projectId: The ID of the project
+//   - limit: The number of finished sprints to include
+//
+// Returns:
+//   - model.Velocity: The velocity of each sprint, the oldest first, and their average
+//   - error: An error that occured during the process
+func (s *sprintService) GetProjectVelocity(ctx context.Context, userId, projectId string, limit int) (model.Velocity, error) {
+	project, err := s.projectRepository.GetProjectById(ctx, projectId)
+	if err != nil {
+		return model.Velocity{}, err
+	}
+
+	if !isProjectMember(project, userId) {
+		return model.Velocity{}, utils.ErrForbidden
+	}
+
+	sprints, err := s.sprintRepository.GetFinishedSprints(ctx, projectId, time.Now().UnixMilli(), limit)
+	if err != nil {
+		return model.Velocity{}, err
+	}
+
+	velocity := model.Velocity{ProjectID: projectId, Sprints: []model.SprintVelocity{}}
+
+	// The sprints are returned the most recent first
+	for _, sprint := range slices.Backward(sprints) {
+		tasks, history, err := s.getSprintHistory(ctx, sprint.ID)
+		if err != nil {
+			return model.Velocity{}, err
+		}
+
+		sprintVelocity := model.SprintVelocity{Sprint: sprint, CommittedTasks: len(tasks)}
+		for _, task := range tasks {
+			points, _ := taskEstimate(task)
+			sprintVelocity.CommittedPoints += points
+
+			if taskStatusAt(task, history[task.ID], sprint.EndAt) == model.TaskStatusCompleted {
+				sprintVelocity.CompletedPoints += points
+				sprintVelocity.CompletedTasks++
+			}
+		}
+
+		velocity.AveragePoints += sprintVelocity.CompletedPoints
+		velocity.Sprints = append(velocity.Sprints, sprintVelocity)
+	}
+
+	if len(velocity.Sprints) > 0 {
+		velocity.AveragePoints /= float64(len(velocity.Sprints))
+	}
+
+	return velocity, nil
+}
+
+// getSprintProject is a private method that returns a sprint and its project after checking that the user is part of the project
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user
+//   - sprintId: The ID of the sprint
+//
+// Returns:
+//   - model.Sprint: The data of the sprint
+//   - model.Project: The data of the sprint project
+//   - error: An error that occured during the process
+func (s *sprintService) getSprintProject(ctx context.Context, userId, sprintId string) (model.Sprint, model.Project, error) {
+	sprint, err := s.sprintRepository.GetSprintById(ctx, sprintId)
+	if err != nil {
+		return model.Sprint{}, model.Project{}, err
+	}
+
+	project, err := s.projectRepository.GetProjectById(ctx, sprint.ProjectID)
+	if err != nil {
+		return model.Sprint{}, model.Project{}, err
+	}
+
+	if !isProjectMember(project, userId) {
+		return model.Sprint{}, model.Project{}, utils.ErrForbidden
+	}
+
+	return sprint, project, nil
+}
+
+// checkTaskMember is a private method that checks if the user is part of the project of a task
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user
+//   - taskId: The ID of the task
+//
+// Returns:
+//   - error: An error that occured during the process
+func (s *sprintService) checkTaskMember(ctx context.Context, userId, taskId string) error {
+	task, err := s.taskRepository.GetTaskById(ctx, taskId)
+	if err != nil {
+		return err
+	}
+
+	project, err := s.projectRepository.GetProjectById(ctx, task.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	if !isProjectMember(project, userId) {
+		return utils.ErrForbidden
+	}
+
+	return nil
+}
+
+// getSprintHistory is a private method that returns the tasks of a sprint and their status history
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - sprintId: The ID of the sprint
+//
+// Returns:
+//   - []model.Task: The list of sprint tasks
+//   - map[string][]model.StatusTransition: The status transitions of each task sorted by their date
+//   - error: An error that occured during the process
+func (s *sprintService) getSprintHistory(ctx context.Context, sprintId string) ([]model.Task, map[string][]model.StatusTransition, error) {
+	tasks, err := s.sprintRepository.GetSprintTasks(ctx, sprintId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	taskIds := make([]string, len(tasks))
+	for i, task := range tasks {
+		taskIds[i] = task.ID
+	}
+
+	transitions, err := s.sprintRepository.GetStatusTransitions(ctx, taskIds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	history := map[string][]model.StatusTransition{}
+	for _, transition := range transitions {
+		history[transition.TaskID] = append(history[transition.TaskID], transition)
+	}
+
+	for _, taskTransitions := range history {
+		slices.SortFunc(taskTransitions, func(a, b model.StatusTransition) int {
+			return cmp.Compare(a.ChangedAt, b.ChangedAt)
+		})
+	}
+
+	return tasks, history, nil
+}
+
+// taskStatusAt is a private function that rebuilds the status a task had at a given date
+//
+// Parameters:
+//   - task: The current task data
+//   - transitions: The status transitions of the task sorted by their date
+//   - at: The timestamp to rebuild the status at
+//
+// Returns:
+//   - string: The status of the task, empty if the task did not exist yet
+func taskStatusAt(task model.Task, transitions []model.StatusTransition, at int64) string {
+	if task.CreatedAt > at {
+		return ""
+	}
+
+	// A task without history kept the same status since it was created
+	if len(transitions) == 0 {
+		return task.Status
+	}
+
+	taskStatus := transitions[0].From
+	for _, transition := range transitions {
+		if transition.ChangedAt > at {
+			break
+		}
+
+		taskStatus = transition.To
+	}
+
+	return taskStatus
+}
+
+// taskEstimate is a private function that returns the estimate of a task, the missing fields count as zero
+//
+// Parameters:
+//   - task: The task data
+//
+// Returns:
+//   - float64: The story points of the task
+//   - int64: The estimated time of the task
+func taskEstimate(task model.Task) (float64, int64) {
+	var points float64
+	if task.StoryPoints != nil {
+		points = *task.StoryPoints
+	}
+
+	var estimatedTime int64
+	if task.EstimatedTime != nil {
+		estimatedTime = *task.EstimatedTime
+	}
+
+	return points, estimatedTime
+}
+
+// validateSprintDates is a private function that checks the dates of a sprint
+//
+// Parameters:
+//   - startAt: The start date of the sprint
+//   - endAt: The end date of the sprint
+//
+// Returns:
+//   - error: An error that occured during the process
+func validateSprintDates(startAt, endAt int64) error {
+	if endAt <= startAt {
+		return fmt.Errorf("%w: the sprint has to end after it starts", utils.ErrInvalidSprint)
+	}
+
+	if endAt-startAt > maxSprintLength.Milliseconds() {
+		return fmt.Errorf("%w: a sprint cannot be longer than %s", utils.ErrInvalidSprint, maxSprintLength)
+	}
+
+	return nil
+}
@@ -1,21 +1,42 @@
 package service
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"math"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/horatiucrisan/task-service/interfaces"
 	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/utils"
 )
 
+// The number of documents deleted at a time from the subcollections of a deleted task
+const subcollectionBatchSize = 100
+
+// The max number of descendant levels returned with a task hierarchy
+const maxTaskTreeDepth = 5
+
+// The max number of documents a Firestore transaction can write
+const maxTransactionWrites = 500
+
+// The time window of the work logs that count as the recent activity of a handler candidate
+const recentActivityWindow = 14 * 24 * time.Hour
+
 type taskService struct {
-	taskRepository interfaces.TaskRepository
+	taskRepository         interfaces.TaskRepository
+	projectRepository      interfaces.ProjectRepository
+	worklogRepository      interfaces.WorkLogRepository
+	availabilityRepository interfaces.AvailabilityRepository
+	jobService             interfaces.JobService
 }
 
-func NewTaskService(taskRepository interfaces.TaskRepository) interfaces.TaskService {
-	return &taskService{taskRepository: taskRepository}
+func NewTaskService(taskRepository interfaces.TaskRepository, projectRepository interfaces.ProjectRepository, worklogRepository interfaces.WorkLogRepository, availabilityRepository interfaces.AvailabilityRepository, jobService interfaces.JobService) interfaces.TaskService {
+	return &taskService{taskRepository: taskRepository, projectRepository: projectRepository, worklogRepository: worklogRepository, availabilityRepository: availabilityRepository, jobService: jobService}
 }
 
 // CreateTask retrieves the data from the controller layer and creates a new Task object and sends it to the repository layer
@@ -28,17 +49,41 @@ func NewTaskService(taskRepository interfaces.TaskRepository) interfaces.TaskSer
 //   - handlerIds: The list of users that are assigned that task
 //   - description: The description of the task
 //   - deadline: The due timestamp of the task
+//   - priority: The priority of the task, medium if empty
+//   - severity: The optional severity of the task
+//   - autoAssign: The auto assignment mode that picks the handler, empty when the handlers are given
 //
 // Returns:
-//   - model.Task: The created task object
+//   - model.Task: The created task object, with the absences of its handlers that cover the deadline
 //   - error: An error that happend during the creation of the task
-func (s *taskService) CreateTask(ctx context.Context, authorId string, projectId string, handlerIds []string, description string, deadline int64) (model.Task, error) {
+func (s *taskService) CreateTask(ctx context.Context, authorId string, projectId string, handlerIds []string, description string, deadline int64, priority string, severity string, autoAssign string) (model.Task, error) {
+	// Pick the handler of the task among the project members
+	if autoAssign != "" {
+		if len(handlerIds) > 0 {
+			return model.Task{}, fmt.Errorf("%w: the handlers cannot be given together with an auto assignment", utils.ErrInvalidAssignment)
+		}
+
+		handlerId, err := s.autoAssignHandler(ctx, authorId, projectId, deadline, autoAssign)
+		if err != nil {
+			return model.Task{}, err
+		}
+
+		handlerIds = []string{handlerId}
+	} else if len(handlerIds) == 0 {
+		return model.Task{}, fmt.Errorf("%w: the task needs at least one handler", utils.ErrInvalidAssignment)
+	}
+
 	// Generate an ID for the task using uuid
 	taskId := uuid.NewString()
 
 	// Get the current time of the task creation
 	now := time.Now().UnixMilli()
 
+	// Set the default priority of the task
+	if priority == "" {
+		priority = model.PriorityMedium
+	}
+
 	taskData := model.Task{
 		ID:                    taskId,
 		AuthorID:              authorId,
@@ -48,10 +93,14 @@ func (s *taskService) CreateTask(ctx context.Context, authorId string, projectId
 		Deadline:              deadline,
 		CreatedAt:             now,
 		CompletedAt:           nil,
-		Status:                "new",
+		Status:                model.TaskStatusNew,
 		CompletedSubtaskCount: 0,
 		SubtaskCount:          0,
 		ResponseCount:         0,
+		Priority:              priority,
+		PriorityRank:          model.PriorityRanks[priority],
+		Severity:              severity,
+		Assignments:           handlerAssignments(handlerIds, authorId, now),
 	}
 
 	// Send the data to the service layer to create the task
@@ -62,10 +111,45 @@ func (s *taskService) CreateTask(ctx context.Context, authorId string, projectId
 		return model.Task{}, err
 	}
 
+	// Warn about the handlers that are absent on the deadline
+	if err = s.setAbsenceWarnings(ctx, &task); err != nil {
+		return model.Task{}, err
+	}
+
 	// Return the created task
 	return task, nil
 }
 
+// GetHandlerSuggestions retrieves the data from the controller layer and ranks the members of a project
+// from the most to the least available handler of a new task. The members that are absent before the deadline are left out
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - projectId: The ID of the project
+//   - deadline: The optional deadline of the new task, only the current absences count when empty
+//
+// Returns:
+//   - model.HandlerSuggestions: The ranked project members
+//   - error: An error that occured during the process
+func (s *taskService) GetHandlerSuggestions(ctx context.Context, userId, projectId string, deadline int64) (model.HandlerSuggestions, error) {
+	project, err := s.projectRepository.GetProjectById(ctx, projectId)
+	if err != nil {
+		return model.HandlerSuggestions{}, err
+	}
+
+	if !isProjectMember(project, userId) {
+		return model.HandlerSuggestions{}, utils.ErrForbidden
+	}
+
+	candidates, unavailableIds, err := s.getHandlerCandidates(ctx, project, deadline)
+	if err != nil {
+		return model.HandlerSuggestions{}, err
+	}
+
+	return model.HandlerSuggestions{ProjectID: projectId, GeneratedAt: time.Now().UnixMilli(), Candidates: candidates, UnavailableIDs: unavailableIds}, nil
+}
+
 // CreateSubtask retrieves the data from the controller layer and creates a new Subtask object and sends it to the repository layer
 // to create a new Subtask for a Task
 //
@@ -147,27 +231,143 @@ func (s *taskService) CreateTaskResponse(ctx context.Context, authorId string, t
 	return response, nil
 }
 
+// DuplicateTask retrieves the data from the controller layer and sends a copy of a task and its subtasks,
+// and optionally of its responses, to the repository layer. The copy starts as a new task with open subtasks
+// and without dependencies
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - taskId: The ID of the task to copy
+//   - includeResponses: True to copy the responses of the task as well
+//   - deadline: The deadline of the copy, 0 to keep the deadline of the original task
+//
+// Returns:
+//   - model.TaskDetails: The created task copy, its subtasks and responses
+//   - error: An error that occured during the process
+func (s *taskService) DuplicateTask(ctx context.Context, userId, taskId string, includeResponses bool, deadline int64) (model.TaskDetails, error) {
+	// Get the original task data
+	task, err := s.taskRepository.GetTaskById(ctx, taskId)
+	if err != nil {
+		return model.TaskDetails{}, err
+	}
+
+	// Check if the user is part of the project of the task
+	project, err := s.projectRepository.GetProjectById(ctx, task.ProjectID)
+	if err != nil {
+		return model.TaskDetails{}, err
+	}
+
+	if !isProjectMember(project, userId) {
+		return model.TaskDetails{}, utils.ErrForbidden
+	}
+
+	// Get all the subtasks and, if requested, all the responses of the task
+	subtasks, _, err := s.taskRepository.GetSubtasks(ctx, taskId, 0, "")
+	if err != nil {
+		return model.TaskDetails{}, err
+	}
+
+	responses := []model.Response{}
+	if includeResponses {
+		if responses, _, err = s.taskRepository.GetResponses(ctx, taskId, 0, ""); err != nil {
+			return model.TaskDetails{}, err
+		}
+	}
+
+	if 1+len(subtasks)+len(responses) > maxTransactionWrites {
+		return model.TaskDetails{}, fmt.Errorf("the task has too many subtasks and responses to be duplicated at once")
+	}
+
+	if deadline == 0 {
+		deadline = task.Deadline
+	}
+
+	now := time.Now().UnixMilli()
+
+	details := model.TaskDetails{
+		Task: model.Task{
+			ID:           uuid.NewString(),
+			AuthorID:     userId,
+			ProjectID:    task.ProjectID,
+			HandlerIDs:   task.HandlerIDs,
+			Description:  task.Description,
+			Status:       model.TaskStatusNew,
+			Deadline:     deadline,
+			CreatedAt:    now,
+			SubtaskCount: int64(len(subtasks)),
+			Priority:     task.Priority,
+			PriorityRank: task.PriorityRank,
+			Severity:     task.Severity,
+			Labels:       task.Labels,
+			ParentTaskID: task.ParentTaskID,
+			Assignments:  handlerAssignments(task.HandlerIDs, userId, now),
+		},
+		Subtasks:  []model.Subtask{},
+		Responses: []model.Response{},
+	}
+
+	// Copy the subtasks as open subtasks, keeping their order
+	for index, subtask := range subtasks {
+		details.Subtasks = append(details.Subtasks, model.Subtask{
+			ID:          uuid.NewString(),
+			TaskID:      details.Task.ID,
+			AuthorID:    subtask.AuthorID,
+			HandlerID:   subtask.HandlerID,
+			Description: subtask.Description,
+			CreatedAt:   now + int64(index),
+			Done:        false,
+		})
+	}
+
+	// Copy the responses with their original timestamps
+	for _, response := range responses {
+		details.Responses = append(details.Responses, model.Response{
+			ID:        uuid.NewString(),
+			AuthorID:  response.AuthorID,
+			TaskID:    details.Task.ID,
+			Message:   response.Message,
+			Timestamp: response.Timestamp,
+		})
+	}
+	details.Task.ResponseCount = int64(len(details.Responses))
+
+	// Send the data to the repository layer to create the copy
+	return s.taskRepository.CreateTaskDetails(ctx, details)
+}
+
 // GetTasks retrieves the data from the controller layer and retrieves the list of tasks from the repository layer
 //
 // Parameter:
 //   - ctx: Request-scoped context
-//   - projectId: The ID of the project that the task is part of
-//   - limit: The number of tasks to retrieve at a time
-//   - orderBy: The ordering criteria
-//   - orderDirection: The direction of the order
-//   - *startAfter: The ID of the last task retrieved at the previous fetching request
+//   - taskQuery: The filtering, ordering and pagination criteria
 //
 // Returns:
 //   - []model.Task: The list of retrieved tasks
+//   - string: The cursor of the next page, empty if there are no more tasks
 //   - error: An error that occured during the process
-func (s *taskService) GetTasks(ctx context.Context, projectId string, limit int, orderBy string, orderDirection string, startAfter string) ([]model.Task, error) {
+func (s *taskService) GetTasks(ctx context.Context, taskQuery model.TaskQuery) ([]model.Task, string, error) {
+	// Check if the ranges are valid
+	if taskQuery.DeadlineFrom != nil && taskQuery.DeadlineTo != nil && *taskQuery.DeadlineFrom > *taskQuery.DeadlineTo {
+		return nil, "", fmt.Errorf("deadlineFrom must be before deadlineTo")
+	}
+
+	if taskQuery.CreatedFrom != nil && taskQuery.CreatedTo != nil && *taskQuery.CreatedFrom > *taskQuery.CreatedTo {
+		return nil, "", fmt.Errorf("createdFrom must be before createdTo")
+	}
+
+	// The ranks are ordered inside a board column, so the tasks of a single status are ordered by rank
+	if taskQuery.OrderBy == "rank" && len(taskQuery.Statuses) != 1 {
+		return nil, "", fmt.Errorf("%w: ordering by rank requires a single status", utils.ErrInvalidRank)
+	}
+
 	// Send the data to the repository layer to retrieve the tasks list
-	tasks, err := s.taskRepository.GetTasks(ctx, projectId, limit, orderBy, orderDirection, startAfter)
+	tasks, nextCursor, err := s.taskRepository.GetTasks(ctx, taskQuery)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	return tasks, nil
+	return tasks, nextCursor, nil
 }
 
 // GetSubtasks retrieves the data from the controller layer and returns a list of subtasks from the repository layer
@@ -175,19 +375,22 @@ func (s *taskService) GetTasks(ctx context.Context, projectId string, limit int,
 // Parameters:
 //   - ctx: Request-scoped context
 //   - taskId: The ID of the task
+//   - limit: The max number of subtasks to retrieve, 0 to retrieve all of them
+//   - cursor: The cursor returned with the previous page
 //
 // Returns:
 //   - []model.Subtask: The list of subtasks of a task
+//   - string: The cursor of the next page, empty if there are no more subtasks
 //   - error: An error that occured during the process
-func (s *taskService) GetSubtasks(ctx context.Context, taskId string) ([]model.Subtask, error) {
+func (s *taskService) GetSubtasks(ctx context.Context, taskId string, limit int, cursor string) ([]model.Subtask, string, error) {
 	// Send the data to the repository layer to retrieve the subtasks list
-	subtasks, err := s.taskRepository.GetSubtasks(ctx, taskId)
+	subtasks, nextCursor, err := s.taskRepository.GetSubtasks(ctx, taskId, limit, cursor)
 	if err != nil {
 		fmt.Printf("%+v", err)
-		return nil, err
+		return nil, "", err
 	}
 
-	return subtasks, nil
+	return subtasks, nextCursor, nil
 }
 
 // GetSubtaskById retrieves the data from the controller layer and sends it to the repository layer
@@ -211,18 +414,21 @@ func (s *taskService) GetSubtaskById(ctx context.Context, taskId, subtaskId stri
 // Parameters:
 //   - ctx: Request-scoped context
 //   - taskId: The ID of the task the responses are part of
+//   - limit: The max number of responses to retrieve, 0 to retrieve all of them
+//   - cursor: The cursor returned with the previous page
 //
 // Returns:
 //   - []model.Response: The list of task responses
+//   - string: The cursor of the next page, empty if there are no more responses
 //   - error: An error that occured during the process
-func (s *taskService) GetResponses(ctx context.Context, taskId string) ([]model.Response, error) {
+func (s *taskService) GetResponses(ctx context.Context, taskId string, limit int, cursor string) ([]model.Response, string, error) {
 	// Send the data to the repository layer to retrieve the list of responses
-	responses, err := s.taskRepository.GetResponses(ctx, taskId)
+	responses, nextCursor, err := s.taskRepository.GetResponses(ctx, taskId, limit, cursor)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	return responses, nil
+	return responses, nextCursor, nil
 }
 
 // GetResponseById retrieves the data from the controller layer and sends it to the repository layer
@@ -260,188 +466,1074 @@ func (s *taskService) GetTaskById(ctx context.Context, taskId string) (model.Tas
 	return task, nil
 }
 
-// UpdateTaskDescription retrieves the data from the controller layer and sends it to the repository layer to update the task description
+// GetUserWork retrieves the data from the controller layer and returns every task and subtask
+// where the user is a handler, grouped by project and sorted by deadline
 //
 // Parameters:
 //   - ctx: Request-scoped context
-//   - taskId: The ID of the task
-//   - description: The new task description
+//   - taskQuery: The handler and the status and due window filters
 //
 // Returns:
-//   - model.Task: The updated task data
+//   - []model.ProjectWork: The work of the user grouped by project
 //   - error: An error that occured during the process
-func (s *taskService) UpdateTaskDescription(ctx context.Context, taskId string, description string) (model.Task, error) {
-	task, err := s.taskRepository.UpdateTaskDescription(ctx, taskId, description)
+func (s *taskService) GetUserWork(ctx context.Context, taskQuery model.TaskQuery) ([]model.ProjectWork, error) {
+	// Check if the due window is valid
+	if taskQuery.DeadlineFrom != nil && taskQuery.DeadlineTo != nil && *taskQuery.DeadlineFrom > *taskQuery.DeadlineTo {
+		return nil, fmt.Errorf("deadlineFrom must be before deadlineTo")
+	}
+
+	// Send the data to the repository layer to retrieve the tasks and subtasks of the user
+	tasks, err := s.taskRepository.GetHandlerTasks(ctx, taskQuery)
 	if err != nil {
-		return model.Task{}, err
+		return nil, err
 	}
 
-	return task, nil
+	subtasks, err := s.taskRepository.GetHandlerSubtasks(ctx, taskQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group the work by project
+	work := map[string]*model.ProjectWork{}
+	projectIds := []string{}
+	getProjectWork := func(projectId string) *model.ProjectWork {
+		if _, ok := work[projectId]; !ok {
+			work[projectId] = &model.ProjectWork{
+				ProjectID: projectId,
+				Tasks:     []model.Task{},
+				Subtasks:  []model.AssignedSubtask{},
+			}
+			projectIds = append(projectIds, projectId)
+		}
+		return work[projectId]
+	}
+
+	for _, task := range tasks {
+		projectWork := getProjectWork(task.ProjectID)
+		projectWork.Tasks = append(projectWork.Tasks, task)
+	}
+
+	for _, subtask := range subtasks {
+		projectWork := getProjectWork(subtask.Task.ProjectID)
+		projectWork.Subtasks = append(projectWork.Subtasks, subtask)
+	}
+
+	// Get the titles of the projects
+	projects, err := s.projectRepository.GetProjectsByIds(ctx, projectIds)
+	if err != nil {
+		return nil, err
+	}
+
+	// Skip the work from the projects that no longer exist
+	projectWorks := []model.ProjectWork{}
+	for _, project := range projects {
+		projectWork := work[project.ID]
+		projectWork.ProjectTitle = project.Title
+
+		// Sort the tasks and subtasks by deadline
+		slices.SortStableFunc(projectWork.Tasks, func(a, b model.Task) int {
+			return cmp.Compare(a.Deadline, b.Deadline)
+		})
+		slices.SortStableFunc(projectWork.Subtasks, func(a, b model.AssignedSubtask) int {
+			return cmp.Compare(a.Task.Deadline, b.Task.Deadline)
+		})
+
+		projectWorks = append(projectWorks, *projectWork)
+	}
+
+	// Sort the projects by their closest deadline
+	slices.SortStableFunc(projectWorks, func(a, b model.ProjectWork) int {
+		return cmp.Compare(earliestDeadline(a), earliestDeadline(b))
+	})
+
+	return projectWorks, nil
 }
 
-// UpdateTaskStatus retrieves the data from the controller layer and sends it to the repository layer to update the status of the task
+// GetDependencyGraph retrieves the project ID from the controller layer and returns the dependency graph of the project.
+// Only the tasks that block or are blocked by other tasks are part of the graph
 //
 // Parameters:
 //   - ctx: Request-scoped context
-//   - taskId: The ID of the task
-//   - status: The new task status
+//   - projectId: The ID of the project
 //
 // Returns:
-//   - model.Task: The updated task data
+//   - model.DependencyGraph: The tasks and the dependencies between them
 //   - error: An error that occured during the process
-func (s *taskService) UpdateTaskStatus(ctx context.Context, taskId string, status string) (model.Task, error) {
-	// Sends the data to the repository layer to update the status of the task
-	task, err := s.taskRepository.UpdateTaskStatus(ctx, taskId, status)
+func (s *taskService) GetDependencyGraph(ctx context.Context, projectId string) (model.DependencyGraph, error) {
+	// Get the tasks of the project
+	tasks, err := s.taskRepository.GetProjectTasks(ctx, projectId)
 	if err != nil {
-		return model.Task{}, err
+		return model.DependencyGraph{}, err
 	}
 
-	return task, nil
+	// Map the status of each task to find the open blockers
+	statuses := map[string]string{}
+	for _, task := range tasks {
+		statuses[task.ID] = task.Status
+	}
+
+	graph := model.DependencyGraph{
+		ProjectID: projectId,
+		Nodes:     []model.DependencyNode{},
+		Edges:     []model.DependencyEdge{},
+	}
+
+	for _, task := range tasks {
+		if len(task.BlockedBy) == 0 && len(task.Blocks) == 0 {
+			continue
+		}
+
+		node := model.DependencyNode{
+			ID:          task.ID,
+			Description: task.Description,
+			Status:      task.Status,
+			HandlerIDs:  task.HandlerIDs,
+		}
+
+		// Add an edge for each blocker that still exists
+		for _, blockerId := range task.BlockedBy {
+			blockerStatus, ok := statuses[blockerId]
+			if !ok {
+				continue
+			}
+
+			if blockerStatus != model.TaskStatusCompleted {
+				node.Blocked = true
+			}
+
+			graph.Edges = append(graph.Edges, model.DependencyEdge{BlockerID: blockerId, TaskID: task.ID})
+		}
+
+		graph.Nodes = append(graph.Nodes, node)
+	}
+
+	return graph, nil
 }
 
-// AddTaskHandler retrieves the data from the controller layer and sends it to the repository layer to add handlers to a task
+// GetUnblockedTasks returns the tasks that were blocked by the completed tasks
+// and have no other open blockers left
 //
 // Parameters:
 //   - ctx: Request-scoped context
-//   - taskId: The ID of the task
-//   - handlerIds: The list of handler IDs to add to the task
+//   - completedTasks: The list of tasks that were completed
 //
 // Returns:
-//   - model.Task: The updated task data
+//   - []model.Task: The list of unblocked tasks
 //   - error: An error that occured during the process
-func (s *taskService) AddTaskHandlers(ctx context.Context, taskId string, handlerIds []string) (model.Task, error) {
-	// Send the data to the repository layer to add handlers
-	task, err := s.taskRepository.AddTaskHandlers(ctx, taskId, handlerIds)
+func (s *taskService) GetUnblockedTasks(ctx context.Context, completedTasks []model.Task) ([]model.Task, error) {
+	// Get the IDs of the tasks that were blocked by the completed tasks
+	var blockedIds []string
+	for _, task := range completedTasks {
+		if task.Status != model.TaskStatusCompleted {
+			continue
+		}
+
+		for _, blockedId := range task.Blocks {
+			if !slices.Contains(blockedIds, blockedId) {
+				blockedIds = append(blockedIds, blockedId)
+			}
+		}
+	}
+
+	if len(blockedIds) == 0 {
+		return []model.Task{}, nil
+	}
+
+	// Get the data of the blocked tasks
+	blockedTasks, err := s.taskRepository.GetTasksByIds(ctx, blockedIds)
 	if err != nil {
-		return model.Task{}, err
+		return nil, err
 	}
 
-	return task, nil
+	// Get the blockers of each task that are still open
+	openBlockers, err := s.getOpenBlockers(ctx, blockedTasks)
+	if err != nil {
+		return nil, err
+	}
+
+	unblockedTasks := []model.Task{}
+	for _, task := range blockedTasks {
+		if task.Status != model.TaskStatusCompleted && len(openBlockers[task.ID]) == 0 {
+			unblockedTasks = append(unblockedTasks, task)
+		}
+	}
+
+	return unblockedTasks, nil
 }
 
-// RemoveTaskHandlers retrieves data from the controller layer and sends it to the repository layer to remove handlers from the list
+// GetTaskTree retrieves the data from the controller layer and returns the task with its descendants.
+// The children are retrieved one level at a time, up to the given depth,
+// and the progress of each returned task is computed from the statuses of its children
 //
 // Parameters:
 //   - ctx: Request-scoped context
-//   - taskId: The ID of the task
-//   - handlerIds: The list of handler IDs to be removed from the task
+//   - taskId: The ID of the root task
+//   - depth: The number of descendant levels to retrieve
 //
 // Returns:
-//   - model.Task: The updated task data
+//   - *model.TaskTree: The task hierarchy
 //   - error: An error that occured during the process
-func (s *taskService) RemoveTaskHandlers(ctx context.Context, taskId string, handlerIds []string) (model.Task, error) {
-	// Send the data to the repository layer to remove the task handlers
-	task, err := s.taskRepository.RemoveTaskHandlers(ctx, taskId, handlerIds)
+func (s *taskService) GetTaskTree(ctx context.Context, taskId string, depth int) (*model.TaskTree, error) {
+	// Check if the depth is within the limits
+	if depth <= 0 {
+		depth = 1
+	}
+	depth = min(depth, maxTaskTreeDepth)
+
+	// Get the root task
+	task, err := s.taskRepository.GetTaskById(ctx, taskId)
 	if err != nil {
-		return model.Task{}, err
+		return nil, err
 	}
 
-	return task, nil
+	root := &model.TaskTree{Task: task, Children: []*model.TaskTree{}}
+
+	// Visit the hierarchy one level at a time, the last level is retrieved
+	// only to compute the progress of the deepest returned tasks
+	level := []*model.TaskTree{root}
+	for currentDepth := 0; currentDepth <= depth && len(level) > 0; currentDepth++ {
+		parentIds := make([]string, len(level))
+		nodes := map[string]*model.TaskTree{}
+		for i, node := range level {
+			parentIds[i] = node.Task.ID
+			nodes[node.Task.ID] = node
+		}
+
+		children, err := s.taskRepository.GetTaskChildren(ctx, parentIds)
+		if err != nil {
+			return nil, err
+		}
+
+		// Group the children by their parent
+		childTasks := map[string][]model.Task{}
+		for _, child := range children {
+			childTasks[child.ParentTaskID] = append(childTasks[child.ParentTaskID], child)
+		}
+
+		var nextLevel []*model.TaskTree
+		for _, node := range level {
+			node.Progress = computeTaskProgress(childTasks[node.Task.ID])
+
+			if currentDepth == depth {
+				continue
+			}
+
+			for _, child := range childTasks[node.Task.ID] {
+				childNode := &model.TaskTree{Task: child, Children: []*model.TaskTree{}}
+				node.Children = append(node.Children, childNode)
+				nextLevel = append(nextLevel, childNode)
+			}
+		}
+
+		level = nextLevel
+	}
+
+	return root, nil
 }
 
-// UpdateSubtaskDescription retrieves the data from the controller layer and sends it to the repository layer
-//
-//	to update the description of the subtask
+// UpdateTaskDescription retrieves the data from the controller layer and sends it to the repository layer to update the task description
 //
 // Parameters:
 //   - ctx: Request-scoped context
-//   - taskId: The ID of the task the subtask is part of
-//   - subtaskId: The ID of the subtask
-//   - description: The new subtask description
+//   - taskId: The ID of the task
+//   - description: The new task description
 //
 // Returns:
-//   - model.Subtask: The updated subtask data
+//   - model.Task: The updated task data
 //   - error: An error that occured during the process
-func (s *taskService) UpdateSubtaskDescription(ctx context.Context, taskId string, subtaskId string, description string) (model.Subtask, error) {
-	// Send the data to the repository layer to update the description of the subtask
-	subtask, err := s.taskRepository.UpdateSubtaskDescription(ctx, taskId, subtaskId, description)
+func (s *taskService) UpdateTaskDescription(ctx context.Context, taskId string, description string) (model.Task, error) {
+	task, err := s.taskRepository.UpdateTaskDescription(ctx, taskId, description)
 	if err != nil {
-		return model.Subtask{}, err
+		return model.Task{}, err
 	}
 
-	return subtask, nil
+	return task, nil
 }
 
-// UpdateSubtaskHandler retrieves the data from the controller layer and sends it to the repository layer
-// to set a new handler for a subtask
+// UpdateTaskStatus retrieves the data from the controller layer and sends it to the repository layer to update the status of the task
 //
 // Parameters:
 //   - ctx: Request-scoped context
-//   - taskId: The ID of the task the subtask is part of
-//   - subtaskId: The ID of the subtask
-//   - handlerId: The ID of the new subtask handler
+//   - userId: The ID of the user that sent the request
+//   - taskId: The ID of the task
+//   - status: The new task status
+//   - override: Whether the exceeded work in progress limits are ignored
 //
 // Returns:
-//   - model.Subtask: The updated subtask data
+//   - model.Task: The updated task data
+//   - []string: The work in progress limits that were overridden
 //   - error: An error that occured during the process
-func (s *taskService) UpdateSubtaskHandler(ctx context.Context, taskId string, subtaskId string, handlerId string) (model.Subtask, error) {
-	// Send the data to the repository layer to update the subtask handler
-	subtask, err := s.taskRepository.UpdateSubtaskHandler(ctx, taskId, subtaskId, handlerId)
+func (s *taskService) UpdateTaskStatus(ctx context.Context, userId, taskId string, status string, override bool) (model.Task, []string, error) {
+	currentTask, err := s.taskRepository.GetTaskById(ctx, taskId)
 	if err != nil {
-		return model.Subtask{}, err
+		return model.Task{}, nil, err
 	}
 
-	return subtask, nil
+	if err = s.checkWipOverride(ctx, userId, currentTask, override); err != nil {
+		return model.Task{}, nil, err
+	}
+
+	// A task can be completed only after all of its blockers are completed
+	if status == model.TaskStatusCompleted {
+		openBlockers, err := s.getOpenBlockers(ctx, []model.Task{currentTask})
+		if err != nil {
+			return model.Task{}, nil, err
+		}
+
+		if len(openBlockers[taskId]) > 0 {
+			return model.Task{}, nil, fmt.Errorf("%w: %v", utils.ErrBlockedTask, openBlockers[taskId])
+		}
+	}
+
+	// Sends the data to the repository layer to update the status of the task
+	return s.taskRepository.UpdateTaskStatus(ctx, taskId, status, override)
 }
 
-// UpdateSubtaskStatus retrieves the data from the controller layer and sends it to the repository layer to update the subtask
+// UpdateTaskRank retrieves the data from the controller layer and moves a task to a position of a board column.
+// The task is placed between its two new neighbors and its status changes to the status of the column
 //
 // Parameters:
 //   - ctx: Request-scoped context
-//   - taskId: The ID of the task the subtask is part of
-//   - subtaskId: The ID of the subtask
-//   - status: The new subtask status
+//   - userId: The ID of the user that sent the request
+//   - taskId: The ID of the task
+//   - status: The status of the target column
+//   - afterId: The ID of the task above the new position, empty for the top of the column
+//   - beforeId: The ID of the task below the new position, empty for the bottom of the column
+//   - override: Whether the exceeded work in progress limits are ignored
 //
 // Returns:
-//   - model.Subtask: The updated subtask data
+//   - model.Task: The updated task data
+//   - []string: The work in progress limits that were overridden
 //   - error: An error that occured during the process
-func (s *taskService) UpdateSubtaskStatus(ctx context.Context, taskId string, subtaskId string, status bool) (model.Subtask, error) {
-	// Send the data to the repository layer to update the subtask status
-	subtask, err := s.taskRepository.UpdateSubtaskStatus(ctx, taskId, subtaskId, status)
+func (s *taskService) UpdateTaskRank(ctx context.Context, userId, taskId, status, afterId, beforeId string, override bool) (model.Task, []string, error) {
+	currentTask, err := s.taskRepository.GetTaskById(ctx, taskId)
 	if err != nil {
-		fmt.Printf("error: %+v", err)
-		return model.Subtask{}, err
+		return model.Task{}, nil, err
 	}
 
-	return subtask, nil
+	project, err := s.projectRepository.GetProjectById(ctx, currentTask.ProjectID)
+	if err != nil {
+		return model.Task{}, nil, err
+	}
+
+	if !isProjectMember(project, userId) {
+		return model.Task{}, nil, utils.ErrForbidden
+	}
+
+	if err = s.checkWipOverride(ctx, userId, currentTask, override); err != nil {
+		return model.Task{}, nil, err
+	}
+
+	// A task can be completed only after all of its blockers are completed
+	if status == model.TaskStatusCompleted && currentTask.Status != model.TaskStatusCompleted {
+		openBlockers, err := s.getOpenBlockers(ctx, []model.Task{currentTask})
+		if err != nil {
+			return model.Task{}, nil, err
+		}
+
+		if len(openBlockers[taskId]) > 0 {
+			return model.Task{}, nil, fmt.Errorf("%w: %v", utils.ErrBlockedTask, openBlockers[taskId])
+		}
+	}
+
+	// Send the data to the repository layer to move the task
+	return s.taskRepository.UpdateTaskRank(ctx, taskId, status, afterId, beforeId, override)
 }
 
-// UpdateResponseMessage retrieves the data from the controller layer and sends it to the repository layer
-// to update the response of a task
+// SetTaskReviewers retrieves the data from the controller layer and replaces the reviewers of a task.
+// Only the project manager and the author of the task can choose the reviewers and a handler cannot review the task
 //
 // Parameters:
 //   - ctx: Request-scoped context
-//   - taskId: The ID of the task the response is part of
-//   - responseId: The response ID
-//   - message: The text message of the response
+//   - userId: The ID of the user that sent the request
+//   - taskId: The ID of the task
+//   - reviewerIds: The IDs of the new reviewers, empty to remove the review from the task workflow
 //
 // Returns:
-//   - model.Response: The udpated data of the response
+//   - model.Task: The updated task data
 //   - error: An error that occured during the process
-func (s *taskService) UpdateResponseMessage(ctx context.Context, taskId string, responseId string, message string) (model.Response, error) {
-	// Send the data to the repository layer to update the text message of the response
-	response, err := s.taskRepository.UpdateResponseMessage(ctx, taskId, responseId, message)
+func (s *taskService) SetTaskReviewers(ctx context.Context, userId, taskId string, reviewerIds []string) (model.Task, error) {
+	currentTask, err := s.taskRepository.GetTaskById(ctx, taskId)
 	if err != nil {
-		fmt.Printf("%+v", err)
-		return model.Response{}, err
+		return model.Task{}, err
 	}
 
-	return response, nil
+	project, err := s.projectRepository.GetProjectById(ctx, currentTask.ProjectID)
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	if project.ProjectManagerID != userId && currentTask.AuthorID != userId {
+		return model.Task{}, utils.ErrForbidden
+	}
+
+	// The reviewers have to be part of the project and cannot review their own work
+	uniqueReviewerIds := []string{}
+	for _, reviewerId := range reviewerIds {
+		if !isProjectMember(project, reviewerId) {
+			return model.Task{}, fmt.Errorf("%w: user `%s` is not a member of the project", utils.ErrInvalidReview, reviewerId)
+		}
+
+		if slices.Contains(currentTask.HandlerIDs, reviewerId) {
+			return model.Task{}, fmt.Errorf("%w: user `%s` is a handler of the task", utils.ErrInvalidReview, reviewerId)
+		}
+
+		if !slices.Contains(uniqueReviewerIds, reviewerId) {
+			uniqueReviewerIds = append(uniqueReviewerIds, reviewerId)
+		}
+	}
+
+	// Send the data to the repository layer to replace the reviewers
+	return s.taskRepository.SetTaskReviewers(ctx, taskId, uniqueReviewerIds)
 }
 
-// RerollTaskVersion retrieves the data from the controller layer and sends it to the repository layer
-// to roll back to an older task version
+// RequestTaskReview retrieves the data from the controller layer and moves a task into the review.
+// Only the handlers of the task and the project manager can request a review
 //
 // Parameters:
 //   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
 //   - taskId: The ID of the task
-//   - task: The old task version
+//   - override: Whether the exceeded work in progress limits are ignored
 //
 // Returns:
-//   - model.Task: The updated task version
+//   - model.Task: The updated task data
+//   - []string: The work in progress limits that were overridden
 //   - error: An error that occured during the process
-func (s *taskService) RerollTaskVersion(ctx context.Context, taskId string, task model.Task) (model.Task, error) {
-	// Send the data to the repository layer to roll back the task version
+func (s *taskService) RequestTaskReview(ctx context.Context, userId, taskId string, override bool) (model.Task, []string, error) {
+	currentTask, err := s.taskRepository.GetTaskById(ctx, taskId)
+	if err != nil {
+		return model.Task{}, nil, err
+	}
+
+	project, err := s.projectRepository.GetProjectById(ctx, currentTask.ProjectID)
+	if err != nil {
+		return model.Task{}, nil, err
+	}
+
+	if project.ProjectManagerID != userId && !slices.Contains(currentTask.HandlerIDs, userId) {
+		return model.Task{}, nil, utils.ErrForbidden
+	}
+
+	if err = s.checkWipOverride(ctx, userId, currentTask, override); err != nil {
+		return model.Task{}, nil, err
+	}
+
+	// Send the data to the repository layer to move the task into the review
+	return s.taskRepository.RequestTaskReview(ctx, taskId, override)
+}
+
+// ApproveTaskReview retrieves the data from the controller layer and completes a task in review.
+// Only a reviewer of the task can approve it and the task cannot have open blockers
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the reviewer
+//   - taskId: The ID of the task
+//   - comment: The optional review comment
+//
+// Returns:
+//   - model.TaskReview: The completed task and the review comment
+//   - error: An error that occured during the process
+func (s *taskService) ApproveTaskReview(ctx context.Context, userId, taskId, comment string) (model.TaskReview, error) {
+	currentTask, err := s.taskRepository.GetTaskById(ctx, taskId)
+	if err != nil {
+		return model.TaskReview{}, err
+	}
+
+	// A task can be completed only after all of its blockers are completed
+	openBlockers, err := s.getOpenBlockers(ctx, []model.Task{currentTask})
+	if err != nil {
+		return model.TaskReview{}, err
+	}
+
+	if len(openBlockers[taskId]) > 0 {
+		return model.TaskReview{}, fmt.Errorf("%w: %v", utils.ErrBlockedTask, openBlockers[taskId])
+	}
+
+	return s.reviewTask(ctx, userId, taskId, true, comment)
+}
+
+// RejectTaskReview retrieves the data from the controller layer and sends a task in review back to development.
+// Only a reviewer of the task can reject it and the rejection has to explain what is missing
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the reviewer
+//   - taskId: The ID of the task
+//   - comment: The reason of the rejection
+//
+// Returns:
+//   - model.TaskReview: The rejected task and the review comment
+//   - error: An error that occured during the process
+func (s *taskService) RejectTaskReview(ctx context.Context, userId, taskId, comment string) (model.TaskReview, error) {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return model.TaskReview{}, fmt.Errorf("%w: a rejection needs a comment", utils.ErrInvalidReview)
+	}
+
+	return s.reviewTask(ctx, userId, taskId, false, comment)
+}
+
+// UpdateTaskPriority retrieves the data from the controller layer and sends it to the repository layer
+// to update the priority and the severity of the task
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - priority: The new task priority
+//   - severity: The new task severity, empty to remove it
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - error: An error that occured during the process
+func (s *taskService) UpdateTaskPriority(ctx context.Context, taskId string, priority string, severity string) (model.Task, error) {
+	// Check if the priority is valid
+	priorityRank, ok := model.PriorityRanks[priority]
+	if !ok {
+		return model.Task{}, fmt.Errorf("invalid priority `%s`", priority)
+	}
+
+	// Send the data to the repository layer to update the priority of the task
+	task, err := s.taskRepository.UpdateTaskPriority(ctx, taskId, priority, priorityRank, severity)
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	return task, nil
+}
+
+// AddTaskHandler retrieves the data from the controller layer and sends it to the repository layer to add handlers to a task
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - taskId: The ID of the task
+//   - handlerIds: The list of handler IDs to add to the task
+//   - override: Whether the exceeded work in progress limits are ignored
+//
+// Returns:
+//   - model.Task: The updated task data, with the absences of its handlers that cover the deadline
+//   - []string: The work in progress limits that were overridden
+//   - error: An error that occured during the process
+func (s *taskService) AddTaskHandlers(ctx context.Context, userId, taskId string, handlerIds []string, override bool) (model.Task, []string, error) {
+	if override {
+		currentTask, err := s.taskRepository.GetTaskById(ctx, taskId)
+		if err != nil {
+			return model.Task{}, nil, err
+		}
+
+		if err = s.checkWipOverride(ctx, userId, currentTask, override); err != nil {
+			return model.Task{}, nil, err
+		}
+	}
+
+	// Send the data to the repository layer to add handlers
+	task, exceeded, err := s.taskRepository.AddTaskHandlers(ctx, taskId, userId, handlerIds, override)
+	if err != nil {
+		return model.Task{}, nil, err
+	}
+
+	// Warn about the handlers that are absent on the deadline
+	if err = s.setAbsenceWarnings(ctx, &task); err != nil {
+		return model.Task{}, nil, err
+	}
+
+	return task, exceeded, nil
+}
+
+// AcceptTaskAssignment retrieves the data from the controller layer and accepts the assignment of a handler to a task
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the handler
+//   - taskId: The ID of the task
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - error: An error that occured during the process
+func (s *taskService) AcceptTaskAssignment(ctx context.Context, userId, taskId string) (model.Task, error) {
+	return s.taskRepository.AnswerTaskAssignment(ctx, taskId, userId, true, "")
+}
+
+// DeclineTaskAssignment retrieves the data from the controller layer and declines the assignment of a handler to a task.
+// The handler is removed from the task and the reason is kept with the assignment
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the handler
+//   - taskId: The ID of the task
+//   - reason: The reason of the decline
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - error: An error that occured during the process
+func (s *taskService) DeclineTaskAssignment(ctx context.Context, userId, taskId, reason string) (model.Task, error) {
+	if strings.TrimSpace(reason) == "" {
+		return model.Task{}, fmt.Errorf("%w: a decline needs a reason", utils.ErrInvalidAssignment)
+	}
+
+	return s.taskRepository.AnswerTaskAssignment(ctx, taskId, userId, false, reason)
+}
+
+// RemoveTaskHandlers retrieves data from the controller layer and sends it to the repository layer to remove handlers from the list
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - handlerIds: The list of handler IDs to be removed from the task
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - error: An error that occured during the process
+func (s *taskService) RemoveTaskHandlers(ctx context.Context, taskId string, handlerIds []string) (model.Task, error) {
+	// Send the data to the repository layer to remove the task handlers
+	task, err := s.taskRepository.RemoveTaskHandlers(ctx, taskId, handlerIds)
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	return task, nil
+}
+
+// AddTaskLabels retrieves the data from the controller layer, checks that every label
+// is part of the project label catalog and sends it to the repository layer to add the labels to the task
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - labels: The list of label names to add
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - error: An error that occured during the process
+func (s *taskService) AddTaskLabels(ctx context.Context, taskId string, labels []string) (model.Task, error) {
+	// Get the task data to find the project it is part of
+	task, err := s.taskRepository.GetTaskById(ctx, taskId)
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	// Get the label catalog of the project
+	catalog, err := s.projectRepository.GetProjectLabels(ctx, task.ProjectID)
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	// Check that every label is defined in the catalog
+	for _, label := range labels {
+		if !slices.ContainsFunc(catalog, func(catalogLabel model.Label) bool { return catalogLabel.Name == label }) {
+			return model.Task{}, fmt.Errorf("%w: `%s`", utils.ErrInvalidLabel, label)
+		}
+	}
+
+	// Send the data to the repository layer to add the labels
+	return s.taskRepository.AddTaskLabels(ctx, taskId, labels)
+}
+
+// RemoveTaskLabels retrieves the data from the controller layer and sends it to the repository layer to remove labels from the task
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - labels: The list of label names to remove
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - error: An error that occured during the process
+func (s *taskService) RemoveTaskLabels(ctx context.Context, taskId string, labels []string) (model.Task, error) {
+	// Send the data to the repository layer to remove the labels
+	task, err := s.taskRepository.RemoveTaskLabels(ctx, taskId, labels)
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	return task, nil
+}
+
+// AddTaskDependency retrieves the data from the controller layer and sends it to the repository layer
+// to mark a task as blocked by another task of the same project
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the blocked task
+//   - blockerId: The ID of the task that blocks it
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - error: An error that occured during the process
+func (s *taskService) AddTaskDependency(ctx context.Context, taskId, blockerId string) (model.Task, error) {
+	// A task cannot block itself
+	if taskId == blockerId {
+		return model.Task{}, fmt.Errorf("%w: a task cannot block itself", utils.ErrInvalidDependency)
+	}
+
+	// Send the data to the repository layer to add the dependency
+	return s.taskRepository.AddTaskDependency(ctx, taskId, blockerId)
+}
+
+// RemoveTaskDependency retrieves the data from the controller layer and sends it to the repository layer
+// to remove the dependency between two tasks
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the blocked task
+//   - blockerId: The ID of the task that blocks it
+//
+// Returns:
+//   - model.Task: The updated task data
+//   - error: An error that occured during the process
+func (s *taskService) RemoveTaskDependency(ctx context.Context, taskId, blockerId string) (model.Task, error) {
+	// Send the data to the repository layer to remove the dependency
+	task, err := s.taskRepository.RemoveTaskDependency(ctx, taskId, blockerId)
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	return task, nil
+}
+
+// AddTaskChildren retrieves the data from the controller layer and sends it to the repository layer
+// to attach a list of tasks to a parent task
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the parent task
+//   - childIds: The list of child task IDs
+//
+// Returns:
+//   - []model.Task: The list of attached tasks
+//   - error: An error that occured during the process
+func (s *taskService) AddTaskChildren(ctx context.Context, taskId string, childIds []string) ([]model.Task, error) {
+	// A task cannot be its own child
+	if slices.Contains(childIds, taskId) {
+		return nil, fmt.Errorf("%w: a task cannot be its own child", utils.ErrInvalidHierarchy)
+	}
+
+	// Remove the duplicate task IDs since a document can be written only once in a transaction
+	var uniqueChildIds []string
+	for _, childId := range childIds {
+		if !slices.Contains(uniqueChildIds, childId) {
+			uniqueChildIds = append(uniqueChildIds, childId)
+		}
+	}
+
+	// Send the data to the repository layer to attach the children
+	return s.taskRepository.AddTaskChildren(ctx, taskId, uniqueChildIds)
+}
+
+// RemoveTaskChildren retrieves the data from the controller layer and sends it to the repository layer
+// to detach a list of tasks from a parent task
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the parent task
+//   - childIds: The list of child task IDs
+//
+// Returns:
+//   - []model.Task: The list of detached tasks
+//   - error: An error that occured during the process
+func (s *taskService) RemoveTaskChildren(ctx context.Context, taskId string, childIds []string) ([]model.Task, error) {
+	// Send the data to the repository layer to detach the children
+	children, err := s.taskRepository.RemoveTaskChildren(ctx, taskId, childIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return children, nil
+}
+
+// MoveTask retrieves the data from the controller layer and moves a task to another project.
+// The user has to be part of both projects and the handlers and reviewers that are not members
+// of the target project are rejected, dropped or kept depending on the nonMembers option
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that moves the task
+//   - taskId: The ID of the task
+//   - projectId: The ID of the target project
+//   - nonMembers: How to handle the handlers that are not members of the target project
+//
+// Returns:
+//   - model.TaskMove: The moved task and the data that was dropped
+//   - error: An error that occured during the process
+func (s *taskService) MoveTask(ctx context.Context, userId, taskId, projectId, nonMembers string) (model.TaskMove, error) {
+	// Get the task data
+	task, err := s.taskRepository.GetTaskById(ctx, taskId)
+	if err != nil {
+		return model.TaskMove{}, err
+	}
+
+	if task.ProjectID == projectId {
+		return model.TaskMove{}, fmt.Errorf("%w: the task is already part of the project `%s`", utils.ErrInvalidMove, projectId)
+	}
+
+	// Check if the user is part of both projects
+	sourceProject, err := s.projectRepository.GetProjectById(ctx, task.ProjectID)
+	if err != nil {
+		return model.TaskMove{}, err
+	}
+
+	targetProject, err := s.projectRepository.GetProjectById(ctx, projectId)
+	if err != nil {
+		return model.TaskMove{}, err
+	}
+
+	if !isProjectMember(sourceProject, userId) || !isProjectMember(targetProject, userId) {
+		return model.TaskMove{}, utils.ErrForbidden
+	}
+
+	move := model.TaskMove{
+		FromProjectID:      sourceProject.ID,
+		ToProjectID:        targetProject.ID,
+		DroppedHandlerIDs:  []string{},
+		DroppedReviewerIDs: []string{},
+		DroppedLabels:      []string{},
+	}
+
+	// Check the handlers that are not members of the target project
+	handlerIds := []string{}
+	for _, handlerId := range task.HandlerIDs {
+		if isProjectMember(targetProject, handlerId) || nonMembers == model.NonMembersKeep {
+			handlerIds = append(handlerIds, handlerId)
+			continue
+		}
+
+		move.DroppedHandlerIDs = append(move.DroppedHandlerIDs, handlerId)
+	}
+
+	if len(move.DroppedHandlerIDs) > 0 && nonMembers != model.NonMembersDrop {
+		return model.TaskMove{}, fmt.Errorf("%w: %v", utils.ErrHandlersNotMembers, move.DroppedHandlerIDs)
+	}
+
+	// Check the reviewers that are not members of the target project
+	reviewerIds := []string{}
+	for _, reviewerId := range task.ReviewerIDs {
+		if isProjectMember(targetProject, reviewerId) || nonMembers == model.NonMembersKeep {
+			reviewerIds = append(reviewerIds, reviewerId)
+			continue
+		}
+
+		move.DroppedReviewerIDs = append(move.DroppedReviewerIDs, reviewerId)
+	}
+
+	if len(move.DroppedReviewerIDs) > 0 && nonMembers != model.NonMembersDrop {
+		return model.TaskMove{}, fmt.Errorf("%w: reviewers %v", utils.ErrHandlersNotMembers, move.DroppedReviewerIDs)
+	}
+
+	// Keep only the labels that are part of the target project catalog
+	catalog, err := s.projectRepository.GetProjectLabels(ctx, targetProject.ID)
+	if err != nil {
+		return model.TaskMove{}, err
+	}
+
+	labels := []string{}
+	for _, label := range task.Labels {
+		if slices.ContainsFunc(catalog, func(catalogLabel model.Label) bool { return catalogLabel.Name == label }) {
+			labels = append(labels, label)
+		} else {
+			move.DroppedLabels = append(move.DroppedLabels, label)
+		}
+	}
+
+	// Send the data to the repository layer to move the task
+	move.Task, err = s.taskRepository.MoveTask(ctx, taskId, sourceProject.ID, targetProject.ID, handlerIds, reviewerIds, labels)
+	if err != nil {
+		return model.TaskMove{}, err
+	}
+
+	// Remove the links to the tasks left in the source project
+	if err = s.taskRepository.ClearTaskDependencies(ctx, task); err != nil {
+		return model.TaskMove{}, err
+	}
+
+	if err = s.taskRepository.ClearTaskChildren(ctx, task.ID); err != nil {
+		return model.TaskMove{}, err
+	}
+
+	return move, nil
+}
+
+// PromoteSubtask retrieves the data from the controller layer and sends it to the repository layer
+// to convert a subtask into a task that is a child of the original task.
+// The deadline and the priority of the original task are used when they are not set
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task the subtask is part of
+//   - subtaskId: The ID of the subtask
+//   - deadline: The deadline of the new task, 0 to use the deadline of the original task
+//   - priority: The priority of the new task, empty to use the priority of the original task
+//   - keepSubtask: True to keep the subtask marked as promoted, false to remove it
+//
+// Returns:
+//   - model.SubtaskPromotion: The created task, the subtask and the updated original task
+//   - error: An error that occured during the process
+func (s *taskService) PromoteSubtask(ctx context.Context, taskId, subtaskId string, deadline int64, priority string, keepSubtask bool) (model.SubtaskPromotion, error) {
+	// Get the original task to fill in the missing data
+	parent, err := s.taskRepository.GetTaskById(ctx, taskId)
+	if err != nil {
+		return model.SubtaskPromotion{}, err
+	}
+
+	if deadline == 0 {
+		deadline = parent.Deadline
+	}
+
+	if priority == "" {
+		priority = parent.Priority
+	}
+	if _, ok := model.PriorityRanks[priority]; !ok {
+		priority = model.PriorityMedium
+	}
+
+	task := model.Task{
+		ID:           uuid.NewString(),
+		Deadline:     deadline,
+		CreatedAt:    time.Now().UnixMilli(),
+		Status:       model.TaskStatusNew,
+		Priority:     priority,
+		PriorityRank: model.PriorityRanks[priority],
+	}
+
+	// Send the data to the repository layer to promote the subtask
+	return s.taskRepository.PromoteSubtask(ctx, taskId, subtaskId, task, keepSubtask)
+}
+
+// UpdateSubtaskDescription retrieves the data from the controller layer and sends it to the repository layer
+//
+//	to update the description of the subtask
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task the subtask is part of
+//   - subtaskId: The ID of the subtask
+//   - description: The new subtask description
+//
+// Returns:
+//   - model.Subtask: The updated subtask data
+//   - error: An error that occured during the process
+func (s *taskService) UpdateSubtaskDescription(ctx context.Context, taskId string, subtaskId string, description string) (model.Subtask, error) {
+	// Send the data to the repository layer to update the description of the subtask
+	subtask, err := s.taskRepository.UpdateSubtaskDescription(ctx, taskId, subtaskId, description)
+	if err != nil {
+		return model.Subtask{}, err
+	}
+
+	return subtask, nil
+}
+
+// UpdateSubtaskHandler retrieves the data from the controller layer and sends it to the repository layer
+// to set a new handler for a subtask
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task the subtask is part of
+//   - subtaskId: The ID of the subtask
+//   - handlerId: The ID of the new subtask handler
+//
+// Returns:
+//   - model.Subtask: The updated subtask data
+//   - error: An error that occured during the process
+func (s *taskService) UpdateSubtaskHandler(ctx context.Context, taskId string, subtaskId string, handlerId string) (model.Subtask, error) {
+	// Send the data to the repository layer to update the subtask handler
+	subtask, err := s.taskRepository.UpdateSubtaskHandler(ctx, taskId, subtaskId, handlerId)
+	if err != nil {
+		return model.Subtask{}, err
+	}
+
+	return subtask, nil
+}
+
+// UpdateSubtaskStatus retrieves the data from the controller layer and sends it to the repository layer to update the subtask
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task the subtask is part of
+//   - subtaskId: The ID of the subtask
+//   - status: The new subtask status
+//
+// Returns:
+//   - model.Subtask: The updated subtask data
+//   - error: An error that occured during the process
+func (s *taskService) UpdateSubtaskStatus(ctx context.Context, taskId string, subtaskId string, status bool) (model.Subtask, error) {
+	// Send the data to the repository layer to update the subtask status
+	subtask, err := s.taskRepository.UpdateSubtaskStatus(ctx, taskId, subtaskId, status)
+	if err != nil {
+		fmt.Printf("error: %+v", err)
+		return model.Subtask{}, err
+	}
+
+	return subtask, nil
+}
+
+// UpdateResponseMessage retrieves the data from the controller layer and sends it to the repository layer
+// to update the response of a task
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task the response is part of
+//   - responseId: The response ID
+//   - message: The text message of the response
+//
+// Returns:
+//   - model.Response: The udpated data of the response
+//   - error: An error that occured during the process
+func (s *taskService) UpdateResponseMessage(ctx context.Context, taskId string, responseId string, message string) (model.Response, error) {
+	// Send the data to the repository layer to update the text message of the response
+	response, err := s.taskRepository.UpdateResponseMessage(ctx, taskId, responseId, message)
+	if err != nil {
+		fmt.Printf("%+v", err)
+		return model.Response{}, err
+	}
+
+	return response, nil
+}
+
+// RerollTaskVersion retrieves the data from the controller layer and sends it to the repository layer
+// to roll back to an older task version. A task can be rolled back to a completed version only after
+// all of its blockers are completed
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskId: The ID of the task
+//   - task: The old task version
+//
+// Returns:
+//   - model.Task: The updated task version
+//   - error: An error that occured during the process
+func (s *taskService) RerollTaskVersion(ctx context.Context, taskId string, task model.Task) (model.Task, error) {
+	// Check if the priority of the old version is valid
+	priorityRank, ok := model.PriorityRanks[task.Priority]
+	if !ok {
+		return model.Task{}, fmt.Errorf("invalid priority `%s`", task.Priority)
+	}
+	task.PriorityRank = priorityRank
+
+	// Check if the status of the old version is valid
+	if task.Status != "" && !slices.Contains(model.TaskStatuses, task.Status) {
+		return model.Task{}, fmt.Errorf("invalid status `%s`", task.Status)
+	}
+
+	if task.Status == model.TaskStatusCompleted {
+		currentTask, err := s.taskRepository.GetTaskById(ctx, taskId)
+		if err != nil {
+			return model.Task{}, err
+		}
+
+		if currentTask.Status != model.TaskStatusCompleted {
+			openBlockers, err := s.getOpenBlockers(ctx, []model.Task{currentTask})
+			if err != nil {
+				return model.Task{}, err
+			}
+
+			if len(openBlockers[taskId]) > 0 {
+				return model.Task{}, fmt.Errorf("%w: %v", utils.ErrBlockedTask, openBlockers[taskId])
+			}
+		}
+	}
+
+	// Send the data to the repository layer to roll back the task version
 	updatedTask, err := s.taskRepository.RerollTaskVersion(ctx, taskId, task)
 	if err != nil {
 		return model.Task{}, err
@@ -473,29 +1565,47 @@ func (s *taskService) RerollSubtaskVersion(ctx context.Context, taskId, subtaskI
 }
 
 // DeleteTaskById retrieves the data from the controller layer and sends it to the repository layer
-// to delete a task, its subtasks and responses
+// to delete a task. The subtasks, responses, work logs and status transitions are deleted in the background by a job
 //
 // Paramters:
 //   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
 //   - taskId: The ID of the task
 //
 // Returns:
 //   - model.Task: The data of the deleted task
+//   - model.Job: The job that deletes the remaining documents of the task
 //   - error: An error that occured during the process
-func (s *taskService) DeleteTaskById(ctx context.Context, taskId string) (model.Task, error) {
+func (s *taskService) DeleteTaskById(ctx context.Context, userId string, taskId string) (model.Task, model.Job, error) {
 	// Send the data to the repository layer to delete the task
 	task, err := s.taskRepository.DeleteTaskById(ctx, taskId)
 	if err != nil {
-		return model.Task{}, err
+		return model.Task{}, model.Job{}, err
+	}
+
+	// Remove the task from the dependencies of the linked tasks
+	if err = s.taskRepository.ClearTaskDependencies(ctx, task); err != nil {
+		return model.Task{}, model.Job{}, err
+	}
+
+	// Detach the children of the task
+	if err = s.taskRepository.ClearTaskChildren(ctx, task.ID); err != nil {
+		return model.Task{}, model.Job{}, err
 	}
 
-	// Delete the subtasks and responses subcollections
-	_, err = s.taskRepository.DeleteTaskSubcollections(ctx, taskId, 10)
+	// Start a job that deletes the subtasks, responses, work logs and status transitions of the task
+	job, err := s.jobService.EnqueueJob(
+		ctx,
+		model.JobTypeDeleteTaskSubcollections,
+		userId,
+		map[string]any{"taskId": task.ID},
+		task.SubtaskCount+task.ResponseCount,
+	)
 	if err != nil {
-		return model.Task{}, err
+		return model.Task{}, model.Job{}, err
 	}
 
-	return task, nil
+	return task, job, nil
 }
 
 // DeleteSubtaskById retrieves the data from the controller layer and sends it to the repository layer
@@ -539,3 +1649,630 @@ func (s *taskService) DeleteResponseById(ctx context.Context, taskId string, res
 
 	return response, nil
 }
+
+// DeleteTaskSubcollections is the job handler that deletes the subtasks, responses, work logs and status transitions of a deleted task.
+// The documents are deleted in batches, so a job that is resumed after a failure continues with the remaining documents
+//
+// Parameters:
+//   - ctx: The job context
+//   - job: The running job
+//   - progress: The job progress reporter
+//
+// Returns:
+//   - error: An error that occured during the process
+func (s *taskService) DeleteTaskSubcollections(ctx context.Context, job model.Job, progress interfaces.JobProgress) error {
+	// Get the ID of the deleted task from the job payload
+	taskId, ok := job.Payload["taskId"].(string)
+	if !ok || taskId == "" {
+		return fmt.Errorf("job %s has no task ID", job.ID)
+	}
+
+	// Continue from the progress of the previous attempts
+	processed := job.Processed
+
+	for {
+		// Send the data to the repository layer to delete the next batch of documents
+		deleted, err := s.taskRepository.DeleteTaskSubcollectionBatch(ctx, taskId, subcollectionBatchSize)
+		if err != nil {
+			return err
+		}
+
+		// Stop when all the documents of the task are deleted
+		if deleted == 0 {
+			return nil
+		}
+
+		// Report the progress and stop if the job was cancelled
+		processed += int64(deleted)
+		if err := progress(processed, job.Total); err != nil {
+			return err
+		}
+	}
+}
+
+// BulkUpdateTasks retrieves the data from the controller layer and sends it to the repository layer
+// to apply the same operation to a list of tasks. The user has to be part of the project of each task
+// and only the project managers can delete tasks or override the work in progress limits
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - taskIds: The list of task IDs
+//   - operation: The operation to apply to each task
+//
+// Returns:
+//   - model.BulkResult: The result of the operation for each task
+//   - error: An error that occured during the process
+func (s *taskService) BulkUpdateTasks(ctx context.Context, userId string, taskIds []string, operation model.BulkTaskOperation) (model.BulkResult, error) {
+	// Remove the duplicate task IDs since a document can be written only once in a batch
+	var uniqueTaskIds []string
+	for _, taskId := range taskIds {
+		if !slices.Contains(uniqueTaskIds, taskId) {
+			uniqueTaskIds = append(uniqueTaskIds, taskId)
+		}
+	}
+
+	result := model.BulkResult{Operation: operation.Operation, Tasks: []model.Task{}}
+
+	// Remove the tasks the user is not allowed to change
+	uniqueTaskIds, forbiddenResults, err := s.filterBulkTasks(ctx, userId, uniqueTaskIds, operation)
+	if err != nil {
+		return model.BulkResult{}, err
+	}
+
+	result.Results = forbiddenResults
+
+	if operation.Operation == model.BulkDelete {
+		// Send the data to the repository layer to delete the tasks
+		if len(uniqueTaskIds) > 0 {
+			tasks, results, err := s.taskRepository.BulkDeleteTasks(ctx, uniqueTaskIds)
+			if err != nil {
+				return model.BulkResult{}, err
+			}
+
+			result.Tasks = tasks
+			result.Results = append(result.Results, results...)
+		}
+
+		// Start a job for each deleted task to remove its subtasks, responses, work logs and status transitions
+		for _, task := range result.Tasks {
+			// Remove the task from the dependencies of the linked tasks
+			if err = s.taskRepository.ClearTaskDependencies(ctx, task); err != nil {
+				return model.BulkResult{}, err
+			}
+
+			// Detach the children of the task
+			if err = s.taskRepository.ClearTaskChildren(ctx, task.ID); err != nil {
+				return model.BulkResult{}, err
+			}
+
+			job, err := s.jobService.EnqueueJob(
+				ctx,
+				model.JobTypeDeleteTaskSubcollections,
+				userId,
+				map[string]any{"taskId": task.ID},
+				task.SubtaskCount+task.ResponseCount,
+			)
+			if err != nil {
+				return model.BulkResult{}, err
+			}
+
+			result.Jobs = append(result.Jobs, job)
+		}
+	} else {
+		// The tasks that still have open blockers cannot be completed
+		if operation.Operation == model.BulkSetStatus && operation.Status == model.TaskStatusCompleted {
+			var blockedResults []model.BulkItemResult
+			uniqueTaskIds, blockedResults, err = s.filterBlockedTasks(ctx, uniqueTaskIds)
+			if err != nil {
+				return model.BulkResult{}, err
+			}
+
+			result.Results = append(result.Results, blockedResults...)
+		}
+
+		// Send the data to the repository layer to update the tasks
+		if len(uniqueTaskIds) > 0 {
+			tasks, results, err := s.taskRepository.BulkUpdateTasks(ctx, userId, uniqueTaskIds, operation)
+			if err != nil {
+				return model.BulkResult{}, err
+			}
+
+			result.Tasks = tasks
+			result.Results = append(result.Results, results...)
+		}
+	}
+
+	// Count the successful and failed items
+	for _, itemResult := range result.Results {
+		if itemResult.Success {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+	}
+
+	return result, nil
+}
+
+// getOpenBlockers is a private method that returns the blockers of each task that are not completed
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - tasks: The list of tasks
+//
+// Returns:
+//   - map[string][]string: The IDs of the open blockers of each task
+//   - error: An error that occured during the process
+func (s *taskService) getOpenBlockers(ctx context.Context, tasks []model.Task) (map[string][]string, error) {
+	// Get the IDs of all the blockers with a single request
+	var blockerIds []string
+	for _, task := range tasks {
+		for _, blockerId := range task.BlockedBy {
+			if !slices.Contains(blockerIds, blockerId) {
+				blockerIds = append(blockerIds, blockerId)
+			}
+		}
+	}
+
+	blockers, err := s.taskRepository.GetTasksByIds(ctx, blockerIds)
+	if err != nil {
+		return nil, err
+	}
+
+	// The deleted blockers are no longer blocking
+	openBlockerIds := map[string]bool{}
+	for _, blocker := range blockers {
+		if blocker.Status != model.TaskStatusCompleted {
+			openBlockerIds[blocker.ID] = true
+		}
+	}
+
+	openBlockers := map[string][]string{}
+	for _, task := range tasks {
+		for _, blockerId := range task.BlockedBy {
+			if openBlockerIds[blockerId] {
+				openBlockers[task.ID] = append(openBlockers[task.ID], blockerId)
+			}
+		}
+	}
+
+	return openBlockers, nil
+}
+
+// filterBlockedTasks is a private method that removes the tasks with open blockers from a bulk completion
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - taskIds: The list of task IDs
+//
+// Returns:
+//   - []string: The IDs of the tasks that can be completed
+//   - []model.BulkItemResult: The failed result of each blocked task
+//   - error: An error that occured during the process
+func (s *taskService) filterBlockedTasks(ctx context.Context, taskIds []string) ([]string, []model.BulkItemResult, error) {
+	tasks, err := s.taskRepository.GetTasksByIds(ctx, taskIds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	openBlockers, err := s.getOpenBlockers(ctx, tasks)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var allowedIds []string
+	blockedResults := []model.BulkItemResult{}
+	for _, taskId := range taskIds {
+		if blockerIds := openBlockers[taskId]; len(blockerIds) > 0 {
+			blockedResults = append(blockedResults, model.BulkItemResult{
+				TaskID: taskId,
+				Error:  fmt.Sprintf("%s: %v", utils.ErrBlockedTask, blockerIds),
+			})
+			continue
+		}
+
+		allowedIds = append(allowedIds, taskId)
+	}
+
+	return allowedIds, blockedResults, nil
+}
+
+// handlerAssignments is a private function that generates the assignments of the handlers of a new task.
+// Each handler has to accept the assignment, the assigner accepts a self assignment right away
+//
+// Parameters:
+//   - handlerIds: The list of handler IDs
+//   - assignerId: The ID of the user that assigns the handlers
+//   - assignedAt: The date of the assignment
+//
+// Returns:
+//   - map[string]model.HandlerAssignment: The assignment of each handler
+func handlerAssignments(handlerIds []string, assignerId string, assignedAt int64) map[string]model.HandlerAssignment {
+	assignments := map[string]model.HandlerAssignment{}
+	for _, handlerId := range handlerIds {
+		assignment := model.HandlerAssignment{State: model.AssignmentPending, AssignedBy: assignerId, AssignedAt: assignedAt}
+		if handlerId == assignerId {
+			assignment.State = model.AssignmentAccepted
+			assignment.AnsweredAt = &assignedAt
+		}
+
+		assignments[handlerId] = assignment
+	}
+
+	return assignments
+}
+
+// filterBulkTasks is a private method that removes the tasks the user is not allowed to change from a bulk operation.
+// The user has to be part of the project of each task, the deletions and the overridden work in progress
+// limits are allowed only for the project manager. The tasks that are not found are left to the repository layer
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - taskIds: The list of task IDs
+//   - operation: The bulk operation
+//
+// Returns:
+//   - []string: The IDs of the tasks the user can change
+//   - []model.BulkItemResult: The failed result of each forbidden task
+//   - error: An error that occured during the process
+func (s *taskService) filterBulkTasks(ctx context.Context, userId string, taskIds []string, operation model.BulkTaskOperation) ([]string, []model.BulkItemResult, error) {
+	tasks, err := s.taskRepository.GetTasksByIds(ctx, taskIds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Get each project of the tasks only once
+	forbiddenTasks := map[string]string{}
+	projects := map[string]model.Project{}
+	for _, task := range tasks {
+		project, ok := projects[task.ProjectID]
+		if !ok {
+			if project, err = s.projectRepository.GetProjectById(ctx, task.ProjectID); err != nil {
+				return nil, nil, err
+			}
+
+			projects[task.ProjectID] = project
+		}
+
+		switch {
+		case !isProjectMember(project, userId):
+			forbiddenTasks[task.ID] = fmt.Sprintf("%s: user `%s` is not part of the project `%s`", utils.ErrForbidden, userId, project.ID)
+		case operation.Operation == model.BulkDelete && project.ProjectManagerID != userId:
+			forbiddenTasks[task.ID] = fmt.Sprintf("%s: only the project manager can delete tasks", utils.ErrForbidden)
+		case operation.Override && project.ProjectManagerID != userId:
+			forbiddenTasks[task.ID] = fmt.Sprintf("%s: only the project manager can override the work in progress limits", utils.ErrForbidden)
+		}
+	}
+
+	var allowedIds []string
+	forbiddenResults := []model.BulkItemResult{}
+	for _, taskId := range taskIds {
+		if reason, ok := forbiddenTasks[taskId]; ok {
+			forbiddenResults = append(forbiddenResults, model.BulkItemResult{TaskID: taskId, Error: reason})
+			continue
+		}
+
+		allowedIds = append(allowedIds, taskId)
+	}
+
+	return allowedIds, forbiddenResults, nil
+}
+
+// computeTaskProgress is a private function that computes the progress of a parent task from its children
+//
+// Parameters:
+//   - children: The list of child tasks
+//
+// Returns:
+//   - model.TaskProgress: The progress of the parent task
+func computeTaskProgress(children []model.Task) model.TaskProgress {
+	progress := model.TaskProgress{
+		Total:    len(children),
+		Statuses: map[string]int{},
+	}
+
+	for _, child := range children {
+		progress.Statuses[child.Status]++
+		if child.Status == model.TaskStatusCompleted {
+			progress.Completed++
+		}
+	}
+
+	if progress.Total > 0 {
+		progress.Percent = math.Round(float64(progress.Completed)/float64(progress.Total)*10000) / 100
+	}
+
+	return progress
+}
+
+// reviewTask is a private method that sends the decision of a reviewer to the repository layer.
+// The comment is stored as a response of the task
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the reviewer
+//   - taskId: The ID of the task
+//   - approved: Whether the reviewer approved the task
+//   - comment: The review comment, empty when the reviewer left no comment
+//
+// Returns:
+//   - model.TaskReview: The reviewed task and the review comment
+//   - error: An error that occured during the process
+func (s *taskService) reviewTask(ctx context.Context, userId, taskId string, approved bool, comment string) (model.TaskReview, error) {
+	var response *model.Response
+	if comment = strings.TrimSpace(comment); comment != "" {
+		response = &model.Response{
+			ID:        uuid.NewString(),
+			AuthorID:  userId,
+			TaskID:    taskId,
+			Message:   comment,
+			Timestamp: time.Now().UnixMilli(),
+		}
+	}
+
+	task, err := s.taskRepository.ReviewTask(ctx, taskId, userId, approved, response)
+	if err != nil {
+		return model.TaskReview{}, err
+	}
+
+	return model.TaskReview{Task: task, Response: response}, nil
+}
+
+// autoAssignHandler is a private method that picks the handler of a new task among the project members.
+// The round-robin mode picks the member that was assigned a task the longest time ago and the
+// least-loaded mode picks the most available member
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that creates the task
+//   - projectId: The ID of the project
+//   - deadline: The deadline of the new task
+//   - autoAssign: The auto assignment mode
+//
+// Returns:
+//   - string: The ID of the picked handler
+//   - error: An error that occured during the process
+func (s *taskService) autoAssignHandler(ctx context.Context, userId, projectId string, deadline int64, autoAssign string) (string, error) {
+	project, err := s.projectRepository.GetProjectById(ctx, projectId)
+	if err != nil {
+		return "", err
+	}
+
+	if !isProjectMember(project, userId) {
+		return "", utils.ErrForbidden
+	}
+
+	candidates, _, err := s.getHandlerCandidates(ctx, project, deadline)
+	if err != nil {
+		return "", err
+	}
+
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("%w: the project has no available members to assign", utils.ErrInvalidAssignment)
+	}
+
+	switch autoAssign {
+	case model.AutoAssignLeastLoaded:
+		return candidates[0].UserID, nil
+	case model.AutoAssignRoundRobin:
+		// The members that were never assigned come first, the first of the equal members is returned
+		next := slices.MinFunc(candidates, func(a, b model.HandlerCandidate) int {
+			return cmp.Compare(timeOrZero(a.LastAssignedAt), timeOrZero(b.LastAssignedAt))
+		})
+		return next.UserID, nil
+	default:
+		return "", fmt.Errorf("%w: unknown auto assignment mode `%s`", utils.ErrInvalidAssignment, autoAssign)
+	}
+}
+
+// getHandlerCandidates is a private method that measures the workload of the available project members and ranks them.
+// The members with fewer overdue tasks, fewer open tasks and smaller open estimates come first
+// and the most recently active member wins a tie
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - project: The project data
+//   - until: The end of the period the candidates have to be available in, only the current absences count when it is in the past
+//
+// Returns:
+//   - []model.HandlerCandidate: The ranked available project members
+//   - []string: The IDs of the members that are absent in the period
+//   - error: An error that occured during the process
+func (s *taskService) getHandlerCandidates(ctx context.Context, project model.Project, until int64) ([]model.HandlerCandidate, []string, error) {
+	tasks, err := s.taskRepository.GetProjectTasks(ctx, project.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	worklogs, err := s.worklogRepository.GetWorkLogs(ctx, model.WorkLogQuery{
+		ProjectID: project.ID,
+		From:      now.Add(-recentActivityWindow).UnixMilli(),
+		To:        now.UnixMilli(),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	absences, err := s.availabilityRepository.GetAbsences(ctx, project.ID, now.UnixMilli(), max(until, now.UnixMilli()))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	unavailableIds := []string{}
+	for _, absence := range absences {
+		if !slices.Contains(unavailableIds, absence.UserID) {
+			unavailableIds = append(unavailableIds, absence.UserID)
+		}
+	}
+
+	// Keep the available members in the order they joined the project
+	candidates := make([]model.HandlerCandidate, 0, len(project.MemberIDs))
+	positions := map[string]int{}
+	for _, memberId := range project.MemberIDs {
+		if _, ok := positions[memberId]; ok || slices.Contains(unavailableIds, memberId) {
+			continue
+		}
+
+		positions[memberId] = len(candidates)
+		candidates = append(candidates, model.HandlerCandidate{UserID: memberId})
+	}
+
+	for _, task := range tasks {
+		open := slices.Contains(model.OpenTaskStatuses, task.Status)
+		points, estimatedTime := taskEstimate(task)
+
+		for _, handlerId := range task.HandlerIDs {
+			position, ok := positions[handlerId]
+			if !ok {
+				continue
+			}
+			candidate := &candidates[position]
+
+			// The handlers assigned before the assignments were tracked count from the task creation
+			assignedAt := task.CreatedAt
+			if assignment, ok := task.Assignments[handlerId]; ok {
+				assignedAt = assignment.AssignedAt
+			}
+
+			if candidate.LastAssignedAt == nil || assignedAt > *candidate.LastAssignedAt {
+				candidate.LastAssignedAt = &assignedAt
+			}
+
+			if !open {
+				continue
+			}
+
+			candidate.OpenTasks++
+			candidate.OpenPoints += points
+			candidate.OpenEstimatedTime += estimatedTime
+			if task.Deadline > 0 && task.Deadline < now.UnixMilli() {
+				candidate.OverdueTasks++
+			}
+		}
+	}
+
+	for _, worklog := range worklogs {
+		position, ok := positions[worklog.UserID]
+		if !ok {
+			continue
+		}
+		candidate := &candidates[position]
+
+		candidate.RecentLoggedTime += worklog.Duration
+		if candidate.LastActiveAt == nil || worklog.EndedAt > *candidate.LastActiveAt {
+			endedAt := worklog.EndedAt
+			candidate.LastActiveAt = &endedAt
+		}
+	}
+
+	// The stable sort keeps the join order of the members that are equally available
+	slices.SortStableFunc(candidates, func(a, b model.HandlerCandidate) int {
+		return cmp.Or(
+			cmp.Compare(a.OverdueTasks, b.OverdueTasks),
+			cmp.Compare(a.OpenTasks, b.OpenTasks),
+			cmp.Compare(a.OpenPoints, b.OpenPoints),
+			cmp.Compare(a.OpenEstimatedTime, b.OpenEstimatedTime),
+			cmp.Compare(timeOrZero(b.LastActiveAt), timeOrZero(a.LastActiveAt)),
+		)
+	})
+
+	return candidates, unavailableIds, nil
+}
+
+// setAbsenceWarnings is a private method that adds the absences of the task handlers that cover the task deadline to the task
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - task: The task data
+//
+// Returns:
+//   - error: An error that occured during the process
+func (s *taskService) setAbsenceWarnings(ctx context.Context, task *model.Task) error {
+	absences, err := s.availabilityRepository.GetAbsences(ctx, task.ProjectID, task.Deadline, task.Deadline)
+	if err != nil {
+		return err
+	}
+
+	if warnings := absenceWarnings(absences, task.HandlerIDs, task.Deadline); len(warnings) > 0 {
+		task.AbsenceWarnings = warnings
+	}
+
+	return nil
+}
+
+// checkWipOverride is a private method that checks if a user can override the work in progress limits of the task project.
+// Only the project manager can override the limits
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - task: The task data
+//   - override: Whether the user asked to override the limits
+//
+// Returns:
+//   - error: An error that occured during the process
+func (s *taskService) checkWipOverride(ctx context.Context, userId string, task model.Task, override bool) error {
+	if !override {
+		return nil
+	}
+
+	project, err := s.projectRepository.GetProjectById(ctx, task.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	if project.ProjectManagerID != userId {
+		return fmt.Errorf("%w: only the project manager can override the work in progress limits", utils.ErrForbidden)
+	}
+
+	return nil
+}
+
+// timeOrZero is a private function that returns an optional date, zero when the date is missing
+//
+// Parameters:
+//   - value: The optional date
+//
+// Returns:
+//   - int64: The date or zero
+func timeOrZero(value *int64) int64 {
+	if value == nil {
+		return 0
+	}
+
+	return *value
+}
+
+// isProjectMember is a private function that checks if a user is the manager or a member of a project
+//
+// Parameters:
+//   - project: The project data
+//   - userId: The ID of the user
+//
+// Returns:
+//   - bool: True if the user is part of the project and false otherwise
+func isProjectMember(project model.Project, userId string) bool {
+	return project.ProjectManagerID == userId || slices.Contains(project.MemberIDs, userId)
+}
+
+// earliestDeadline is a private function that returns the closest deadline of the work from a project
+//
+// Parameters:
+//   - projectWork: The sorted work of the project
+//
+// Returns:
+//   - int64: The closest deadline
+func earliestDeadline(projectWork model.ProjectWork) int64 {
+	deadline := int64(math.MaxInt64)
+	if len(projectWork.Tasks) > 0 {
+		deadline = projectWork.Tasks[0].Deadline
+	}
+	if len(projectWork.Subtasks) > 0 && projectWork.Subtasks[0].Task.Deadline < deadline {
+		deadline = projectWork.Subtasks[0].Task.Deadline
+	}
+
+	return deadline
+}
@@ -0,0 +1,370 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+// templateVariablePattern matches the `{{variable}}` placeholders of a task template
+var templateVariablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+
+type templateService struct {
+	templateRepository interfaces.TemplateRepository
+	taskRepository     interfaces.TaskRepository
+	projectRepository  interfaces.ProjectRepository
+}
+
+func NewTemplateService(templateRepository interfaces.TemplateRepository, taskRepository interfaces.TaskRepository, projectRepository interfaces.ProjectRepository) interfaces.TemplateService {
+	return &templateService{templateRepository: templateRepository, taskRepository: taskRepository, projectRepository: projectRepository}
+}
+
+// CreateTemplate retrieves the data from the controller layer and sends a new task template to the repository layer.
+// Only the project manager can create the templates of a project
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - template: The template data with the project, name, descriptions, priority, severity and labels
+//
+// Returns:
+//   - model.TaskTemplate: The created template
+//   - error: An error that occured during the process
+func (s *templateService) CreateTemplate(ctx context.Context, userId string, template model.TaskTemplate) (model.TaskTemplate, error) {
+	// Check if the user is the manager of the project
+	project, err := s.projectRepository.GetProjectById(ctx, template.ProjectID)
+	if err != nil {
+		return model.TaskTemplate{}, err
+	}
+
+	if project.ProjectManagerID != userId {
+		return model.TaskTemplate{}, utils.ErrForbidden
+	}
+
+	// Check if the labels are part of the project label catalog
+	catalog, err := s.projectRepository.GetProjectLabels(ctx, project.ID)
+	if err != nil {
+		return model.TaskTemplate{}, err
+	}
+
+	for _, label := range template.Labels {
+		if !slices.ContainsFunc(catalog, func(catalogLabel model.Label) bool { return catalogLabel.Name == label }) {
+			return model.TaskTemplate{}, fmt.Errorf("%w: `%s`", utils.ErrInvalidLabel, label)
+		}
+	}
+
+	// Set the default priority of the template
+	if template.Priority == "" {
+		template.Priority = model.PriorityMedium
+	}
+
+	if template.Labels == nil {
+		template.Labels = []string{}
+	}
+
+	if template.Subtasks == nil {
+		template.Subtasks = []model.TemplateSubtask{}
+	}
+
+	template.ID = uuid.NewString()
+	template.AuthorID = userId
+	template.CreatedAt = time.Now().UnixMilli()
+	template.Variables = templateVariables(template)
+
+	// Send the data to the repository layer to create the template
+	return s.templateRepository.CreateTemplate(ctx, template)
+}
+
+// CreateTemplateFromTask retrieves the data from the controller layer and saves a task and its subtasks
+// as a new template of the task project
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - taskId: The ID of the task used as blueprint
+//   - name: The name of the template
+//
+// Returns:
+//   - model.TaskTemplate: The created template
+//   - error: An error that occured during the process
+func (s *templateService) CreateTemplateFromTask(ctx context.Context, userId, taskId, name string) (model.TaskTemplate, error) {
+	// Get the task and all its subtasks
+	task, err := s.taskRepository.GetTaskById(ctx, taskId)
+	if err != nil {
+		return model.TaskTemplate{}, err
+	}
+
+	subtasks, _, err := s.taskRepository.GetSubtasks(ctx, taskId, 0, "")
+	if err != nil {
+		return model.TaskTemplate{}, err
+	}
+
+	template := model.TaskTemplate{
+		ProjectID:   task.ProjectID,
+		Name:        name,
+		Description: task.Description,
+		Priority:    task.Priority,
+		Severity:    task.Severity,
+		Labels:      task.Labels,
+		Subtasks:    []model.TemplateSubtask{},
+	}
+
+	for _, subtask := range subtasks {
+		template.Subtasks = append(template.Subtasks, model.TemplateSubtask{Description: subtask.Description})
+	}
+
+	return s.CreateTemplate(ctx, userId, template)
+}
+
+// InstantiateTemplate retrieves the data from the controller layer and creates a new task with its subtasks
+// from a template. The placeholders of the template are replaced with the given variables
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - templateId: The ID of the template
+//   - variables: The values of the template placeholders
+//   - deadline: The deadline of the new task
+//   - handlerIds: The handlers of the new task
+//   - subtaskHandlerIds: The handlers of the new subtasks in the order of the template subtasks, empty to leave a subtask unassigned
+//
+// Returns:
+//   - model.TaskDetails: The created task and its subtasks
+//   - error: An error that occured during the process
+func (s *templateService) InstantiateTemplate(ctx context.Context, userId, templateId string, variables map[string]string, deadline int64, handlerIds, subtaskHandlerIds []string) (model.TaskDetails, error) {
+	// Get the template and check if the user is part of its project
+	template, project, err := s.getTemplateProject(ctx, userId, templateId)
+	if err != nil {
+		return model.TaskDetails{}, err
+	}
+
+	// Check if all the placeholders have a value
+	missing := []string{}
+	for _, variable := range template.Variables {
+		if _, ok := variables[variable]; !ok {
+			missing = append(missing, variable)
+		}
+	}
+
+	if len(missing) > 0 {
+		return model.TaskDetails{}, fmt.Errorf("%w: %v", utils.ErrMissingTemplateVariables, missing)
+	}
+
+	if len(subtaskHandlerIds) > len(template.Subtasks) {
+		return model.TaskDetails{}, fmt.Errorf("the template has only %d subtasks", len(template.Subtasks))
+	}
+
+	// Check if the handlers are part of the project
+	nonMembers := []string{}
+	for _, handlerId := range slices.Concat(handlerIds, subtaskHandlerIds) {
+		if handlerId != "" && !isProjectMember(project, handlerId) && !slices.Contains(nonMembers, handlerId) {
+			nonMembers = append(nonMembers, handlerId)
+		}
+	}
+
+	if len(nonMembers) > 0 {
+		return model.TaskDetails{}, fmt.Errorf("%w: %v", utils.ErrHandlersNotMembers, nonMembers)
+	}
+
+	// Keep only the labels that are still part of the project catalog
+	catalog, err := s.projectRepository.GetProjectLabels(ctx, project.ID)
+	if err != nil {
+		return model.TaskDetails{}, err
+	}
+
+	labels := []string{}
+	for _, label := range template.Labels {
+		if slices.ContainsFunc(catalog, func(catalogLabel model.Label) bool { return catalogLabel.Name == label }) {
+			labels = append(labels, label)
+		}
+	}
+
+	now := time.Now().UnixMilli()
+
+	details := model.TaskDetails{
+		Task: model.Task{
+			ID:           uuid.NewString(),
+			AuthorID:     userId,
+			ProjectID:    project.ID,
+			HandlerIDs:   handlerIds,
+			Description:  renderTemplate(template.Description, variables),
+			Status:       model.TaskStatusNew,
+			Deadline:     deadline,
+			CreatedAt:    now,
+			SubtaskCount: int64(len(template.Subtasks)),
+			Priority:     template.Priority,
+			PriorityRank: model.PriorityRanks[template.Priority],
+			Severity:     template.Severity,
+			Labels:       labels,
+			Assignments:  handlerAssignments(handlerIds, userId, now),
+		},
+		Subtasks:  []model.Subtask{},
+		Responses: []model.Response{},
+	}
+
+	// Create the subtasks in the order of the template
+	for index, templateSubtask := range template.Subtasks {
+		handlerId := ""
+		if index < len(subtaskHandlerIds) {
+			handlerId = subtaskHandlerIds[index]
+		}
+
+		details.Subtasks = append(details.Subtasks, model.Subtask{
+			ID:          uuid.NewString(),
+			TaskID:      details.Task.ID,
+			AuthorID:    userId,
+			HandlerID:   handlerId,
+			Description: renderTemplate(templateSubtask.Description, variables),
+			CreatedAt:   now + int64(index),
+			Done:        false,
+		})
+	}
+
+	// Send the data to the repository layer to create the task
+	return s.taskRepository.CreateTaskDetails(ctx, details)
+}
+
+// GetProjectTemplates retrieves the data from the controller layer and returns the templates of a project
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - projectId: The ID of the project
+//
+// Returns:
+//   - []model.TaskTemplate: The list of project templates
+//   - error: An error that occured during the process
+func (s *templateService) GetProjectTemplates(ctx context.Context, userId, projectId string) ([]model.TaskTemplate, error) {
+	// Check if the user is part of the project
+	project, err := s.projectRepository.GetProjectById(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isProjectMember(project, userId) {
+		return nil, utils.ErrForbidden
+	}
+
+	return s.templateRepository.GetProjectTemplates(ctx, projectId)
+}
+
+// GetTemplateById retrieves the data from the controller layer and returns the data of a template
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - templateId: The ID of the template
+//
+// Returns:
+//   - model.TaskTemplate: The data of the template
+//   - error: An error that occured during the process
+func (s *templateService) GetTemplateById(ctx context.Context, userId, templateId string) (model.TaskTemplate, error) {
+	template, _, err := s.getTemplateProject(ctx, userId, templateId)
+	return template, err
+}
+
+// DeleteTemplate retrieves the data from the controller layer and sends it to the repository layer to delete a template.
+// Only the project manager can delete the templates of a project
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - templateId: The ID of the template
+//
+// Returns:
+//   - model.TaskTemplate: The data of the deleted template
+//   - error: An error that occured during the process
+func (s *templateService) DeleteTemplate(ctx context.Context, userId, templateId string) (model.TaskTemplate, error) {
+	template, project, err := s.getTemplateProject(ctx, userId, templateId)
+	if err != nil {
+		return model.TaskTemplate{}, err
+	}
+
+	if project.ProjectManagerID != userId {
+		return model.TaskTemplate{}, utils.ErrForbidden
+	}
+
+	if err = s.templateRepository.DeleteTemplate(ctx, templateId); err != nil {
+		return model.TaskTemplate{}, err
+	}
+
+	return template, nil
+}
+
+// getTemplateProject is a private method that returns a template and its project
+// after checking that the user is part of the project
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - templateId: The ID of the template
+//
+// Returns:
+//   - model.TaskTemplate: The data of the template
+//   - model.Project: The data of the template project
+//   - error: An error that occured during the process
+func (s *templateService) getTemplateProject(ctx context.Context, userId, templateId string) (model.TaskTemplate, model.Project, error) {
+	template, err := s.templateRepository.GetTemplateById(ctx, templateId)
+	if err != nil {
+		return model.TaskTemplate{}, model.Project{}, err
+	}
+
+	project, err := s.projectRepository.GetProjectById(ctx, template.ProjectID)
+	if err != nil {
+		return model.TaskTemplate{}, model.Project{}, err
+	}
+
+	if !isProjectMember(project, userId) {
+		return model.TaskTemplate{}, model.Project{}, utils.ErrForbidden
+	}
+
+	return template, project, nil
+}
+
+// templateVariables is a private function that returns the names of the placeholders
+// used by the descriptions of a template, in the order they first appear
+//
+// Parameters:
+//   - template: The template data
+//
+// Returns:
+//   - []string: The list of placeholder names
+func templateVariables(template model.TaskTemplate) []string {
+	texts := []string{template.Description}
+	for _, subtask := range template.Subtasks {
+		texts = append(texts, subtask.Description)
+	}
+
+	variables := []string{}
+	for _, text := range texts {
+		for _, match := range templateVariablePattern.FindAllStringSubmatch(text, -1) {
+			if !slices.Contains(variables, match[1]) {
+				variables = append(variables, match[1])
+			}
+		}
+	}
+
+	return variables
+}
+
+// renderTemplate is a private function that replaces the placeholders of a text with their values
+//
+// Parameters:
+//   - text: The text with placeholders
+//   - variables: The values of the placeholders
+//
+// Returns:
+//   - string: The text with the placeholders replaced
+func renderTemplate(text string, variables map[string]string) string {
+	return templateVariablePattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := templateVariablePattern.FindStringSubmatch(placeholder)[1]
+		return variables[name]
+	})
+}
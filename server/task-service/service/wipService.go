@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+type wipService struct {
+	wipRepository     interfaces.WipRepository
+	projectRepository interfaces.ProjectRepository
+}
+
+func NewWipService(wipRepository interfaces.WipRepository, projectRepository interfaces.ProjectRepository) interfaces.WipService {
+	return &wipService{wipRepository: wipRepository, projectRepository: projectRepository}
+}
+
+// GetWipLimits retrieves the data from the controller layer and returns the work in progress limits of a project
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - projectId: The ID of the project
+//
+// Returns:
+//   - model.WipLimits: The work in progress limits of the project
+//   - error: An error that occured during the process
+func (s *wipService) GetWipLimits(ctx context.Context, userId, projectId string) (model.WipLimits, error) {
+	project, err := s.projectRepository.GetProjectById(ctx, projectId)
+	if err != nil {
+		return model.WipLimits{}, err
+	}
+
+	if !isProjectMember(project, userId) {
+		return model.WipLimits{}, utils.ErrForbidden
+	}
+
+	return s.wipRepository.GetWipLimits(ctx, projectId)
+}
+
+// SetWipLimits retrieves the data from the controller layer and replaces the work in progress limits of a project.
+// Only the project manager can change the limits of the project
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - projectId: The ID of the project
+//   - statusLimits: The max number of project tasks of each status
+//   - handlerLimits: The max number of tasks of each status assigned to a single handler
+//
+// Returns:
+//   - model.WipLimits: The new work in progress limits of the project
+//   - error: An error that occured during the process
+func (s *wipService) SetWipLimits(ctx context.Context, userId, projectId string, statusLimits, handlerLimits map[string]int) (model.WipLimits, error) {
+	project, err := s.projectRepository.GetProjectById(ctx, projectId)
+	if err != nil {
+		return model.WipLimits{}, err
+	}
+
+	if project.ProjectManagerID != userId {
+		return model.WipLimits{}, utils.ErrForbidden
+	}
+
+	if statusLimits == nil {
+		statusLimits = map[string]int{}
+	}
+
+	if handlerLimits == nil {
+		handlerLimits = map[string]int{}
+	}
+
+	limits := model.WipLimits{
+		ProjectID:     projectId,
+		StatusLimits:  statusLimits,
+		HandlerLimits: handlerLimits,
+		UpdatedBy:     userId,
+		UpdatedAt:     time.Now().UnixMilli(),
+	}
+
+	// Send the data to the repository layer to replace the limits
+	return s.wipRepository.SetWipLimits(ctx, limits)
+}
@@ -0,0 +1,344 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/horatiucrisan/task-service/interfaces"
+	"github.com/horatiucrisan/task-service/model"
+	"github.com/horatiucrisan/task-service/utils"
+)
+
+// The max duration of a single work log
+const maxWorkLogDuration = 24 * time.Hour
+
+type worklogService struct {
+	worklogRepository interfaces.WorkLogRepository
+	taskRepository    interfaces.TaskRepository
+	projectRepository interfaces.ProjectRepository
+}
+
+func NewWorkLogService(worklogRepository interfaces.WorkLogRepository, taskRepository interfaces.TaskRepository, projectRepository interfaces.ProjectRepository) interfaces.WorkLogService {
+	return &worklogService{worklogRepository: worklogRepository, taskRepository: taskRepository, projectRepository: projectRepository}
+}
+
+// CreateWorkLog retrieves the data from the controller layer and logs the time a user spent on a task or on a subtask.
+// The entry is described either by its start and end dates or by its duration, in which case it ends now
+// unless a start date is provided
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - taskId: The ID of the task
+//   - entry: The subtask, the dates, the duration and the note of the work log
+//
+// Returns:
+//   - model.WorkLog: The created work log
+//   - error: An error that occured during the process
+func (s *worklogService) CreateWorkLog(ctx context.Context, userId, taskId string, entry model.WorkLog) (model.WorkLog, error) {
+	task, err := s.getWorkLogTask(ctx, userId, taskId, entry.SubtaskID)
+	if err != nil {
+		return model.WorkLog{}, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	// Complete the missing dates of the entry
+	switch {
+	case entry.StartedAt != 0 && entry.EndedAt != 0:
+		if entry.Duration != 0 && entry.Duration != entry.EndedAt-entry.StartedAt {
+			return model.WorkLog{}, fmt.Errorf("%w: the duration does not match the start and end dates", utils.ErrInvalidWorkLog)
+		}
+		entry.Duration = entry.EndedAt - entry.StartedAt
+	case entry.Duration != 0 && entry.StartedAt != 0:
+		entry.EndedAt = entry.StartedAt + entry.Duration
+	case entry.Duration != 0:
+		entry.EndedAt = now
+		entry.StartedAt = now - entry.Duration
+	default:
+		return model.WorkLog{}, fmt.Errorf("%w: either the start and end dates or the duration are required", utils.ErrInvalidWorkLog)
+	}
+
+	if err := validateWorkLog(entry, now); err != nil {
+		return model.WorkLog{}, err
+	}
+
+	worklog := model.WorkLog{
+		ID:        uuid.NewString(),
+		ProjectID: task.ProjectID,
+		TaskID:    task.ID,
+		SubtaskID: entry.SubtaskID,
+		UserID:    userId,
+		StartedAt: entry.StartedAt,
+		EndedAt:   entry.EndedAt,
+		Duration:  entry.Duration,
+		Note:      entry.Note,
+		CreatedAt: now,
+	}
+
+	// Send the data to the repository layer to create the work log
+	return s.worklogRepository.CreateWorkLog(ctx, worklog)
+}
+
+// GetTaskWorkLogs retrieves the data from the controller layer and returns the work logs of a task
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - taskId: The ID of the task
+//
+// Returns:
+//   - []model.WorkLog: The list of task work logs
+//   - error: An error that occured during the process
+func (s *worklogService) GetTaskWorkLogs(ctx context.Context, userId, taskId string) ([]model.WorkLog, error) {
+	if _, err := s.getWorkLogTask(ctx, userId, taskId, ""); err != nil {
+		return nil, err
+	}
+
+	return s.worklogRepository.GetTaskWorkLogs(ctx, taskId)
+}
+
+// DeleteWorkLog retrieves the data from the controller layer and removes a work log.
+// Only the author of the work log and the project manager can remove it
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - taskId: The ID of the task
+//   - worklogId: The ID of the work log
+//
+// Returns:
+//   - model.WorkLog: The deleted work log
+//   - error: An error that occured during the process
+func (s *worklogService) DeleteWorkLog(ctx context.Context, userId, taskId, worklogId string) (model.WorkLog, error) {
+	worklog, err := s.worklogRepository.GetWorkLogById(ctx, worklogId)
+	if err != nil {
+		return model.WorkLog{}, err
+	}
+
+	if worklog.TaskID != taskId {
+		return model.WorkLog{}, fmt.Errorf("work log with ID %s not found", worklogId)
+	}
+
+	if worklog.UserID != userId {
+		project, err := s.projectRepository.GetProjectById(ctx, worklog.ProjectID)
+		if err != nil {
+			return model.WorkLog{}, err
+		}
+
+		if project.ProjectManagerID != userId {
+			return model.WorkLog{}, utils.ErrForbidden
+		}
+	}
+
+	return s.worklogRepository.DeleteWorkLog(ctx, worklogId)
+}
+
+// StartTimer retrieves the data from the controller layer and starts the timer of a user on a task or on a subtask
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - taskId: The ID of the task
+//   - subtaskId: The ID of the subtask, empty when the time is logged on the task
+//   - note: The note of the work log created when the timer stops
+//
+// Returns:
+//   - model.Timer: The started timer
+//   - error: An error that occured during the process
+func (s *worklogService) StartTimer(ctx context.Context, userId, taskId, subtaskId, note string) (model.Timer, error) {
+	task, err := s.getWorkLogTask(ctx, userId, taskId, subtaskId)
+	if err != nil {
+		return model.Timer{}, err
+	}
+
+	timer := model.Timer{
+		UserID:    userId,
+		ProjectID: task.ProjectID,
+		TaskID:    task.ID,
+		SubtaskID: subtaskId,
+		Note:      note,
+		StartedAt: time.Now().UnixMilli(),
+	}
+
+	return s.worklogRepository.StartTimer(ctx, timer)
+}
+
+// GetTimer retrieves the data from the controller layer and returns the running timer of a user
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//
+// Returns:
+//   - model.Timer: The running timer
+//   - error: An error that occured during the process
+func (s *worklogService) GetTimer(ctx context.Context, userId string) (model.Timer, error) {
+	return s.worklogRepository.GetTimer(ctx, userId)
+}
+
+// StopTimer retrieves the data from the controller layer and stops the running timer of a user.
+// The elapsed time is logged on the task of the timer
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - note: The note of the work log, the note of the timer is kept when empty
+//
+// Returns:
+//   - model.WorkLog: The created work log
+//   - error: An error that occured during the process
+func (s *worklogService) StopTimer(ctx context.Context, userId, note string) (model.WorkLog, error) {
+	return s.worklogRepository.StopTimer(ctx, userId, func(timer model.Timer) (model.WorkLog, error) {
+		now := time.Now().UnixMilli()
+
+		worklog := model.WorkLog{
+			ID:        uuid.NewString(),
+			ProjectID: timer.ProjectID,
+			TaskID:    timer.TaskID,
+			SubtaskID: timer.SubtaskID,
+			UserID:    timer.UserID,
+			StartedAt: timer.StartedAt,
+			EndedAt:   now,
+			Duration:  now - timer.StartedAt,
+			Note:      timer.Note,
+			CreatedAt: now,
+		}
+
+		if note != "" {
+			worklog.Note = note
+		}
+
+		// A forgotten timer is capped instead of logging days of work
+		if worklog.Duration > maxWorkLogDuration.Milliseconds() {
+			worklog.Duration = maxWorkLogDuration.Milliseconds()
+			worklog.EndedAt = worklog.StartedAt + worklog.Duration
+		}
+
+		if worklog.Duration <= 0 {
+			return model.WorkLog{}, fmt.Errorf("%w: the timer was stopped right after it started", utils.ErrInvalidWorkLog)
+		}
+
+		return worklog, nil
+	})
+}
+
+// GetTimeReport retrieves the data from the controller layer and returns the time logged inside a date range.
+// The project manager can see the time of every project member, the other users can only see their own time
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user that sent the request
+//   - worklogQuery: The project, the user and the date range of the report
+//
+// Returns:
+//   - model.TimeReport: The work logs of the range and their totals per user and task
+//   - error: An error that occured during the process
+func (s *worklogService) GetTimeReport(ctx context.Context, userId string, worklogQuery model.WorkLogQuery) (model.TimeReport, error) {
+	if worklogQuery.ProjectID == "" && worklogQuery.UserID == "" {
+		worklogQuery.UserID = userId
+	}
+
+	if worklogQuery.UserID != userId {
+		if worklogQuery.ProjectID == "" {
+			return model.TimeReport{}, utils.ErrForbidden
+		}
+
+		project, err := s.projectRepository.GetProjectById(ctx, worklogQuery.ProjectID)
+		if err != nil {
+			return model.TimeReport{}, err
+		}
+
+		if project.ProjectManagerID != userId {
+			return model.TimeReport{}, utils.ErrForbidden
+		}
+	}
+
+	worklogs, err := s.worklogRepository.GetWorkLogs(ctx, worklogQuery)
+	if err != nil {
+		return model.TimeReport{}, err
+	}
+
+	report := model.TimeReport{Query: worklogQuery, Totals: []model.TimeTotal{}, WorkLogs: worklogs}
+
+	// Sum the logged time of every user on every task
+	totals := map[[2]string]int64{}
+	for _, worklog := range worklogs {
+		report.Duration += worklog.Duration
+		totals[[2]string{worklog.UserID, worklog.TaskID}] += worklog.Duration
+	}
+
+	for key, duration := range totals {
+		report.Totals = append(report.Totals, model.TimeTotal{UserID: key[0], TaskID: key[1], Duration: duration})
+	}
+
+	slices.SortFunc(report.Totals, func(a, b model.TimeTotal) int {
+		return cmp.Or(cmp.Compare(a.UserID, b.UserID), cmp.Compare(a.TaskID, b.TaskID))
+	})
+
+	return report, nil
+}
+
+// getWorkLogTask is a private method that returns a task the user can log time on.
+// The user has to be part of the project and the subtask, when provided, has to belong to the task
+//
+// Parameters:
+//   - ctx: Request-scoped context
+//   - userId: The ID of the user
+//   - taskId: The ID of the task
+//   - subtaskId: The ID of the subtask, empty to skip the check
+//
+// Returns:
+//   - model.Task: The data of the task
+//   - error: An error that occured during the process
+func (s *worklogService) getWorkLogTask(ctx context.Context, userId, taskId, subtaskId string) (model.Task, error) {
+	task, err := s.taskRepository.GetTaskById(ctx, taskId)
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	project, err := s.projectRepository.GetProjectById(ctx, task.ProjectID)
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	if !isProjectMember(project, userId) {
+		return model.Task{}, utils.ErrForbidden
+	}
+
+	if subtaskId != "" {
+		if _, err := s.taskRepository.GetSubtaskById(ctx, taskId, subtaskId); err != nil {
+			return model.Task{}, err
+		}
+	}
+
+	return task, nil
+}
+
+// validateWorkLog is a private function that checks the dates and the duration of a work log
+//
+// Parameters:
+//   - worklog: The work log object
+//   - now: The current timestamp
+//
+// Returns:
+//   - error: An error that occured during the process
+func validateWorkLog(worklog model.WorkLog, now int64) error {
+	if worklog.Duration <= 0 {
+		return fmt.Errorf("%w: the work log has to end after it starts", utils.ErrInvalidWorkLog)
+	}
+
+	if worklog.Duration > maxWorkLogDuration.Milliseconds() {
+		return fmt.Errorf("%w: a work log cannot be longer than %s", utils.ErrInvalidWorkLog, maxWorkLogDuration)
+	}
+
+	if worklog.EndedAt > now {
+		return fmt.Errorf("%w: a work log cannot end in the future", utils.ErrInvalidWorkLog)
+	}
+
+	return nil
+}
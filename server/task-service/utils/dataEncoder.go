@@ -1,9 +1,11 @@
 package utils
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/horatiucrisan/task-service/model"
 )
@@ -33,3 +35,75 @@ func EncodeData(w http.ResponseWriter, r *http.Request, data any) error {
 
 	return nil
 }
+
+// EncodePaginatedData encodes a page of data together with the cursor of the next page
+//
+// Parameters:
+//   - w: The response writer of the controller method
+//   - r: The http request
+//   - data: The data to be encoded
+//   - nextCursor: The cursor of the next page, empty if there are no more pages
+func EncodePaginatedData(w http.ResponseWriter, r *http.Request, data any, nextCursor string) error {
+	// Set the header type and the return status
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	encodedData := model.EncodedResponse{
+		Success:    true,
+		Message:    "Data encoded successfully",
+		Data:       data,
+		NextCursor: nextCursor,
+	}
+
+	// Encode the data into the JSON format and return it
+	if err := json.NewEncoder(w).Encode(encodedData); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EncodeCSV writes the rows into the response as a downloadable CSV file
+//
+// Parameters:
+//   - w: The response writer of the controller method
+//   - r: The http request
+//   - filename: The name of the downloaded file
+//   - rows: The header row followed by the data rows
+func EncodeCSV(w http.ResponseWriter, r *http.Request, filename string, rows [][]string) error {
+	// Set the header type and the return status
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	// Neutralize the cells a spreadsheet would evaluate as formulas
+	escapedRows := make([][]string, len(rows))
+	for i, row := range rows {
+		escapedRows[i] = make([]string, len(row))
+		for j, cell := range row {
+			escapedRows[i][j] = escapeCSVCell(cell)
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(escapedRows); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// escapeCSVCell is a private function that prefixes a cell with a quote when it starts like a spreadsheet formula
+//
+// Parameters:
+//   - cell: The cell value
+//
+// Returns:
+//   - string: The escaped cell value
+func escapeCSVCell(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+
+	return cell
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ParseDurations retrieves a comma separated list of durations, such as `24h,1h,30m`
+//
+// Parameters:
+//   - value: The list of durations
+//
+// Returns:
+//   - []time.Duration: The list of positive durations, nil when the list is empty
+//   - error: An error that occured during the process
+func ParseDurations(value string) ([]time.Duration, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var durations []time.Duration
+	for _, item := range strings.Split(value, ",") {
+		duration, err := time.ParseDuration(strings.TrimSpace(item))
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration `%s`: %w", item, err)
+		}
+
+		if duration <= 0 {
+			return nil, fmt.Errorf("invalid duration `%s`: the duration must be positive", item)
+		}
+
+		durations = append(durations, duration)
+	}
+
+	return durations, nil
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"errors"
+
+	cursor "github.com/horatiucrisan/cursor-lib"
+)
+
+// ErrJobCancelled is returned by the job progress reporter when the job was cancelled by the user
+var ErrJobCancelled = errors.New("job cancelled")
+
+// ErrJobLeaseLost is returned when a worker updates a job that is no longer running under its claim
+var ErrJobLeaseLost = errors.New("job lease lost")
+
+// ErrInvalidCursor is returned when a pagination cursor was altered or belongs to another ordering
+var ErrInvalidCursor = cursor.ErrInvalidCursor
+
+// ErrInvalidSearchQuery is returned when a search query has no words to look for
+var ErrInvalidSearchQuery = errors.New("search query has no searchable words")
+
+// ErrForbidden is returned when the user has no access to the requested resource
+var ErrForbidden = errors.New("forbidden")
+
+// ErrInvalidLabel is returned when a task label is not part of the project label catalog
+var ErrInvalidLabel = errors.New("label is not part of the project label catalog")
+
+// ErrInvalidDependency is returned when a task dependency links a task to itself or to a task from another project
+var ErrInvalidDependency = errors.New("invalid task dependency")
+
+// ErrDependencyCycle is returned when a task dependency would make a task block itself
+var ErrDependencyCycle = errors.New("task dependency would create a cycle")
+
+// ErrBlockedTask is returned when a task is completed while some of its blockers are still open
+var ErrBlockedTask = errors.New("task is blocked by open tasks")
+
+// ErrInvalidHierarchy is returned when a task is attached to itself or to a parent from another project
+var ErrInvalidHierarchy = errors.New("invalid task hierarchy")
+
+// ErrHierarchyCycle is returned when a task would become a descendant of itself
+var ErrHierarchyCycle = errors.New("task hierarchy would create a cycle")
+
+// ErrInvalidMove is returned when a task is moved to the project it is already part of
+var ErrInvalidMove = errors.New("invalid task move")
+
+// ErrHandlersNotMembers is returned when a task is moved to a project its handlers are not part of
+var ErrHandlersNotMembers = errors.New("task handlers are not members of the target project")
+
+// ErrMissingTemplateVariables is returned when a task template is instantiated without the values of all its placeholders
+var ErrMissingTemplateVariables = errors.New("missing task template variables")
+
+// ErrInvalidRecurrence is returned when a recurrence rule cannot produce a next occurrence or the task is already recurring
+var ErrInvalidRecurrence = errors.New("invalid recurrence rule")
+
+// ErrSeriesStopped is returned when a stopped task series is edited
+var ErrSeriesStopped = errors.New("task series was stopped")
+
+// ErrInvalidWorkLog is returned when a work log has no duration or ends before it starts
+var ErrInvalidWorkLog = errors.New("invalid work log")
+
+// ErrTimerRunning is returned when a user starts a timer while another one is running
+var ErrTimerRunning = errors.New("a timer is already running")
+
+// ErrNoTimer is returned when a user stops a timer while none is running
+var ErrNoTimer = errors.New("no timer is running")
+
+// ErrInvalidSprint is returned when a sprint ends before it starts or a task is planned into a sprint of another project
+var ErrInvalidSprint = errors.New("invalid sprint")
+
+// ErrInvalidTimeWindow is returned when a time window is longer than the max window of the metric
+var ErrInvalidTimeWindow = errors.New("invalid time window")
+
+// ErrInvalidRank is returned when the neighbors of a moved task are not next to each other in the target column
+var ErrInvalidRank = errors.New("invalid board position")
+
+// ErrWipLimitExceeded is returned when a task change would exceed a work in progress limit of the project
+var ErrWipLimitExceeded = errors.New("work in progress limit exceeded")
+
+// ErrReviewRequired is returned when a task with reviewers is completed without an approved review
+var ErrReviewRequired = errors.New("task completion requires an approved review")
+
+// ErrInvalidReview is returned when a review is requested or decided for a task that is not in the matching state
+var ErrInvalidReview = errors.New("invalid task review")
+
+// ErrInvalidAssignment is returned when a user answers a task assignment that is not waiting for their answer
+var ErrInvalidAssignment = errors.New("invalid task assignment")
+
+// ErrInvalidAbsence is returned when an absence ends before it starts or is set for a user outside the project
+var ErrInvalidAbsence = errors.New("invalid absence")
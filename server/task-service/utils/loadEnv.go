@@ -3,6 +3,7 @@ package utils
 import (
 	"os"
 
+	cursor "github.com/horatiucrisan/cursor-lib"
 	"github.com/joho/godotenv"
 )
 
@@ -11,6 +12,21 @@ type env struct {
 	TASKS_COLLECTION       string
 	TASKS_SUBCOLLECTION    string
 	RESPONSES_COLLECTION   string
+	JOBS_COLLECTION        string
+	PROJECTS_COLLECTION    string
+	LABELS_SUBCOLLECTION   string
+	ABSENCES_SUBCOLLECTION string
+	TEMPLATES_COLLECTION   string
+	SERIES_COLLECTION      string
+	WORKLOGS_COLLECTION    string
+	TIMERS_COLLECTION      string
+	SPRINTS_COLLECTION     string
+	TRANSITIONS_COLLECTION string
+	WIP_LIMITS_COLLECTION  string
+	CURSOR_SECRET          string
+	REMINDER_OFFSETS       string
+	ESCALATION_GRACE       string
+	ANALYTICS_CACHE_TTL    string
 	RABBITMQ_URL           string
 	ROUTE                  string
 	PORT                   string
@@ -22,6 +38,9 @@ type env struct {
 
 var EnvInstances *env
 
+// CursorSigner signs and checks the pagination cursors with the cursor secret
+var CursorSigner *cursor.Signer
+
 // LoadEnv retrieves the data fron the local env file and generates a new EnvInstance object with it
 //
 // Returns:
@@ -38,6 +57,21 @@ func LoadEnv() error {
 		TASKS_COLLECTION:       os.Getenv("TASKS"),
 		TASKS_SUBCOLLECTION:    os.Getenv("SUBTASKS"),
 		RESPONSES_COLLECTION:   os.Getenv("RESPONSES"),
+		JOBS_COLLECTION:        os.Getenv("JOBS"),
+		PROJECTS_COLLECTION:    os.Getenv("PROJECTS"),
+		LABELS_SUBCOLLECTION:   os.Getenv("LABELS"),
+		ABSENCES_SUBCOLLECTION: os.Getenv("ABSENCES"),
+		TEMPLATES_COLLECTION:   os.Getenv("TEMPLATES"),
+		SERIES_COLLECTION:      os.Getenv("SERIES"),
+		WORKLOGS_COLLECTION:    os.Getenv("WORKLOGS"),
+		TIMERS_COLLECTION:      os.Getenv("TIMERS"),
+		SPRINTS_COLLECTION:     os.Getenv("SPRINTS"),
+		TRANSITIONS_COLLECTION: os.Getenv("STATUS_TRANSITIONS"),
+		WIP_LIMITS_COLLECTION:  os.Getenv("WIP_LIMITS"),
+		CURSOR_SECRET:          os.Getenv("CURSOR_SECRET"),
+		REMINDER_OFFSETS:       os.Getenv("REMINDER_OFFSETS"),
+		ESCALATION_GRACE:       os.Getenv("ESCALATION_GRACE"),
+		ANALYTICS_CACHE_TTL:    os.Getenv("ANALYTICS_CACHE_TTL"),
 		RABBITMQ_URL:           os.Getenv("RABBITMQ_URL"),
 		ROUTE:                  os.Getenv("ROUTE"),
 		PORT:                   os.Getenv("PORT"),
@@ -47,5 +81,12 @@ func LoadEnv() error {
 		RABBITMQ_VERSIONS:      os.Getenv("RABBITMQ_VERSIONS"),
 	}
 
+	// Refuse to start without a cursor secret, the cursors signed with an empty key could be forged
+	signer, err := cursor.NewSigner(EnvInstances.CURSOR_SECRET)
+	if err != nil {
+		return err
+	}
+	CursorSigner = signer
+
 	return nil
 }
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ParseListParam retrieves a list query parameter.
+// The values can be sent as repeated parameters or as a comma separated list
+//
+// Parameters:
+//   - r: The http request
+//   - name: The name of the query parameter
+//
+// Returns:
+//   - []string: The list of values
+func ParseListParam(r *http.Request, name string) []string {
+	var values []string
+	for _, param := range r.URL.Query()[name] {
+		for _, value := range strings.Split(param, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+
+	return values
+}
+
+// ParseInt64Param retrieves an optional numeric query parameter
+//
+// Parameters:
+//   - r: The http request
+//   - name: The name of the query parameter
+//
+// Returns:
+//   - *int64: The value of the parameter, nil if the parameter was not sent
+//   - error: An error that occured during the process
+func ParseInt64Param(r *http.Request, name string) (*int64, error) {
+	param := r.URL.Query().Get(name)
+	if param == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid `%s` value: %s", name, param)
+	}
+
+	return &value, nil
+}
+
+// ParseBoolParam retrieves an optional boolean query parameter
+//
+// Parameters:
+//   - r: The http request
+//   - name: The name of the query parameter
+//
+// Returns:
+//   - *bool: The value of the parameter, nil if the parameter was not sent
+//   - error: An error that occured during the process
+func ParseBoolParam(r *http.Request, name string) (*bool, error) {
+	param := r.URL.Query().Get(name)
+	if param == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseBool(param)
+	if err != nil {
+		return nil, fmt.Errorf("invalid `%s` value: %s", name, param)
+	}
+
+	return &value, nil
+}